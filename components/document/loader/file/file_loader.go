@@ -23,6 +23,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
@@ -35,14 +36,46 @@ const (
 	MetaKeyFileName  = "_file_name"
 	MetaKeyExtension = "_extension"
 	MetaKeySource    = "_source"
+
+	// MetaKeyRelativePath and MetaKeyModTime are only set when loading a directory or glob source.
+	MetaKeyRelativePath = "_relative_path" // slash-separated path relative to the directory, or to the non-glob part of a glob
+	MetaKeyModTime      = "_mod_time"      // modification time of the file, as time.Time
+)
+
+// SymlinkPolicy decides how symbolic links met while walking a directory or glob source are handled.
+// A single file source is always opened through its links.
+type SymlinkPolicy int
+
+const (
+	// SymlinkSkip ignores symbolic links, this is the default.
+	SymlinkSkip SymlinkPolicy = iota
+	// SymlinkFollow follows symbolic links to files and directories, wherever they point.
+	// Directories already visited are not walked twice, so link cycles terminate.
+	SymlinkFollow
+	// SymlinkFollowWithinRoot follows symbolic links only if their target is inside the walked directory.
+	SymlinkFollowWithinRoot
 )
 
 type FileLoaderConfig struct {
-	UseNameAsID bool
+	UseNameAsID bool // for directory and glob sources, the relative path is used as the name
 	Parser      parser.Parser
+
+	// The following fields apply when the source URI is a directory, or a glob such as ./docs/**/*.md.
+	// Each matched file is parsed with Parser, which by default is an ExtParser choosing by file extension.
+
+	// Recursive walks subdirectories of a directory source. A glob source descends as far as its pattern requires.
+	Recursive bool
+	// IncludePatterns keeps only the files matching any of the patterns, empty keeps all files.
+	// A pattern containing "/" is matched against the slash-separated relative path and may use "**"
+	// to match any number of directories, otherwise it is matched against the base name, e.g. "*.pdf".
+	IncludePatterns []string
+	// ExcludePatterns drops the files and directories matching any of the patterns, in the same syntax as IncludePatterns.
+	ExcludePatterns []string
+	// SymlinkPolicy decides how symbolic links are handled, default to SymlinkSkip.
+	SymlinkPolicy SymlinkPolicy
 }
 
-// FileLoader loads a local file and use its content directly as Document's content.
+// FileLoader loads a local file, or all files of a directory or glob, and uses their content as Document's content.
 type FileLoader struct {
 	FileLoaderConfig
 }
@@ -65,6 +98,12 @@ func NewFileLoader(ctx context.Context, config *FileLoaderConfig) (*FileLoader,
 		config.Parser = parser
 	}
 
+	for _, pattern := range append(append([]string{}, config.IncludePatterns...), config.ExcludePatterns...) {
+		if err := validatePattern(pattern); err != nil {
+			return nil, err
+		}
+	}
+
 	return &FileLoader{FileLoaderConfig: *config}, nil
 }
 
@@ -80,51 +119,150 @@ func (f *FileLoader) Load(ctx context.Context, src document.Source, opts ...docu
 		}
 	}()
 
-	file, err := openFile(src.URI)
+	if f.Parser == nil {
+		return nil, errors.New("no parser specified")
+	}
+
+	o := document.GetLoaderCommonOptions(&document.LoaderOptions{}, opts...)
+
+	if root, pattern, ok := multiFileSource(src.URI); ok {
+		docs, err = f.loadFiles(ctx, root, pattern, o.ParserOptions)
+	} else {
+		docs, err = f.loadSingleFile(ctx, src.URI, o.ParserOptions)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
+		Source: src,
+		Docs:   docs,
+	})
+
+	return docs, nil
+}
+
+func (f *FileLoader) loadSingleFile(ctx context.Context, uri string, parserOpts []parser.Option) ([]*schema.Document, error) {
+	file, err := openFile(uri)
 	if err != nil {
 		return nil, err
 	}
 
 	defer file.Close()
 
-	name := filepath.Base(src.URI)
-	ext := filepath.Ext(src.URI)
+	name := filepath.Base(uri)
+	ext := filepath.Ext(uri)
 
 	meta := map[string]any{
 		MetaKeyExtension: ext,
 		MetaKeyFileName:  name,
-		MetaKeySource:    src.URI,
+		MetaKeySource:    uri,
 	}
 
-	if f.Parser == nil {
-		return nil, errors.New("no parser specified")
+	docs, err := f.Parser.Parse(ctx, file, append([]parser.Option{parser.WithURI(uri), parser.WithExtraMeta(meta)}, parserOpts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("file parse err of [%s]: %w", uri, err)
 	}
 
-	o := document.GetLoaderCommonOptions(&document.LoaderOptions{}, opts...)
+	if f.UseNameAsID {
+		setIDs(docs, name)
+	}
 
-	docs, err = f.Parser.Parse(ctx, file, append([]parser.Option{parser.WithURI(src.URI), parser.WithExtraMeta(meta)}, o.ParserOptions...)...)
+	return docs, nil
+}
+
+// loadFiles loads the files under root matching pattern, in lexical order of their relative paths.
+func (f *FileLoader) loadFiles(ctx context.Context, root, pattern string, parserOpts []parser.Option) ([]*schema.Document, error) {
+	w, err := newWalker(root, pattern, &f.FileLoaderConfig)
 	if err != nil {
-		return nil, fmt.Errorf("file parse err of [%s]: %w", src.URI, err)
+		return nil, err
 	}
 
-	if f.UseNameAsID {
-		if len(docs) == 1 {
-			docs[0].ID = name
-		} else {
-			for idx, doc := range docs {
-				doc.ID = fmt.Sprintf("%s_%d", name, idx)
-			}
+	files, err := w.walk()
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []*schema.Document
+	for _, file := range files {
+		fileDocs, err := f.loadWalkedFile(ctx, file, parserOpts)
+		if err != nil {
+			return nil, err
 		}
+		docs = append(docs, fileDocs...)
 	}
 
-	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
-		Source: src,
-		Docs:   docs,
-	})
+	return docs, nil
+}
+
+func (f *FileLoader) loadWalkedFile(ctx context.Context, file walkedFile, parserOpts []parser.Option) ([]*schema.Document, error) {
+	reader, err := os.Open(file.path)
+	if err != nil {
+		return nil, fmt.Errorf("file loader open file path failed with err: %w, path= %s", err, file.path)
+	}
+	defer reader.Close()
+
+	meta := map[string]any{
+		MetaKeyExtension:    filepath.Ext(file.path),
+		MetaKeyFileName:     filepath.Base(file.path),
+		MetaKeySource:       file.path,
+		MetaKeyRelativePath: file.rel,
+		MetaKeyModTime:      file.modTime,
+	}
+
+	docs, err := f.Parser.Parse(ctx, reader, append([]parser.Option{parser.WithURI(file.path), parser.WithExtraMeta(meta)}, parserOpts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("file parse err of [%s]: %w", file.path, err)
+	}
+
+	if f.UseNameAsID {
+		setIDs(docs, file.rel)
+	}
 
 	return docs, nil
 }
 
+// multiFileSource reports whether uri is a directory or a glob, and splits it into
+// the directory to walk and the glob pattern relative to it, if any.
+func multiFileSource(uri string) (root, pattern string, ok bool) {
+	if len(uri) == 0 {
+		return "", "", false
+	}
+
+	if info, err := os.Stat(uri); err == nil {
+		return uri, "", info.IsDir()
+	}
+
+	if !hasMeta(uri) {
+		return "", "", false
+	}
+
+	segments := strings.Split(filepath.ToSlash(uri), "/")
+	i := 0
+	for ; i < len(segments) && !hasMeta(segments[i]); i++ {
+	}
+
+	root = filepath.FromSlash(strings.Join(segments[:i], "/"))
+	if i == 1 && segments[0] == "" {
+		root = string(filepath.Separator)
+	}
+	if root == "" {
+		root = "."
+	}
+
+	return root, strings.Join(segments[i:], "/"), true
+}
+
+func setIDs(docs []*schema.Document, name string) {
+	if len(docs) == 1 {
+		docs[0].ID = name
+	} else {
+		for idx, doc := range docs {
+			doc.ID = fmt.Sprintf("%s_%d", name, idx)
+		}
+	}
+}
+
 func (f *FileLoader) GetType() string {
 	return "FileLoader"
 }
@@ -18,11 +18,16 @@ package file
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
 )
 
 func TestFileLoader_Load(t *testing.T) {
@@ -49,3 +54,147 @@ func TestFileLoader_Load(t *testing.T) {
 		assert.Equal(t, "./testdata/test.md", docs[0].MetaData[MetaKeySource])
 	})
 }
+
+// writeTree creates the files under root, with their path as content.
+func writeTree(t *testing.T, root string, files ...string) {
+	for _, file := range files {
+		p := filepath.Join(root, filepath.FromSlash(file))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(file), 0o644))
+	}
+}
+
+func relPaths(docs []*schema.Document) []string {
+	var res []string
+	for _, doc := range docs {
+		res = append(res, doc.MetaData[MetaKeyRelativePath].(string))
+	}
+	return res
+}
+
+func TestFileLoader_LoadDir(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	writeTree(t, root,
+		"a.md",
+		"b.txt",
+		"docs/c.md",
+		"docs/deep/d.md",
+		"node_modules/e.md",
+	)
+
+	t.Run("flat", func(t *testing.T) {
+		loader, err := NewFileLoader(ctx, &FileLoaderConfig{UseNameAsID: true})
+		require.NoError(t, err)
+
+		docs, err := loader.Load(ctx, document.Source{URI: root})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a.md", "b.txt"}, relPaths(docs))
+
+		assert.Equal(t, "a.md", docs[0].Content)
+		assert.Equal(t, "a.md", docs[0].ID)
+		assert.Equal(t, ".md", docs[0].MetaData[MetaKeyExtension])
+		assert.Equal(t, filepath.Join(root, "a.md"), docs[0].MetaData[MetaKeySource])
+		info, err := os.Stat(filepath.Join(root, "a.md"))
+		require.NoError(t, err)
+		assert.Equal(t, info.ModTime(), docs[0].MetaData[MetaKeyModTime].(time.Time))
+	})
+
+	t.Run("recursive with include and exclude", func(t *testing.T) {
+		loader, err := NewFileLoader(ctx, &FileLoaderConfig{
+			UseNameAsID:     true,
+			Recursive:       true,
+			IncludePatterns: []string{"*.md"},
+			ExcludePatterns: []string{"node_modules", "docs/deep/**"},
+		})
+		require.NoError(t, err)
+
+		docs, err := loader.Load(ctx, document.Source{URI: root})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a.md", "docs/c.md"}, relPaths(docs))
+		assert.Equal(t, "docs/c.md", docs[1].ID)
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := NewFileLoader(ctx, &FileLoaderConfig{IncludePatterns: []string{"["}})
+		assert.Error(t, err)
+	})
+}
+
+func TestFileLoader_LoadGlob(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	writeTree(t, root,
+		"a.md",
+		"docs/b.md",
+		"docs/c.txt",
+		"docs/deep/d.md",
+	)
+
+	loader, err := NewFileLoader(ctx, &FileLoaderConfig{})
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		glob string
+		want []string
+	}{
+		{"*.md", []string{"a.md"}},
+		{"docs/*.md", []string{"b.md"}},
+		{"*/*.md", []string{"docs/b.md"}},
+		{"**/*.md", []string{"a.md", "docs/b.md", "docs/deep/d.md"}},
+		{"docs/**/*.md", []string{"b.md", "deep/d.md"}},
+		{"*.pdf", nil},
+	} {
+		t.Run(tt.glob, func(t *testing.T) {
+			docs, err := loader.Load(ctx, document.Source{URI: filepath.Join(root, tt.glob)})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, relPaths(docs))
+		})
+	}
+
+	t.Run("root of relative glob", func(t *testing.T) {
+		for uri, want := range map[string]string{
+			"*.md":          ".",
+			"docs/*/x":      "docs",
+			"/abs/**/x.md":  filepath.FromSlash("/abs"),
+			"/*.md":         string(filepath.Separator),
+			"./testdata/**": "testdata",
+		} {
+			r, _, ok := multiFileSource(uri)
+			assert.True(t, ok)
+			assert.Equal(t, want, filepath.Clean(r), uri)
+		}
+	})
+}
+
+func TestFileLoader_LoadSymlinks(t *testing.T) {
+	ctx := context.Background()
+	base := t.TempDir()
+	root := filepath.Join(base, "root")
+	writeTree(t, base, "root/a.md", "root/sub/b.md", "outside/c.md")
+
+	if err := os.Symlink(filepath.Join(root, "sub"), filepath.Join(root, "inner")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	require.NoError(t, os.Symlink(filepath.Join(base, "outside"), filepath.Join(root, "outer")))
+	require.NoError(t, os.Symlink(root, filepath.Join(root, "sub", "loop")))
+
+	for _, tt := range []struct {
+		name   string
+		policy SymlinkPolicy
+		want   []string
+	}{
+		{"skip", SymlinkSkip, []string{"a.md", "sub/b.md"}},
+		{"follow", SymlinkFollow, []string{"a.md", "inner/b.md", "outer/c.md"}},
+		{"follow within root", SymlinkFollowWithinRoot, []string{"a.md", "inner/b.md"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			loader, err := NewFileLoader(ctx, &FileLoaderConfig{Recursive: true, SymlinkPolicy: tt.policy})
+			require.NoError(t, err)
+
+			docs, err := loader.Load(ctx, document.Source{URI: root})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, relPaths(docs))
+		})
+	}
+}
@@ -0,0 +1,237 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package file
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type walkedFile struct {
+	path    string // path to open, root joined with rel
+	rel     string // slash-separated path relative to the root
+	modTime time.Time
+}
+
+// walker lists the files of a directory or glob source.
+type walker struct {
+	root     string
+	realRoot string // root with symbolic links resolved, for SymlinkFollowWithinRoot
+	pattern  string // glob relative to root, empty for a directory source
+	maxDepth int    // max depth of directories to descend into, -1 for unlimited
+
+	includes []string
+	excludes []string
+	symlinks SymlinkPolicy
+	visited  map[string]bool // real paths of the directories walked, to break symbolic link cycles
+}
+
+func newWalker(root, pattern string, conf *FileLoaderConfig) (*walker, error) {
+	if err := validatePattern(pattern); pattern != "" && err != nil {
+		return nil, err
+	}
+
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return nil, fmt.Errorf("file loader resolve root failed with err: %w, root= %s", err, root)
+	}
+	if realRoot, err = filepath.Abs(realRoot); err != nil {
+		return nil, fmt.Errorf("file loader resolve root failed with err: %w, root= %s", err, root)
+	}
+
+	w := &walker{
+		root:     root,
+		realRoot: realRoot,
+		pattern:  pattern,
+		includes: conf.IncludePatterns,
+		excludes: conf.ExcludePatterns,
+		symlinks: conf.SymlinkPolicy,
+		visited:  map[string]bool{realRoot: true},
+	}
+
+	switch {
+	case pattern == "" && conf.Recursive, strings.Contains(pattern, "**"):
+		w.maxDepth = -1
+	case pattern == "":
+		w.maxDepth = 0
+	default:
+		w.maxDepth = strings.Count(pattern, "/")
+	}
+
+	return w, nil
+}
+
+func (w *walker) walk() ([]walkedFile, error) {
+	var files []walkedFile
+	if err := w.walkDir(w.root, "", 0, &files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func (w *walker) walkDir(dir, rel string, depth int, files *[]walkedFile) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("file loader read dir failed with err: %w, path= %s", err, dir)
+	}
+
+	for _, entry := range entries {
+		entryPath := filepath.Join(dir, entry.Name())
+		entryRel := path.Join(rel, entry.Name())
+
+		info, err := entry.Info()
+		if err != nil {
+			return fmt.Errorf("file loader stat failed with err: %w, path= %s", err, entryPath)
+		}
+
+		if info.Mode()&fs.ModeSymlink != 0 {
+			var follow bool
+			if info, follow = w.followSymlink(entryPath); !follow {
+				continue
+			}
+		}
+
+		if matchAny(w.excludes, entryRel) {
+			continue
+		}
+
+		if info.IsDir() {
+			if w.maxDepth >= 0 && depth >= w.maxDepth {
+				continue
+			}
+			if realDir, err := filepath.EvalSymlinks(entryPath); err == nil {
+				if realDir, err = filepath.Abs(realDir); err == nil {
+					if w.visited[realDir] {
+						continue
+					}
+					w.visited[realDir] = true
+				}
+			}
+			if err := w.walkDir(entryPath, entryRel, depth+1, files); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		if w.pattern != "" && !matchPath(w.pattern, entryRel) {
+			continue
+		}
+		if len(w.includes) > 0 && !matchAny(w.includes, entryRel) {
+			continue
+		}
+
+		*files = append(*files, walkedFile{
+			path:    entryPath,
+			rel:     entryRel,
+			modTime: info.ModTime(),
+		})
+	}
+
+	return nil
+}
+
+// followSymlink returns the info of the link target if the policy allows following it.
+// Broken links are never followed.
+func (w *walker) followSymlink(linkPath string) (fs.FileInfo, bool) {
+	if w.symlinks == SymlinkSkip {
+		return nil, false
+	}
+
+	info, err := os.Stat(linkPath)
+	if err != nil {
+		return nil, false
+	}
+
+	if w.symlinks == SymlinkFollowWithinRoot {
+		target, err := filepath.EvalSymlinks(linkPath)
+		if err != nil {
+			return nil, false
+		}
+		if target, err = filepath.Abs(target); err != nil {
+			return nil, false
+		}
+		if target != w.realRoot && !strings.HasPrefix(target, w.realRoot+string(filepath.Separator)) {
+			return nil, false
+		}
+	}
+
+	return info, true
+}
+
+// matchAny reports whether rel matches any of the patterns, see FileLoaderConfig.IncludePatterns for the syntax.
+func matchAny(patterns []string, rel string) bool {
+	for _, pattern := range patterns {
+		if strings.Contains(pattern, "/") {
+			if matchPath(pattern, rel) {
+				return true
+			}
+		} else if ok, _ := path.Match(pattern, path.Base(rel)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPath matches a slash-separated path against a pattern whose segments are path.Match patterns,
+// where a "**" segment matches zero or more path segments.
+func matchPath(pattern, rel string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(rel, "/"))
+}
+
+func matchSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if matchSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+func validatePattern(pattern string) error {
+	for _, segment := range strings.Split(pattern, "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("file loader invalid pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// hasMeta reports whether s contains any of the glob meta characters.
+func hasMeta(s string) bool {
+	return strings.ContainsAny(s, `*?[`)
+}
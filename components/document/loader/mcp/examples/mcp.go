@@ -0,0 +1,69 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	mcpl "github.com/cloudwego/eino-ext/components/document/loader/mcp"
+)
+
+func main() {
+	ctx := context.Background()
+
+	svr := server.NewMCPServer("demo", "1.0.0", server.WithResourceCapabilities(false, false))
+	svr.AddResource(mcp.NewResource("docs://readme", "readme", mcp.WithMIMEType("text/markdown")),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return []mcp.ResourceContents{
+				mcp.TextResourceContents{URI: "docs://readme", MIMEType: "text/markdown", Text: "# Demo\nhello"},
+			}, nil
+		})
+
+	cli, err := client.NewInProcessClient(svr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{
+		Name:    "example-client",
+		Version: "1.0.0",
+	}
+	if _, err = cli.Initialize(ctx, initRequest); err != nil {
+		log.Fatal(err)
+	}
+
+	loader, err := mcpl.NewLoader(ctx, &mcpl.LoaderConfig{Cli: cli})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	docs, err := loader.Load(ctx, document.Source{URI: "docs://readme"})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, doc := range docs {
+		fmt.Println(doc.ID, doc.MetaData[mcpl.MetaKeyMIMEType])
+		fmt.Println(doc.Content)
+	}
+}
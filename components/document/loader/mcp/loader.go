@@ -0,0 +1,149 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	MetaKeyMIMEType = "_mime_type"
+)
+
+var _ document.Loader = (*Loader)(nil)
+
+// LoaderConfig is the config for MCP resource Loader.
+type LoaderConfig struct {
+	// Cli is the MCP (Model Control Protocol) client, ref: https://github.com/mark3labs/mcp-go
+	// Notice: should Initialize with server before use
+	// Required
+	Cli client.MCPClient
+	// Parser parses the content of each resource, blob resources are base64 decoded before parsing
+	// Optional. Default: parser.TextParser
+	Parser parser.Parser
+}
+
+// NewLoader creates a loader which reads MCP resources, the uri of the source is the uri of the resource.
+func NewLoader(ctx context.Context, conf *LoaderConfig) (*Loader, error) {
+	if conf == nil || conf.Cli == nil {
+		return nil, errors.New("mcp client is required")
+	}
+
+	p := conf.Parser
+	if p == nil {
+		p = parser.TextParser{}
+	}
+
+	return &Loader{
+		cli:    conf.Cli,
+		parser: p,
+	}, nil
+}
+
+// Loader is a loader for MCP resources.
+type Loader struct {
+	cli    client.MCPClient
+	parser parser.Parser
+}
+
+// Load reads the resource and parses each of its contents, a resource may return several contents, e.g. the files of a directory.
+func (l *Loader) Load(ctx context.Context, src document.Source, opts ...document.LoaderOption) (docs []*schema.Document, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, l.GetType(), components.ComponentOfLoader)
+	ctx = callbacks.OnStart(ctx, &document.LoaderCallbackInput{
+		Source: src,
+	})
+	defer func() {
+		if err != nil {
+			_ = callbacks.OnError(ctx, err)
+		}
+	}()
+
+	result, err := l.cli.ReadResource(ctx, mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{
+			URI: src.URI,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read mcp resource [%s] fail: %w", src.URI, err)
+	}
+
+	for _, content := range result.Contents {
+		var (
+			uri, mimeType string
+			reader        io.Reader
+		)
+		switch c := content.(type) {
+		case mcp.TextResourceContents:
+			uri, mimeType = c.URI, c.MIMEType
+			reader = strings.NewReader(c.Text)
+		case mcp.BlobResourceContents:
+			uri, mimeType = c.URI, c.MIMEType
+			data, decodeErr := base64.StdEncoding.DecodeString(c.Blob)
+			if decodeErr != nil {
+				return nil, fmt.Errorf("decode blob of mcp resource [%s] fail: %w", c.URI, decodeErr)
+			}
+			reader = bytes.NewReader(data)
+		default:
+			return nil, fmt.Errorf("unknown mcp resource contents type: %T", content)
+		}
+		if uri == "" {
+			uri = src.URI
+		}
+
+		parsed, parseErr := l.parser.Parse(ctx, reader,
+			parser.WithURI(uri),
+			parser.WithExtraMeta(map[string]any{MetaKeyMIMEType: mimeType}))
+		if parseErr != nil {
+			return nil, fmt.Errorf("parse content of mcp resource [%s] err: %w", uri, parseErr)
+		}
+		for _, doc := range parsed {
+			if doc.ID == "" {
+				doc.ID = uri
+			}
+		}
+		docs = append(docs, parsed...)
+	}
+
+	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
+		Source: src,
+		Docs:   docs,
+	})
+
+	return docs, nil
+}
+
+func (l *Loader) GetType() string {
+	return "MCPLoader"
+}
+
+func (l *Loader) IsCallbacksEnabled() bool {
+	return true
+}
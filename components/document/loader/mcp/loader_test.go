@@ -0,0 +1,80 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoader(t *testing.T) {
+	ctx := context.Background()
+
+	svr := server.NewMCPServer("test", "1.0.0")
+	svr.AddResource(mcp.NewResource("file:///notes.txt", "notes"), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{URI: "file:///notes.txt", MIMEType: "text/plain", Text: "hello"},
+		}, nil
+	})
+	svr.AddResource(mcp.NewResource("file:///dir", "dir"), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{URI: "file:///dir/a.md", MIMEType: "text/markdown", Text: "# a"},
+			mcp.BlobResourceContents{URI: "file:///dir/b.bin", MIMEType: "application/octet-stream", Blob: "Ynl0ZXM="},
+		}, nil
+	})
+
+	cli, err := client.NewInProcessClient(svr)
+	require.NoError(t, err)
+	defer cli.Close()
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	_, err = cli.Initialize(ctx, initRequest)
+	require.NoError(t, err)
+
+	_, err = NewLoader(ctx, &LoaderConfig{})
+	assert.Error(t, err)
+
+	loader, err := NewLoader(ctx, &LoaderConfig{Cli: cli})
+	require.NoError(t, err)
+
+	docs, err := loader.Load(ctx, document.Source{URI: "file:///notes.txt"})
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "file:///notes.txt", docs[0].ID)
+	assert.Equal(t, "hello", docs[0].Content)
+	assert.Equal(t, map[string]any{parser.MetaKeySource: "file:///notes.txt", MetaKeyMIMEType: "text/plain"}, docs[0].MetaData)
+
+	docs, err = loader.Load(ctx, document.Source{URI: "file:///dir"})
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	assert.Equal(t, "file:///dir/a.md", docs[0].ID)
+	assert.Equal(t, "# a", docs[0].Content)
+	assert.Equal(t, "file:///dir/b.bin", docs[1].ID)
+	assert.Equal(t, "bytes", docs[1].Content)
+	assert.Equal(t, "application/octet-stream", docs[1].MetaData[MetaKeyMIMEType])
+
+	_, err = loader.Load(ctx, document.Source{URI: "file:///missing"})
+	assert.ErrorContains(t, err, "read mcp resource [file:///missing] fail")
+}
@@ -21,7 +21,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path"
 	"strings"
+	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -35,15 +37,43 @@ import (
 	"github.com/cloudwego/eino/schema"
 )
 
+const (
+	MetaKeyBucket       = "_s3_bucket"
+	MetaKeyObjectKey    = "_s3_object_key"
+	MetaKeyETag         = "_s3_etag"
+	MetaKeyLastModified = "_s3_last_modified"
+)
+
+const defaultConcurrency = 4
+
 // LoaderConfig is the configuration for s3 loader.
 type LoaderConfig struct {
 	Region       *string // the region of the AWS bucket
 	AWSAccessKey *string
 	AWSSecretKey *string
 
+	Endpoint     *string // custom endpoint for S3-compatible storage such as MinIO, e.g. http://localhost:9000
+	UsePathStyle bool    // address buckets as endpoint/bucket/key instead of bucket.endpoint/key, required by most S3-compatible storage
+
 	UseObjectKeyAsID bool // whether to use object key as document ID
 
-	Parser parser.Parser // the parser to parse the s3 object stream into documents, default to parser.TextParser, which directly converts []byte to string
+	// the parser to parse the s3 object stream into documents, default to parser.TextParser, which directly converts []byte to string.
+	// The object URI (s3://bucket/key) is passed with parser.WithURI, so a *parser.ExtParser picks a parser per object by extension.
+	Parser parser.Parser
+
+	// The following fields only apply to batch load, i.e. when the source URI is a prefix ending with "/", such as s3://bucket/docs/.
+
+	// KeyPatterns filters the objects under the prefix by path.Match glob patterns, an object is loaded if any pattern matches.
+	// A pattern containing "/" is matched against the key relative to the prefix, otherwise against the base name of the key.
+	// Empty means all objects.
+	KeyPatterns []string
+	// Extensions filters the objects under the prefix by extension, such as ".pdf", case-insensitive.
+	// Empty means all extensions.
+	Extensions []string
+	// Concurrency is the max number of objects downloaded and parsed at the same time, default to 4.
+	Concurrency int
+	// SkipFailedObjects skips the objects failing to download or parse instead of failing the whole load.
+	SkipFailedObjects bool
 }
 
 type loader struct {
@@ -52,6 +82,11 @@ type loader struct {
 	parser parser.Parser
 
 	useObjectKeyAsID bool
+
+	keyPatterns       []string
+	extensions        map[string]bool
+	concurrency       int
+	skipFailedObjects bool
 }
 
 // NewS3Loader creates a new s3 loader.
@@ -88,21 +123,49 @@ func NewS3Loader(ctx context.Context, conf *LoaderConfig) (document.Loader, erro
 		return nil, fmt.Errorf("new s3 loader, load config err: %w", err)
 	}
 
-	client := s3.NewFromConfig(sdkConfig)
+	client := s3.NewFromConfig(sdkConfig, func(o *s3.Options) {
+		if conf.Endpoint != nil {
+			o.BaseEndpoint = conf.Endpoint
+		}
+		o.UsePathStyle = conf.UsePathStyle
+	})
 
 	p := conf.Parser
 	if p == nil {
 		p = &parser.TextParser{}
 	}
 
+	for _, pattern := range conf.KeyPatterns {
+		if _, err = path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("new s3 loader, invalid key pattern %q: %w", pattern, err)
+		}
+	}
+
+	var extensions map[string]bool
+	if len(conf.Extensions) > 0 {
+		extensions = make(map[string]bool, len(conf.Extensions))
+		for _, ext := range conf.Extensions {
+			extensions["."+strings.ToLower(strings.TrimPrefix(ext, "."))] = true
+		}
+	}
+
+	concurrency := conf.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
 	return &loader{
-		client:           client,
-		parser:           p,
-		useObjectKeyAsID: conf.UseObjectKeyAsID,
+		client:            client,
+		parser:            p,
+		useObjectKeyAsID:  conf.UseObjectKeyAsID,
+		keyPatterns:       conf.KeyPatterns,
+		extensions:        extensions,
+		concurrency:       concurrency,
+		skipFailedObjects: conf.SkipFailedObjects,
 	}, nil
 }
 
-// Load loads the s3 object from the given URI.
+// Load loads the s3 object from the given URI, or all objects under the prefix if the URI ends with "/".
 func (l *loader) Load(ctx context.Context, src document.Source, opts ...document.LoaderOption) (docs []*schema.Document, err error) {
 	ctx = callbacks.EnsureRunInfo(ctx, l.GetType(), components.ComponentOfLoader)
 	ctx = callbacks.OnStart(ctx, &document.LoaderCallbackInput{
@@ -121,12 +184,27 @@ func (l *loader) Load(ctx context.Context, src document.Source, opts ...document
 		return nil, err
 	}
 
-	// prefix is not supported now
+	o := document.GetLoaderCommonOptions(&document.LoaderOptions{}, opts...)
+
 	if isPrefix {
-		err = errors.New("s3 loader does not support batch load with prefix for now")
+		docs, err = l.loadPrefix(ctx, bucket, key, o.ParserOptions)
+	} else {
+		docs, err = l.loadObject(ctx, bucket, key, o.ParserOptions)
+	}
+	if err != nil {
 		return nil, err
 	}
 
+	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
+		Source: src,
+		Docs:   docs,
+	})
+
+	return docs, nil
+}
+
+// loadObject downloads and parses a single object.
+func (l *loader) loadObject(ctx context.Context, bucket, key string, parserOpts []parser.Option) ([]*schema.Document, error) {
 	// get object from s3
 	resp, err := l.client.GetObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(bucket),
@@ -135,37 +213,164 @@ func (l *loader) Load(ctx context.Context, src document.Source, opts ...document
 	if err != nil {
 		var noKey *types.NoSuchKey
 		if errors.As(err, &noKey) {
-			err = fmt.Errorf("s3 loader bucket= %s, key= %s not found, err: %w", bucket, key, err)
-			return nil, err
+			return nil, fmt.Errorf("s3 loader bucket= %s, key= %s not found, err: %w", bucket, key, err)
 		}
 
-		err = fmt.Errorf("s3 loader get object err: %w", err)
-		return nil, err
+		return nil, fmt.Errorf("s3 loader get object err: %w", err)
 	}
 	defer resp.Body.Close()
 
-	o := document.GetLoaderCommonOptions(&document.LoaderOptions{}, opts...)
+	uri := "s3://" + bucket + "/" + key
+	docs, err := l.parser.Parse(ctx, resp.Body, append([]parser.Option{parser.WithURI(uri)}, parserOpts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("s3 loader parse err: %w", err)
+	}
+
+	for _, doc := range docs {
+		if doc == nil {
+			continue
+		}
+
+		if doc.MetaData == nil {
+			doc.MetaData = make(map[string]any)
+		}
+		doc.MetaData[MetaKeyBucket] = bucket
+		doc.MetaData[MetaKeyObjectKey] = key
+		if resp.ETag != nil {
+			doc.MetaData[MetaKeyETag] = *resp.ETag
+		}
+		if resp.LastModified != nil {
+			doc.MetaData[MetaKeyLastModified] = *resp.LastModified
+		}
+
+		if l.useObjectKeyAsID {
+			doc.ID = key
+		}
+	}
 
-	docs, err = l.parser.Parse(ctx, resp.Body, append([]parser.Option{parser.WithURI(src.URI)}, o.ParserOptions...)...)
+	return docs, nil
+}
+
+// loadPrefix loads all objects under the prefix matching the filters, with at most l.concurrency objects in flight.
+// The documents are returned in the listing order of the objects.
+func (l *loader) loadPrefix(ctx context.Context, bucket, prefix string, parserOpts []parser.Option) ([]*schema.Document, error) {
+	keys, err := l.listKeys(ctx, bucket, prefix)
 	if err != nil {
-		err = fmt.Errorf("s3 loader parse err: %w", err)
 		return nil, err
 	}
 
-	if l.useObjectKeyAsID {
-		for _, doc := range docs {
-			doc.ID = key
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		results  = make([][]*schema.Document, len(keys))
+		sem      = make(chan struct{}, l.concurrency)
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	for i, key := range keys {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
 		}
+
+		wg.Add(1)
+		go func(i int, key string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			objDocs, e := l.loadObject(ctx, bucket, key, parserOpts)
+			if e != nil {
+				if l.skipFailedObjects {
+					return
+				}
+
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("s3 loader load object %s failed: %w", key, e)
+				}
+				mu.Unlock()
+				cancel()
+				return
+			}
+			results[i] = objDocs
+		}(i, key)
 	}
+	wg.Wait()
 
-	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
-		Source: src,
-		Docs:   docs,
-	})
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var docs []*schema.Document
+	for _, objDocs := range results {
+		docs = append(docs, objDocs...)
+	}
 
 	return docs, nil
 }
 
+// listKeys lists the keys under the prefix page by page, keeping the ones matching the filters.
+func (l *loader) listKeys(ctx context.Context, bucket, prefix string) ([]string, error) {
+	var keys []string
+
+	paginator := s3.NewListObjectsV2Paginator(l.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("s3 loader list objects err: %w", err)
+		}
+
+		for _, obj := range page.Contents {
+			key := aws.ToString(obj.Key)
+			if strings.HasSuffix(key, "/") { // folder placeholder
+				continue
+			}
+			if l.matchKey(strings.TrimPrefix(key, prefix)) {
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	return keys, nil
+}
+
+// matchKey reports whether the key relative to the prefix passes the extension and pattern filters.
+func (l *loader) matchKey(relKey string) bool {
+	if l.extensions != nil && !l.extensions[strings.ToLower(path.Ext(relKey))] {
+		return false
+	}
+
+	if len(l.keyPatterns) == 0 {
+		return true
+	}
+
+	for _, pattern := range l.keyPatterns {
+		name := relKey
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(relKey)
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
 func uriToBucketAndKey(uri string) (bucket string, key string, isPrefix bool, err error) {
 	const (
 		uriPrefix = `s3://`
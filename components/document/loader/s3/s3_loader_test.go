@@ -18,15 +18,24 @@ package s3
 
 import (
 	"context"
+	"encoding/xml"
 	"errors"
+	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/bytedance/mockey"
 	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/document/parser"
 	"github.com/cloudwego/eino/schema"
 	"github.com/stretchr/testify/assert"
 )
@@ -95,12 +104,6 @@ func TestLoader_Load(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "incomplete")
 
-		_, err = s3Loader.Load(ctx, document.Source{
-			URI: "s3://bucket/prefix/",
-		})
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "does not support batch load with prefix")
-
 		mockey.PatchConvey("get object returns no such key", func() {
 			mockey.Mock((*s3.Client).GetObject).Return(nil, &types.NoSuchKey{}).Build()
 
@@ -140,3 +143,211 @@ func TestLoader_Load(t *testing.T) {
 		assert.Equal(t, "key.txt", result[0].ID)
 	})
 }
+
+// fakeS3 is a minimal path-style S3 server serving ListObjectsV2 and GetObject.
+type fakeS3 struct {
+	bucket   string
+	objects  map[string]string
+	denied   map[string]bool // keys whose GetObject is denied
+	pageSize int
+	modified time.Time
+}
+
+type fakeListResult struct {
+	XMLName               xml.Name `xml:"ListBucketResult"`
+	Name                  string
+	Prefix                string
+	KeyCount              int
+	IsTruncated           bool
+	NextContinuationToken string `xml:",omitempty"`
+	Contents              []fakeObject
+}
+
+type fakeObject struct {
+	Key          string
+	ETag         string
+	LastModified string
+	Size         int
+}
+
+func (f *fakeS3) etag(key string) string {
+	return fmt.Sprintf(`"etag-%s"`, key)
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != f.bucket {
+		f.error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	if key == "" {
+		f.list(w, r)
+		return
+	}
+
+	content, ok := f.objects[key]
+	switch {
+	case !ok:
+		f.error(w, http.StatusNotFound, "NoSuchKey")
+	case f.denied[key]:
+		f.error(w, http.StatusForbidden, "AccessDenied")
+	default:
+		w.Header().Set("ETag", f.etag(key))
+		w.Header().Set("Last-Modified", f.modified.Format(http.TimeFormat))
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		_, _ = io.WriteString(w, content)
+	}
+}
+
+func (f *fakeS3) list(w http.ResponseWriter, r *http.Request) {
+	prefix := r.URL.Query().Get("prefix")
+	var keys []string
+	for key := range f.objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	start, _ := strconv.Atoi(r.URL.Query().Get("continuation-token"))
+	end := min(start+f.pageSize, len(keys))
+
+	result := fakeListResult{Name: f.bucket, Prefix: prefix, KeyCount: end - start}
+	if end < len(keys) {
+		result.IsTruncated = true
+		result.NextContinuationToken = strconv.Itoa(end)
+	}
+	for _, key := range keys[start:end] {
+		result.Contents = append(result.Contents, fakeObject{
+			Key:          key,
+			ETag:         f.etag(key),
+			LastModified: f.modified.Format(time.RFC3339),
+			Size:         len(f.objects[key]),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+func (f *fakeS3) error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", code, code)
+}
+
+func TestLoader_LoadPrefix(t *testing.T) {
+	ctx := context.Background()
+	modified := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	fake := &fakeS3{
+		bucket: "bucket",
+		objects: map[string]string{
+			"docs/":           "",
+			"docs/a.txt":      "a",
+			"docs/b.md":       "# b",
+			"docs/sub/c.txt":  "c",
+			"docs/sub/d.json": "{}",
+			"docs/denied.txt": "denied",
+			"other/e.txt":     "e",
+		},
+		denied:   map[string]bool{"docs/denied.txt": true},
+		pageSize: 2,
+		modified: modified,
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	newLoader := func(t *testing.T, conf *LoaderConfig) document.Loader {
+		conf.Region = aws.String("us-east-1")
+		conf.AWSAccessKey = aws.String("ak")
+		conf.AWSSecretKey = aws.String("sk")
+		conf.Endpoint = aws.String(server.URL)
+		conf.UsePathStyle = true
+		l, err := NewS3Loader(ctx, conf)
+		assert.NoError(t, err)
+		return l
+	}
+
+	contents := func(docs []*schema.Document) []string {
+		var res []string
+		for _, doc := range docs {
+			res = append(res, doc.Content)
+		}
+		return res
+	}
+
+	t.Run("invalid key pattern", func(t *testing.T) {
+		_, err := NewS3Loader(ctx, &LoaderConfig{KeyPatterns: []string{"["}})
+		assert.Error(t, err)
+	})
+
+	t.Run("fail on bad object", func(t *testing.T) {
+		l := newLoader(t, &LoaderConfig{})
+		_, err := l.Load(ctx, document.Source{URI: "s3://bucket/docs/"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "docs/denied.txt")
+	})
+
+	t.Run("skip bad object", func(t *testing.T) {
+		l := newLoader(t, &LoaderConfig{SkipFailedObjects: true, Concurrency: 2, UseObjectKeyAsID: true})
+		docs, err := l.Load(ctx, document.Source{URI: "s3://bucket/docs/"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "# b", "c", "{}"}, contents(docs))
+
+		assert.Equal(t, "docs/a.txt", docs[0].ID)
+		assert.Equal(t, "bucket", docs[0].MetaData[MetaKeyBucket])
+		assert.Equal(t, "docs/a.txt", docs[0].MetaData[MetaKeyObjectKey])
+		assert.Equal(t, `"etag-docs/a.txt"`, docs[0].MetaData[MetaKeyETag])
+		assert.Equal(t, modified, docs[0].MetaData[MetaKeyLastModified].(time.Time).UTC())
+		assert.Equal(t, "s3://bucket/docs/a.txt", docs[0].MetaData[parser.MetaKeySource])
+	})
+
+	t.Run("filter by extension", func(t *testing.T) {
+		l := newLoader(t, &LoaderConfig{Extensions: []string{"TXT", ".md"}, SkipFailedObjects: true})
+		docs, err := l.Load(ctx, document.Source{URI: "s3://bucket/docs/"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "# b", "c"}, contents(docs))
+	})
+
+	t.Run("filter by pattern", func(t *testing.T) {
+		l := newLoader(t, &LoaderConfig{KeyPatterns: []string{"sub/*", "b.*"}})
+		docs, err := l.Load(ctx, document.Source{URI: "s3://bucket/docs/"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"# b", "c", "{}"}, contents(docs))
+	})
+
+	t.Run("parser per extension", func(t *testing.T) {
+		p, err := parser.NewExtParser(ctx, &parser.ExtParserConfig{
+			Parsers: map[string]parser.Parser{".md": upperParser{}},
+		})
+		assert.NoError(t, err)
+
+		l := newLoader(t, &LoaderConfig{Parser: p, Extensions: []string{".txt", ".md"}, SkipFailedObjects: true})
+		docs, err := l.Load(ctx, document.Source{URI: "s3://bucket/docs/"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "# B", "c"}, contents(docs))
+	})
+
+	t.Run("single object", func(t *testing.T) {
+		l := newLoader(t, &LoaderConfig{})
+		docs, err := l.Load(ctx, document.Source{URI: "s3://bucket/other/e.txt"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"e"}, contents(docs))
+		assert.Equal(t, "other/e.txt", docs[0].MetaData[MetaKeyObjectKey])
+
+		_, err = l.Load(ctx, document.Source{URI: "s3://bucket/other/missing.txt"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
+
+type upperParser struct{}
+
+func (upperParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.Document{{Content: strings.ToUpper(string(data))}}, nil
+}
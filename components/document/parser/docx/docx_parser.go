@@ -22,6 +22,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"github.com/carmel/gooxml/document"
+	"github.com/carmel/gooxml/schema/soo/wml"
 	"github.com/cloudwego/eino/components/document/parser"
 	"github.com/cloudwego/eino/schema"
 	"github.com/google/uuid"
@@ -32,8 +33,15 @@ import (
 
 const (
 	SectionTypeKey = "sectionType"
+
+	// MetaKeyBlockIndex is the position of a table among the paragraphs and tables of the document body,
+	// starting from 0, for documents of tables.
+	MetaKeyBlockIndex = "_block_index"
 )
 
+// SectionTypeTable is the section type of documents of tables, see Config.TablesAsDocuments.
+const SectionTypeTable = "table"
+
 // Config is the configuration for Docx parser.
 type Config struct {
 	ToSections      bool // whether to split content by sections
@@ -41,6 +49,12 @@ type Config struct {
 	IncludeHeaders  bool // whether to include headers in the parsed content
 	IncludeFooters  bool // whether to include footers in the parsed content
 	IncludeTables   bool // whether to include table content
+
+	// TableFormat is the representation of tables, TableFormatMarkdown by default.
+	TableFormat TableFormat
+	// TablesAsDocuments returns each table of the document body as a document of its own,
+	// with MetaKeyTableIndex, MetaKeyTableHeader and MetaKeyBlockIndex set, instead of inlining it in the main content.
+	TablesAsDocuments bool
 }
 
 // DocxParser reads from io.Reader and parse Docx document content as plain text.
@@ -50,6 +64,9 @@ type DocxParser struct {
 	includeHeaders  bool
 	includeFooters  bool
 	includeTables   bool
+
+	tableFormat       TableFormat
+	tablesAsDocuments bool
 }
 
 // NewDocxParser creates a new Docx parser.
@@ -57,12 +74,23 @@ func NewDocxParser(ctx context.Context, config *Config) (*DocxParser, error) {
 	if config == nil {
 		config = &Config{}
 	}
+
+	tableFormat := config.TableFormat
+	if tableFormat == "" {
+		tableFormat = TableFormatMarkdown
+	}
+	if tableFormat != TableFormatMarkdown && tableFormat != TableFormatHTML {
+		return nil, fmt.Errorf("unsupported table format %q", tableFormat)
+	}
+
 	return &DocxParser{
-		toSections:      config.ToSections,
-		includeComments: config.IncludeComments,
-		includeHeaders:  config.IncludeHeaders,
-		includeFooters:  config.IncludeFooters,
-		includeTables:   config.IncludeTables,
+		toSections:        config.ToSections,
+		includeComments:   config.IncludeComments,
+		includeHeaders:    config.IncludeHeaders,
+		includeFooters:    config.IncludeFooters,
+		includeTables:     config.IncludeTables,
+		tableFormat:       tableFormat,
+		tablesAsDocuments: config.TablesAsDocuments,
 	}, nil
 }
 
@@ -83,7 +111,7 @@ func (wp *DocxParser) Parse(ctx context.Context, reader io.Reader, opts ...parse
 	}
 
 	// Extract content based on configuration
-	sections := wp.extractContent(doc)
+	sections, tables := wp.extractContent(doc)
 	if wp.toSections {
 		for key, section := range sections {
 			content := strings.TrimSpace(section)
@@ -123,6 +151,23 @@ func (wp *DocxParser) Parse(ctx context.Context, reader io.Reader, opts ...parse
 		}
 	}
 
+	for _, table := range tables {
+		metadata := make(map[string]interface{})
+		for k, v := range commonOpts.ExtraMeta {
+			metadata[k] = v
+		}
+		metadata[SectionTypeKey] = SectionTypeTable
+		metadata[MetaKeyTableIndex] = table.index
+		metadata[MetaKeyTableHeader] = tableHeader(table.rows)
+		metadata[MetaKeyBlockIndex] = table.blockIndex
+
+		docs = append(docs, &schema.Document{
+			ID:       uuid.New().String(),
+			Content:  renderTable(table.rows, wp.tableFormat),
+			MetaData: metadata,
+		})
+	}
+
 	return docs, nil
 }
 
@@ -134,14 +179,22 @@ func GetSectionType(doc *schema.Document) (string, bool) {
 	return sectionType, ok
 }
 
-// extractContent extracts all content from the Docx document based on configuration.
-func (wp *DocxParser) extractContent(doc *document.Document) map[string]string {
+// bodyTable is a table of the document body.
+type bodyTable struct {
+	index      int // index among the tables of the body
+	blockIndex int // index among the paragraphs and tables of the body
+	rows       [][]string
+}
+
+// extractContent extracts all content from the Docx document based on configuration,
+// and the tables of the body when they are returned as documents of their own.
+func (wp *DocxParser) extractContent(doc *document.Document) (map[string]string, []bodyTable) {
 	sections := make(map[string]string)
 
 	// Extract main document content
 	var mainContentBuf bytes.Buffer
 	mainContentBuf.WriteString("=== MAIN CONTENT ===\n")
-	mainContent := wp.extractMainContent(doc)
+	mainContent, tables := wp.extractMainContent(doc)
 	mainContentBuf.WriteString(mainContent)
 	mainContentBuf.WriteString("\n")
 	sections["main"] = mainContentBuf.String()
@@ -194,7 +247,7 @@ func (wp *DocxParser) extractContent(doc *document.Document) map[string]string {
 		}
 	}
 
-	return sections
+	return sections, tables
 }
 
 // extractComments extracts comments from the Docx document.
@@ -285,77 +338,116 @@ func (wp *DocxParser) extractFooters(doc *document.Document) string {
 	return buf.String()
 }
 
-// extractMainContent extracts the main document content.
-func (wp *DocxParser) extractMainContent(doc *document.Document) string {
+// extractMainContent extracts the main document content: the paragraphs and tables of the body, in order.
+// Tables are rendered in the table format, or returned apart when they are returned as documents of their own.
+func (wp *DocxParser) extractMainContent(doc *document.Document) (string, []bodyTable) {
 	var buf bytes.Buffer
 
-	// Extract paragraphs
+	paragraphs := make(map[*wml.CT_P]document.Paragraph)
 	for _, para := range doc.Paragraphs() {
-		var text string
-		for _, run := range para.Runs() {
-			text += run.Text()
-		}
-		if len(text) > 0 {
-			buf.WriteString(text)
-			buf.WriteString("\n")
+		paragraphs[para.X()] = para
+	}
+	tables := make(map[*wml.CT_Tbl]document.Table)
+	for _, table := range doc.Tables() {
+		tables[table.X()] = table
+	}
+
+	var (
+		bodyTables []bodyTable
+		tableIdx   int
+		blockIdx   int
+	)
+	body := doc.X().Body
+	if body == nil {
+		return "", nil
+	}
+	for _, elt := range body.EG_BlockLevelElts {
+		for _, content := range elt.EG_ContentBlockContent {
+			for _, p := range content.P {
+				blockIdx++
+				para, ok := paragraphs[p]
+				if !ok {
+					continue
+				}
+				text := paragraphText(para)
+				if len(text) > 0 {
+					buf.WriteString(text)
+					buf.WriteString("\n")
+				}
+			}
+
+			for _, tbl := range content.Tbl {
+				blockIdx++
+				table, ok := tables[tbl]
+				if !ok {
+					continue
+				}
+				rows := tableRows(table)
+				if len(rows) == 0 {
+					continue
+				}
+
+				if wp.tablesAsDocuments {
+					bodyTables = append(bodyTables, bodyTable{index: tableIdx, blockIndex: blockIdx - 1, rows: rows})
+				} else {
+					buf.WriteString(renderTable(rows, wp.tableFormat))
+					buf.WriteString("\n")
+				}
+				tableIdx++
+			}
 		}
 	}
 
-	return buf.String()
+	return buf.String(), bodyTables
 }
 
-// extractTables extracts table content from the Docx document in Markdown format.
-func (wp *DocxParser) extractTables(doc *document.Document) string {
-	var buf bytes.Buffer
-
-	for tableIdx, table := range doc.Tables() {
-		buf.WriteString(fmt.Sprintf("### Table %d\n\n", tableIdx+1))
+// paragraphText concatenates the text of the runs of the paragraph.
+func paragraphText(para document.Paragraph) string {
+	var text string
+	for _, run := range para.Runs() {
+		text += run.Text()
+	}
+	return text
+}
 
-		rows := table.Rows()
-		if len(rows) == 0 {
+// tableRows extracts the text of the cells of the table, row by row. Paragraphs of a cell are separated by a line break.
+func tableRows(table document.Table) [][]string {
+	var rows [][]string
+	for _, row := range table.Rows() {
+		cells := row.Cells()
+		if len(cells) == 0 {
 			continue
 		}
 
-		// Process each row
-		for rowIdx, row := range rows {
-			cells := row.Cells()
-			if len(cells) == 0 {
-				continue
-			}
-
-			// Extract cell content
-			var cellContents []string
-			for _, cell := range cells {
-				var cellText string
-				for _, para := range cell.Paragraphs() {
-					for _, run := range para.Runs() {
-						cellText += run.Text()
-					}
-				}
-				// Clean up cell text - remove newlines and trim spaces
-				cellText = strings.ReplaceAll(strings.TrimSpace(cellText), "\n", " ")
-				if cellText == "" {
-					cellText = " " // Empty cell placeholder
+		cellContents := make([]string, 0, len(cells))
+		for _, cell := range cells {
+			var texts []string
+			for _, para := range cell.Paragraphs() {
+				if text := paragraphText(para); text != "" {
+					texts = append(texts, text)
 				}
-				cellContents = append(cellContents, cellText)
 			}
+			cellContents = append(cellContents, strings.TrimSpace(strings.Join(texts, "\n")))
+		}
+		rows = append(rows, cellContents)
+	}
+	return rows
+}
 
-			// Write table row
-			buf.WriteString("| ")
-			buf.WriteString(strings.Join(cellContents, " | "))
-			buf.WriteString(" |\n")
+// extractTables extracts table content from the Docx document in the table format.
+func (wp *DocxParser) extractTables(doc *document.Document) string {
+	var buf bytes.Buffer
 
-			// Add separator row after first row (header)
-			if rowIdx == 0 {
-				buf.WriteString("|")
-				for range cellContents {
-					buf.WriteString(" --- |")
-				}
-				buf.WriteString("\n")
-			}
+	for tableIdx, table := range doc.Tables() {
+		buf.WriteString(fmt.Sprintf("### Table %d\n\n", tableIdx+1))
+
+		rows := tableRows(table)
+		if len(rows) == 0 {
+			continue
 		}
 
-		buf.WriteString("\n") // Add spacing between tables
+		buf.WriteString(renderTable(rows, wp.tableFormat))
+		buf.WriteString("\n\n") // Add spacing between tables
 	}
 
 	return buf.String()
@@ -20,7 +20,9 @@ import (
 	"context"
 	"github.com/cloudwego/eino/components/document/parser"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -49,4 +51,45 @@ func TestDocxParser_Parse(t *testing.T) {
 		}
 
 	})
+
+	t.Run("tables inline", func(t *testing.T) {
+		ctx := context.Background()
+		f, err := os.Open("./examples/testdata/test_docx.docx")
+		require.NoError(t, err)
+		defer f.Close()
+
+		p, err := NewDocxParser(ctx, nil)
+		require.NoError(t, err)
+
+		docs, err := p.Parse(ctx, f)
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Contains(t, docs[0].Content, "| Head1 | Head2 | Head3 |\n| --- | --- | --- |\n| 1 | 3 | 5 |\n| 2 | 4 | 6 |\n")
+	})
+
+	t.Run("tables as documents", func(t *testing.T) {
+		ctx := context.Background()
+		f, err := os.Open("./examples/testdata/test_docx.docx")
+		require.NoError(t, err)
+		defer f.Close()
+
+		_, err = NewDocxParser(ctx, &Config{TableFormat: "csv"})
+		assert.Error(t, err)
+
+		p, err := NewDocxParser(ctx, &Config{TableFormat: TableFormatHTML, TablesAsDocuments: true})
+		require.NoError(t, err)
+
+		docs, err := p.Parse(ctx, f, parser.WithExtraMeta(map[string]any{"test": "test"}))
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+		assert.False(t, strings.Contains(docs[0].Content, "Head1"))
+
+		table := docs[1]
+		assert.Equal(t, SectionTypeTable, table.MetaData[SectionTypeKey])
+		assert.Equal(t, 0, table.MetaData[MetaKeyTableIndex])
+		assert.Equal(t, []string{"Head1", "Head2", "Head3"}, table.MetaData[MetaKeyTableHeader])
+		assert.Contains(t, table.MetaData, MetaKeyBlockIndex)
+		assert.Equal(t, "test", table.MetaData["test"])
+		assert.True(t, strings.HasPrefix(table.Content, "<table>\n<thead>\n<tr><th>Head1</th><th>Head2</th><th>Head3</th></tr>"))
+	})
 }
@@ -0,0 +1,143 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package docx
+
+import (
+	"html"
+	"strings"
+)
+
+// TableFormat is the text representation of tables.
+type TableFormat string
+
+const (
+	// TableFormatMarkdown renders tables as markdown tables, the first row being the header.
+	TableFormatMarkdown TableFormat = "markdown"
+	// TableFormatHTML renders tables as html tables, the first row being the header.
+	TableFormatHTML TableFormat = "html"
+)
+
+const (
+	// MetaKeyTableIndex is the index of the table in the document, starting from 0, for documents of tables.
+	MetaKeyTableIndex = "_table_index"
+	// MetaKeyTableHeader is the header row of the table as []string, for documents of tables.
+	MetaKeyTableHeader = "_table_header"
+)
+
+// renderTable renders the rows of a table in the format, the first row being the header.
+// Rows shorter than the widest one are padded with empty cells.
+func renderTable(rows [][]string, format TableFormat) string {
+	if len(rows) == 0 {
+		return ""
+	}
+
+	width := 0
+	for _, row := range rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+
+	if format == TableFormatHTML {
+		return renderHTMLTable(rows, width)
+	}
+	return renderMarkdownTable(rows, width)
+}
+
+func renderMarkdownTable(rows [][]string, width int) string {
+	var sb strings.Builder
+	for i, row := range rows {
+		sb.WriteString("|")
+		for j := 0; j < width; j++ {
+			cell := ""
+			if j < len(row) {
+				cell = markdownCell(row[j])
+			}
+			if cell == "" {
+				cell = " " // empty cell placeholder
+			}
+			sb.WriteString(" ")
+			sb.WriteString(cell)
+			sb.WriteString(" |")
+		}
+		sb.WriteString("\n")
+
+		// separator row after the header
+		if i == 0 {
+			sb.WriteString("|")
+			for j := 0; j < width; j++ {
+				sb.WriteString(" --- |")
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func markdownCell(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	return strings.ReplaceAll(text, "|", `\|`)
+}
+
+func renderHTMLTable(rows [][]string, width int) string {
+	var sb strings.Builder
+	sb.WriteString("<table>\n")
+	for i, row := range rows {
+		tag := "td"
+		switch i {
+		case 0:
+			tag = "th"
+			sb.WriteString("<thead>\n")
+		case 1:
+			sb.WriteString("<tbody>\n")
+		}
+
+		sb.WriteString("<tr>")
+		for j := 0; j < width; j++ {
+			cell := ""
+			if j < len(row) {
+				cell = html.EscapeString(strings.TrimSpace(row[j]))
+				cell = strings.ReplaceAll(cell, "\n", "<br>")
+			}
+			sb.WriteString("<" + tag + ">" + cell + "</" + tag + ">")
+		}
+		sb.WriteString("</tr>\n")
+
+		if i == 0 {
+			sb.WriteString("</thead>\n")
+		}
+	}
+	if len(rows) > 1 {
+		sb.WriteString("</tbody>\n")
+	}
+	sb.WriteString("</table>")
+
+	return sb.String()
+}
+
+// tableHeader returns a copy of the first row of the table.
+func tableHeader(rows [][]string) []string {
+	if len(rows) == 0 {
+		return nil
+	}
+	header := make([]string, len(rows[0]))
+	for i, cell := range rows[0] {
+		header[i] = strings.TrimSpace(cell)
+	}
+	return header
+}
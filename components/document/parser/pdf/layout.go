@@ -0,0 +1,433 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pdf
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/dslipak/pdf"
+)
+
+const (
+	defaultFontSize = 10.0
+
+	// lineTolerance is the max vertical distance between glyphs of the same line, in font sizes.
+	lineTolerance = 0.5
+	// wordGap is the min horizontal gap between glyphs separated by a space, in font sizes.
+	wordGap = 0.15
+	// paragraphGap is the min vertical gap between paragraphs, in typical line spacings.
+	paragraphGap = 1.5
+	// columnGap is the min width of the empty band between columns, in font sizes.
+	columnGap = 1.5
+	// columnMinShare is the min share of the glyphs each side of a column gap must hold.
+	columnMinShare = 0.2
+	// cellGap is the min horizontal gap between the cells of a table row, in font sizes.
+	cellGap = 1.2
+	// cellAlignment is the max distance between aligned edges of cells of the same table column, in font sizes.
+	cellAlignment = 1.0
+	// tableRowGap is the max vertical distance between rows of a table, in font sizes.
+	tableRowGap = 3.0
+	// tableMaxCellShare is the max share of the text width a table cell may span.
+	tableMaxCellShare = 0.4
+)
+
+type layoutLine struct {
+	y        float64
+	fontSize float64
+	text     string
+	glyphs   []pdf.Text
+	cells    []layoutCell // runs of glyphs separated by wide gaps, candidates for table cells
+}
+
+type layoutCell struct {
+	start, end float64
+	text       string
+}
+
+// layoutBlock is a paragraph or a table of a page.
+type layoutBlock struct {
+	text  string     // the lines of the paragraph, empty for a table
+	table [][]string // the rows of the table, nil for a paragraph
+}
+
+// layoutText rebuilds the text of a page from its glyphs, see layoutBlocks.
+// Columns are separated by a blank line, as paragraphs are.
+func layoutText(glyphs []pdf.Text) string {
+	return renderBlocks(layoutBlocks(glyphs, false), TableFormatMarkdown)
+}
+
+// layoutBlocks rebuilds the blocks of a page from its glyphs: glyphs are split into columns,
+// grouped into lines by baseline, separated by spaces where they are far apart,
+// and lines are grouped into paragraphs by vertical spacing.
+// With detectTables, runs of lines made of aligned cells are first taken out of the page as tables,
+// and the text between them is laid out as above.
+func layoutBlocks(glyphs []pdf.Text, detectTables bool) []layoutBlock {
+	var texts []pdf.Text
+	for _, g := range glyphs {
+		if g.S == "" {
+			continue
+		}
+		g.FontSize = math.Abs(g.FontSize)
+		if g.FontSize == 0 {
+			g.FontSize = defaultFontSize
+		}
+		texts = append(texts, g)
+	}
+
+	if !detectTables {
+		return textBlocks(texts)
+	}
+
+	var (
+		blocks []layoutBlock
+		rest   []pdf.Text
+	)
+	pageLines := lines(texts)
+	left, right := textSpan(pageLines)
+	for i := 0; i < len(pageLines); i++ {
+		end := tableEnd(pageLines, i, right-left)
+		if end-i < 2 {
+			rest = append(rest, pageLines[i].glyphs...)
+			continue
+		}
+
+		blocks = append(blocks, textBlocks(rest)...)
+		rest = nil
+
+		rows := make([][]string, 0, end-i)
+		for _, l := range pageLines[i:end] {
+			row := make([]string, len(l.cells))
+			for j, c := range l.cells {
+				row[j] = c.text
+			}
+			rows = append(rows, row)
+		}
+		blocks = append(blocks, layoutBlock{table: rows})
+		i = end - 1
+	}
+
+	return append(blocks, textBlocks(rest)...)
+}
+
+// textBlocks lays out the glyphs as paragraphs, column by column.
+func textBlocks(texts []pdf.Text) []layoutBlock {
+	if len(texts) == 0 {
+		return nil
+	}
+
+	var blocks []layoutBlock
+	for _, column := range splitColumns(texts) {
+		for _, text := range paragraphs(lines(column)) {
+			blocks = append(blocks, layoutBlock{text: text})
+		}
+	}
+	return blocks
+}
+
+func renderBlocks(blocks []layoutBlock, format TableFormat) string {
+	parts := make([]string, 0, len(blocks))
+	for _, b := range blocks {
+		if b.table != nil {
+			parts = append(parts, renderTable(b.table, format))
+		} else {
+			parts = append(parts, b.text)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// splitColumns splits the glyphs at the widest vertical empty band holding a large enough share of glyphs
+// on each side, recursively, and returns the columns from left to right.
+func splitColumns(texts []pdf.Text) [][]pdf.Text {
+	if len(texts) < 2 {
+		return [][]pdf.Text{texts}
+	}
+
+	type span struct{ start, end float64 }
+	spans := make([]span, len(texts))
+	fontSizes := make([]float64, len(texts))
+	for i, t := range texts {
+		spans[i] = span{t.X, glyphEnd(t)}
+		fontSizes[i] = t.FontSize
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	minGap := columnGap * median(fontSizes)
+
+	var (
+		bestGap   float64
+		bestSplit float64
+		reach     = spans[0].end
+	)
+	for i := 1; i < len(spans); i++ {
+		if gap := spans[i].start - reach; gap >= minGap && gap > bestGap {
+			left := float64(i) / float64(len(spans))
+			if left >= columnMinShare && 1-left >= columnMinShare {
+				bestGap, bestSplit = gap, reach+gap/2
+			}
+		}
+		reach = math.Max(reach, spans[i].end)
+	}
+
+	if bestGap == 0 {
+		return [][]pdf.Text{texts}
+	}
+
+	var left, right []pdf.Text
+	for _, t := range texts {
+		if t.X < bestSplit {
+			left = append(left, t)
+		} else {
+			right = append(right, t)
+		}
+	}
+
+	return append(splitColumns(left), splitColumns(right)...)
+}
+
+// lines groups the glyphs by baseline, from the top of the page to the bottom.
+func lines(texts []pdf.Text) []layoutLine {
+	sorted := make([]pdf.Text, len(texts))
+	copy(sorted, texts)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Y != sorted[j].Y {
+			return sorted[i].Y > sorted[j].Y
+		}
+		return sorted[i].X < sorted[j].X
+	})
+
+	var (
+		result []layoutLine
+		line   []pdf.Text
+	)
+	flush := func() {
+		if len(line) == 0 {
+			return
+		}
+		if l, ok := newLine(line); ok {
+			result = append(result, l)
+		}
+		line = nil
+	}
+
+	for _, t := range sorted {
+		if len(line) > 0 && line[0].Y-t.Y > lineTolerance*math.Max(line[0].FontSize, t.FontSize) {
+			flush()
+		}
+		line = append(line, t)
+	}
+	flush()
+
+	return result
+}
+
+// newLine builds a line from its glyphs, splitting them into cells where they are far apart.
+func newLine(glyphs []pdf.Text) (layoutLine, bool) {
+	sort.SliceStable(glyphs, func(i, j int) bool { return glyphs[i].X < glyphs[j].X })
+
+	l := layoutLine{y: glyphs[0].Y, fontSize: glyphs[0].FontSize, glyphs: glyphs}
+
+	start := 0
+	for i := 1; i <= len(glyphs); i++ {
+		if i < len(glyphs) && glyphs[i].X-glyphEnd(glyphs[i-1]) <= cellGap*glyphs[i].FontSize {
+			continue
+		}
+		if text := lineText(glyphs[start:i]); text != "" {
+			l.cells = append(l.cells, layoutCell{start: glyphs[start].X, end: glyphEnd(glyphs[i-1]), text: text})
+		}
+		start = i
+	}
+
+	if len(l.cells) == 0 {
+		return l, false
+	}
+
+	texts := make([]string, len(l.cells))
+	for i, c := range l.cells {
+		texts[i] = c.text
+	}
+	l.text = strings.Join(texts, " ")
+
+	return l, true
+}
+
+// lineText joins glyphs sorted from left to right, inserting a space where glyphs are far apart.
+func lineText(line []pdf.Text) string {
+	var (
+		sb   strings.Builder
+		prev *pdf.Text
+	)
+	for i := range line {
+		t := &line[i]
+		if prev != nil {
+			// skip glyphs drawn twice at the same place, a common way to render bold text
+			if t.S == prev.S && math.Abs(t.X-prev.X) < 0.1*t.FontSize {
+				continue
+			}
+			if t.X-glyphEnd(*prev) > wordGap*t.FontSize && !strings.HasSuffix(sb.String(), " ") && !strings.HasPrefix(t.S, " ") {
+				sb.WriteByte(' ')
+			}
+		}
+		sb.WriteString(t.S)
+		prev = t
+	}
+
+	return strings.TrimSpace(sb.String())
+}
+
+// paragraphs joins lines with a line break into paragraphs, split where the vertical gap
+// is much larger than the typical line spacing.
+func paragraphs(lines []layoutLine) []string {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	gaps := make([]float64, 0, len(lines)-1)
+	for i := 1; i < len(lines); i++ {
+		gaps = append(gaps, lines[i-1].y-lines[i].y)
+	}
+	spacing := median(gaps)
+
+	var (
+		result []string
+		sb     strings.Builder
+	)
+	for i, line := range lines {
+		if i > 0 {
+			gap := lines[i-1].y - line.y
+			// with few lines the median is unreliable, fall back to the font size
+			threshold := paragraphGap * math.Max(spacing, line.fontSize)
+			if len(gaps) < 3 {
+				threshold = paragraphGap * 1.2 * line.fontSize
+			}
+			if gap > threshold {
+				result = append(result, sb.String())
+				sb.Reset()
+			} else {
+				sb.WriteByte('\n')
+			}
+		}
+		sb.WriteString(line.text)
+	}
+
+	return append(result, sb.String())
+}
+
+// tableEnd returns the end of the run of table rows starting at lines[start]: lines with the same
+// number (at least 2) of narrow cells, each aligned on the left, right or center with the cell
+// of the first line, and not further apart than tableRowGap.
+// The run is shorter than 2 lines when lines[start] doesn't start a table.
+func tableEnd(lines []layoutLine, start int, width float64) int {
+	isRow := func(l layoutLine) bool {
+		if len(l.cells) < 2 {
+			return false
+		}
+		// wide cells are rather lines of text columns
+		for _, c := range l.cells {
+			if c.end-c.start > tableMaxCellShare*width {
+				return false
+			}
+		}
+		return true
+	}
+
+	first := lines[start]
+	if !isRow(first) {
+		return start
+	}
+
+	end := start + 1
+	for ; end < len(lines); end++ {
+		l := lines[end]
+		if len(l.cells) != len(first.cells) || !isRow(l) || lines[end-1].y-l.y > tableRowGap*l.fontSize {
+			break
+		}
+
+		aligned := true
+		tolerance := cellAlignment * l.fontSize
+		for j, c := range l.cells {
+			f := first.cells[j]
+			if math.Abs(c.start-f.start) > tolerance && math.Abs(c.end-f.end) > tolerance &&
+				math.Abs((c.start+c.end)-(f.start+f.end))/2 > tolerance {
+				aligned = false
+				break
+			}
+		}
+		if !aligned {
+			break
+		}
+	}
+
+	return end
+}
+
+// textSpan returns the left and right edges of the lines.
+func textSpan(lines []layoutLine) (left, right float64) {
+	for i, l := range lines {
+		start, end := l.cells[0].start, l.cells[len(l.cells)-1].end
+		if i == 0 || start < left {
+			left = start
+		}
+		if i == 0 || end > right {
+			right = end
+		}
+	}
+	return left, right
+}
+
+// glyphEnd returns the right edge of the glyph. Many PDFs don't report glyph widths,
+// in which case the width is estimated from the characters and the font size.
+func glyphEnd(t pdf.Text) float64 {
+	if t.W > 0 {
+		return t.X + t.W
+	}
+
+	var em float64
+	for _, r := range t.S {
+		em += runeWidth(r)
+	}
+	return t.X + em*t.FontSize
+}
+
+// runeWidth approximates the advance width of a character in a proportional Latin font, in ems.
+func runeWidth(r rune) float64 {
+	switch {
+	case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul),
+		r >= 0x3000 && r <= 0x303f, r >= 0xff00 && r <= 0xffef: // CJK punctuation and full-width forms
+		return 1
+	case strings.ContainsRune("ijlft.,;:'!|()[] ", r):
+		return 0.28
+	case strings.ContainsRune("mwMW", r):
+		return 0.8
+	case unicode.IsUpper(r):
+		return 0.67
+	default:
+		return 0.55
+	}
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	return sorted[len(sorted)/2]
+}
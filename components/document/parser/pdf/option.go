@@ -19,7 +19,11 @@ package pdf
 import "github.com/cloudwego/eino/components/document/parser"
 
 type options struct {
-	toPages *bool
+	toPages           *bool
+	preserveLayout    *bool
+	extractTables     *bool
+	tableFormat       *TableFormat
+	tablesAsDocuments *bool
 }
 
 // WithToPages is a parser option that specifies whether to parse the PDF into pages.
@@ -28,3 +32,31 @@ func WithToPages(toPages bool) parser.Option {
 		opts.toPages = &toPages
 	})
 }
+
+// WithPreserveLayout is a parser option that specifies whether to rebuild lines and paragraphs from glyph positions.
+func WithPreserveLayout(preserveLayout bool) parser.Option {
+	return parser.WrapImplSpecificOptFn(func(opts *options) {
+		opts.preserveLayout = &preserveLayout
+	})
+}
+
+// WithExtractTables is a parser option that specifies whether to detect tables and render them in the table format.
+func WithExtractTables(extractTables bool) parser.Option {
+	return parser.WrapImplSpecificOptFn(func(opts *options) {
+		opts.extractTables = &extractTables
+	})
+}
+
+// WithTableFormat is a parser option that specifies how extracted tables are rendered.
+func WithTableFormat(format TableFormat) parser.Option {
+	return parser.WrapImplSpecificOptFn(func(opts *options) {
+		opts.tableFormat = &format
+	})
+}
+
+// WithTablesAsDocuments is a parser option that specifies whether extracted tables are returned as documents of their own.
+func WithTablesAsDocuments(tablesAsDocuments bool) parser.Option {
+	return parser.WrapImplSpecificOptFn(func(opts *options) {
+		opts.tablesAsDocuments = &tablesAsDocuments
+	})
+}
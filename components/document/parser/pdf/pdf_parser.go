@@ -19,24 +19,86 @@ package pdf
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/cloudwego/eino/components/document/parser"
 	"github.com/cloudwego/eino/schema"
 	"github.com/dslipak/pdf"
 )
 
+const (
+	MetaKeyPage       = "_page"        // 1-based page number, only set when parsing into pages
+	MetaKeyTotalPages = "_total_pages" // number of pages of the PDF
+	MetaKeyTitle      = "_title"
+	MetaKeyAuthor     = "_author"
+	MetaKeySubject    = "_subject"
+	MetaKeyKeywords   = "_keywords"
+	MetaKeyCreator    = "_creator"
+	MetaKeyProducer   = "_producer"
+)
+
+// infoMetaKeys maps the entries of the document information dictionary to metadata keys.
+var infoMetaKeys = map[string]string{
+	"Title":    MetaKeyTitle,
+	"Author":   MetaKeyAuthor,
+	"Subject":  MetaKeySubject,
+	"Keywords": MetaKeyKeywords,
+	"Creator":  MetaKeyCreator,
+	"Producer": MetaKeyProducer,
+}
+
+// ErrEncrypted is returned, wrapped, when the PDF is encrypted and can't be decrypted with Config.Password.
+var ErrEncrypted = errors.New("pdf parser: encrypted pdf")
+
+// CorruptError is returned when the PDF, or one of its pages, can't be read.
+type CorruptError struct {
+	Page int // 1-based page number, 0 if the whole document is unreadable
+	Err  error
+}
+
+func (e *CorruptError) Error() string {
+	if e.Page == 0 {
+		return fmt.Sprintf("pdf parser: corrupt pdf: %v", e.Err)
+	}
+	return fmt.Sprintf("pdf parser: corrupt pdf page %d: %v", e.Page, e.Err)
+}
+
+func (e *CorruptError) Unwrap() error {
+	return e.Err
+}
+
 // Config is the configuration for PDF parser.
 type Config struct {
-	ToPages bool // whether to
+	ToPages bool // whether to parse the PDF into one document per page
+
+	// PreserveLayout rebuilds lines and paragraphs from the glyph positions instead of concatenating the text runs,
+	// and reads simple multi-column layouts column by column.
+	PreserveLayout bool
+
+	// ExtractTables detects tables from the glyph positions, as PreserveLayout does, and renders them in TableFormat.
+	ExtractTables bool
+	// TableFormat is the representation of extracted tables, TableFormatMarkdown by default.
+	TableFormat TableFormat
+	// TablesAsDocuments returns each extracted table as a document of its own, following the documents of the text,
+	// with MetaKeyTableIndex, MetaKeyTableHeader and MetaKeyPage set, instead of inlining it in the text.
+	TablesAsDocuments bool
+
+	Password string // password of encrypted PDFs, optional
 }
 
 // PDFParser reads from io.Reader and parse its content as plain text.
 // Attention: This is in alpha stage, and may not support all PDF use cases well enough.
-// For example, it will not preserve whitespace and new line for now.
+// By default, it will not preserve whitespace and new line, set PreserveLayout to keep them.
 type PDFParser struct {
-	ToPages bool
+	ToPages           bool
+	PreserveLayout    bool
+	ExtractTables     bool
+	TableFormat       TableFormat
+	TablesAsDocuments bool
+	Password          string
 }
 
 // NewPDFParser creates a new PDF parser.
@@ -44,7 +106,22 @@ func NewPDFParser(ctx context.Context, config *Config) (*PDFParser, error) {
 	if config == nil {
 		config = &Config{}
 	}
-	return &PDFParser{ToPages: config.ToPages}, nil
+	tableFormat := config.TableFormat
+	if tableFormat == "" {
+		tableFormat = TableFormatMarkdown
+	}
+	if tableFormat != TableFormatMarkdown && tableFormat != TableFormatHTML {
+		return nil, fmt.Errorf("pdf parser: unsupported table format %q", tableFormat)
+	}
+
+	return &PDFParser{
+		ToPages:           config.ToPages,
+		PreserveLayout:    config.PreserveLayout,
+		ExtractTables:     config.ExtractTables,
+		TableFormat:       tableFormat,
+		TablesAsDocuments: config.TablesAsDocuments,
+		Password:          config.Password,
+	}, nil
 }
 
 // Parse parses the PDF content from io.Reader.
@@ -52,7 +129,11 @@ func (pp *PDFParser) Parse(ctx context.Context, reader io.Reader, opts ...parser
 	commonOpts := parser.GetCommonOptions(nil, opts...)
 
 	specificOpts := parser.GetImplSpecificOptions(&options{
-		toPages: &pp.ToPages,
+		toPages:           &pp.ToPages,
+		preserveLayout:    &pp.PreserveLayout,
+		extractTables:     &pp.ExtractTables,
+		tableFormat:       &pp.TableFormat,
+		tablesAsDocuments: &pp.TablesAsDocuments,
 	}, opts...)
 
 	data, err := io.ReadAll(reader)
@@ -60,37 +141,89 @@ func (pp *PDFParser) Parse(ctx context.Context, reader io.Reader, opts ...parser
 		return nil, fmt.Errorf("pdf parser read all from reader failed: %w", err)
 	}
 
-	readerAt := bytes.NewReader(data)
+	f, err := pp.newReader(data)
+	if err != nil {
+		return nil, err
+	}
 
-	f, err := pdf.NewReader(readerAt, int64(readerAt.Len()))
+	pages, info, err := readDocument(f)
 	if err != nil {
-		return nil, fmt.Errorf("create new pdf reader failed: %w", err)
+		return nil, err
 	}
 
-	pages := f.NumPage()
 	var (
-		buf     bytes.Buffer
-		toPages = specificOpts.toPages != nil && *specificOpts.toPages
+		buf            bytes.Buffer
+		toPages        = specificOpts.toPages != nil && *specificOpts.toPages
+		preserveLayout = specificOpts.preserveLayout != nil && *specificOpts.preserveLayout
+		extractTables  = specificOpts.extractTables != nil && *specificOpts.extractTables
+		tablesAsDocs   = extractTables && specificOpts.tablesAsDocuments != nil && *specificOpts.tablesAsDocuments
+		tableFormat    = TableFormatMarkdown
+		tableDocs      []*schema.Document
 	)
+	if specificOpts.tableFormat != nil && *specificOpts.tableFormat != "" {
+		tableFormat = *specificOpts.tableFormat
+	}
+
+	newMeta := func() map[string]any {
+		meta := make(map[string]any, len(commonOpts.ExtraMeta)+len(info)+2)
+		for k, v := range info {
+			meta[k] = v
+		}
+		for k, v := range commonOpts.ExtraMeta {
+			meta[k] = v
+		}
+		meta[MetaKeyTotalPages] = pages
+		return meta
+	}
+
 	fonts := make(map[string]*pdf.Font)
 	for i := 1; i <= pages; i++ {
-		p := f.Page(i)
-		for _, name := range p.Fonts() { // cache fonts so we don't continually parse charmap
-			if _, ok := fonts[name]; !ok {
-				font := p.Font(name)
-				fonts[name] = &font
+		var text string
+		if preserveLayout || extractTables {
+			var blocks []layoutBlock
+			blocks, err = readPageLayout(f, i, extractTables)
+			if err != nil {
+				return nil, err
+			}
+
+			if tablesAsDocs {
+				var textBlocks []layoutBlock
+				for _, b := range blocks {
+					if b.table == nil {
+						textBlocks = append(textBlocks, b)
+						continue
+					}
+					meta := newMeta()
+					meta[MetaKeyPage] = i
+					meta[MetaKeyTableIndex] = len(tableDocs)
+					meta[MetaKeyTableHeader] = tableHeader(b.table)
+					tableDocs = append(tableDocs, &schema.Document{
+						Content:  renderTable(b.table, tableFormat),
+						MetaData: meta,
+					})
+				}
+				blocks = textBlocks
+			}
+			text = renderBlocks(blocks, tableFormat)
+		} else {
+			text, err = readPagePlainText(f, i, fonts)
+			if err != nil {
+				return nil, err
 			}
-		}
-		text, err := p.GetPlainText(fonts)
-		if err != nil {
-			return nil, fmt.Errorf("read pdf page failed: %w, page= %d", err, i)
 		}
 
 		if toPages {
+			meta := newMeta()
+			meta[MetaKeyPage] = i
 			docs = append(docs, &schema.Document{
 				Content:  text,
-				MetaData: commonOpts.ExtraMeta,
+				MetaData: meta,
 			})
+		} else if preserveLayout || extractTables {
+			if i > 1 {
+				buf.WriteString("\n\n")
+			}
+			buf.WriteString(text)
 		} else {
 			buf.WriteString(text + "\n")
 		}
@@ -99,9 +232,93 @@ func (pp *PDFParser) Parse(ctx context.Context, reader io.Reader, opts ...parser
 	if !toPages {
 		docs = append(docs, &schema.Document{
 			Content:  buf.String(),
-			MetaData: commonOpts.ExtraMeta,
+			MetaData: newMeta(),
 		})
 	}
 
-	return docs, nil
+	return append(docs, tableDocs...), nil
+}
+
+func (pp *PDFParser) newReader(data []byte) (*pdf.Reader, error) {
+	var pw func() string
+	if pp.Password != "" {
+		tried := false
+		pw = func() string {
+			if tried {
+				return ""
+			}
+			tried = true
+			return pp.Password
+		}
+	}
+
+	readerAt := bytes.NewReader(data)
+	f, err := pdf.NewReaderEncrypted(readerAt, int64(readerAt.Len()), pw)
+	if err != nil {
+		if errors.Is(err, pdf.ErrInvalidPassword) || strings.Contains(err.Error(), "encryption") {
+			return nil, fmt.Errorf("%w: %v", ErrEncrypted, err)
+		}
+		return nil, &CorruptError{Err: err}
+	}
+
+	return f, nil
+}
+
+// readDocument reads the page count and the information dictionary.
+// The pdf package reports malformed objects by panicking, which is turned into a *CorruptError.
+func readDocument(f *pdf.Reader) (pages int, info map[string]any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &CorruptError{Err: fmt.Errorf("%v", r)}
+		}
+	}()
+
+	pages = f.NumPage()
+
+	info = make(map[string]any)
+	dict := f.Trailer().Key("Info")
+	for key, metaKey := range infoMetaKeys {
+		if v := strings.TrimSpace(dict.Key(key).Text()); v != "" {
+			info[metaKey] = v
+		}
+	}
+
+	return pages, info, nil
+}
+
+func readPagePlainText(f *pdf.Reader, i int, fonts map[string]*pdf.Font) (text string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &CorruptError{Page: i, Err: fmt.Errorf("%v", r)}
+		}
+	}()
+
+	p := f.Page(i)
+	for _, name := range p.Fonts() { // cache fonts so we don't continually parse charmap
+		if _, ok := fonts[name]; !ok {
+			font := p.Font(name)
+			fonts[name] = &font
+		}
+	}
+	text, err = p.GetPlainText(fonts)
+	if err != nil {
+		return "", &CorruptError{Page: i, Err: err}
+	}
+
+	return text, nil
+}
+
+func readPageLayout(f *pdf.Reader, i int, detectTables bool) (blocks []layoutBlock, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &CorruptError{Page: i, Err: fmt.Errorf("%v", r)}
+		}
+	}()
+
+	p := f.Page(i)
+	if p.V.IsNull() {
+		return nil, nil
+	}
+
+	return layoutBlocks(p.Content().Text, detectTables), nil
 }
@@ -17,12 +17,17 @@
 package pdf
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/dslipak/pdf"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestLoader_Load(t *testing.T) {
@@ -39,8 +44,172 @@ func TestLoader_Load(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, 2, len(docs))
 		assert.True(t, len(docs[0].Content) > 0)
-		assert.Equal(t, map[string]any{"test": "test"}, docs[0].MetaData)
+		assert.Equal(t, "test", docs[0].MetaData["test"])
+		assert.Equal(t, 1, docs[0].MetaData[MetaKeyPage])
+		assert.Equal(t, 2, docs[0].MetaData[MetaKeyTotalPages])
 		assert.True(t, len(docs[0].Content) > 0)
-		assert.Equal(t, map[string]any{"test": "test"}, docs[1].MetaData)
+		assert.Equal(t, "test", docs[1].MetaData["test"])
+		assert.Equal(t, 2, docs[1].MetaData[MetaKeyPage])
+		assert.Equal(t, 2, docs[1].MetaData[MetaKeyTotalPages])
+		assert.Equal(t, "WPS 文字", docs[1].MetaData[MetaKeyCreator])
+		assert.NotEmpty(t, docs[1].MetaData[MetaKeyAuthor])
 	})
+
+	t.Run("preserve layout", func(t *testing.T) {
+		ctx := context.Background()
+
+		f, err := os.Open("./testdata/test_pdf.pdf")
+		require.NoError(t, err)
+		defer f.Close()
+
+		p, err := NewPDFParser(ctx, &Config{PreserveLayout: true})
+		require.NoError(t, err)
+
+		docs, err := p.Parse(ctx, f)
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, "test a new pdf.\na new line with 中文。\n\n尝试一些样式。", docs[0].Content)
+		assert.Equal(t, 2, docs[0].MetaData[MetaKeyTotalPages])
+		assert.NotContains(t, docs[0].MetaData, MetaKeyPage)
+	})
+
+	t.Run("extract tables", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := NewPDFParser(ctx, &Config{TableFormat: "csv"})
+		assert.Error(t, err)
+
+		f, err := os.Open("./testdata/test_pdf.pdf")
+		require.NoError(t, err)
+		defer f.Close()
+
+		p, err := NewPDFParser(ctx, &Config{ExtractTables: true, TablesAsDocuments: true, TableFormat: TableFormatHTML})
+		require.NoError(t, err)
+
+		// the pdf has no table, the text is laid out as with PreserveLayout
+		docs, err := p.Parse(ctx, f, WithTableFormat(TableFormatMarkdown))
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, "test a new pdf.\na new line with 中文。\n\n尝试一些样式。", docs[0].Content)
+	})
+
+	t.Run("corrupt pdf", func(t *testing.T) {
+		ctx := context.Background()
+		p, err := NewPDFParser(ctx, nil)
+		require.NoError(t, err)
+
+		_, err = p.Parse(ctx, strings.NewReader("not a pdf"))
+		var corrupt *CorruptError
+		require.True(t, errors.As(err, &corrupt))
+		assert.Equal(t, 0, corrupt.Page)
+	})
+
+	t.Run("encrypted pdf", func(t *testing.T) {
+		ctx := context.Background()
+		data, err := os.ReadFile("./testdata/encrypted.pdf")
+		require.NoError(t, err)
+
+		for _, conf := range []*Config{nil, {Password: "wrong"}} {
+			p, err := NewPDFParser(ctx, conf)
+			require.NoError(t, err)
+
+			_, err = p.Parse(ctx, bytes.NewReader(data))
+			assert.True(t, errors.Is(err, ErrEncrypted))
+		}
+	})
+}
+
+func TestLayoutText(t *testing.T) {
+	// word places the glyphs of s one after another from x, with widths as runeWidth estimates.
+	word := func(s string, x, y float64) []pdf.Text {
+		var texts []pdf.Text
+		for _, r := range s {
+			texts = append(texts, pdf.Text{S: string(r), X: x, Y: y, FontSize: 10})
+			x += runeWidth(r) * 10
+		}
+		return texts
+	}
+	line := func(x, y float64, words ...string) []pdf.Text {
+		var texts []pdf.Text
+		for _, w := range words {
+			texts = append(texts, word(w, x, y)...)
+			x = glyphEnd(texts[len(texts)-1]) + 3
+		}
+		return texts
+	}
+	concat := func(parts ...[]pdf.Text) []pdf.Text {
+		var texts []pdf.Text
+		for _, p := range parts {
+			texts = append(texts, p...)
+		}
+		return texts
+	}
+
+	t.Run("lines and paragraphs", func(t *testing.T) {
+		texts := concat(
+			line(50, 700, "first", "line"),
+			line(50, 688, "second", "line"),
+			line(50, 676, "third"),
+			line(50, 640, "new", "paragraph"),
+			line(50, 628, "end"),
+		)
+		assert.Equal(t, "first line\nsecond line\nthird\n\nnew paragraph\nend", layoutText(texts))
+	})
+
+	t.Run("unordered glyphs and duplicates", func(t *testing.T) {
+		texts := concat(line(50, 688, "world"), line(50, 700, "hello"))
+		texts = append(texts, texts[0]) // bold rendered twice
+		for i, j := 0, len(texts)-1; i < j; i, j = i+1, j-1 {
+			texts[i], texts[j] = texts[j], texts[i]
+		}
+		assert.Equal(t, "hello\nworld", layoutText(texts))
+	})
+
+	t.Run("two columns", func(t *testing.T) {
+		texts := concat(
+			line(50, 700, "left", "one"),
+			line(300, 700, "right", "one"),
+			line(50, 688, "left", "two"),
+			line(300, 688, "right", "two"),
+		)
+		assert.Equal(t, "left one\nleft two\n\nright one\nright two", layoutText(texts))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Equal(t, "", layoutText(nil))
+	})
+
+	t.Run("tables", func(t *testing.T) {
+		texts := concat(
+			line(50, 700, "the", "quarterly", "revenue", "of", "each", "region", "is", "listed", "below"),
+			line(50, 670, "Region"), line(150, 670, "Q1"), line(250, 670, "Q2"),
+			line(50, 658, "North"), line(150, 658, "10"), line(250, 658, "12"),
+			line(50, 646, "South|East"), line(150, 646, "9"), line(250, 646, "11"),
+			line(50, 610, "all", "figures", "are", "in", "millions", "of", "dollars", "and", "unaudited"),
+		)
+
+		blocks := layoutBlocks(texts, true)
+		require.Len(t, blocks, 3)
+		assert.Equal(t, "the quarterly revenue of each region is listed below", blocks[0].text)
+		assert.Equal(t, [][]string{{"Region", "Q1", "Q2"}, {"North", "10", "12"}, {"South|East", "9", "11"}}, blocks[1].table)
+		assert.Equal(t, "all figures are in millions of dollars and unaudited", blocks[2].text)
+
+		assert.Equal(t, "the quarterly revenue of each region is listed below\n\n"+
+			"| Region | Q1 | Q2 |\n| --- | --- | --- |\n| North | 10 | 12 |\n| South\\|East | 9 | 11 |\n\n"+
+			"all figures are in millions of dollars and unaudited", renderBlocks(blocks, TableFormatMarkdown))
+
+		// without table detection, the cells are laid out as text
+		assert.NotContains(t, layoutText(texts), "---")
+	})
+}
+
+func TestRenderTable(t *testing.T) {
+	rows := [][]string{{"name", "note"}, {"a<b", "x\ny"}, {"c"}}
+
+	assert.Equal(t, "| name | note |\n| --- | --- |\n| a<b | x y |\n| c |   |", renderTable(rows, TableFormatMarkdown))
+	assert.Equal(t, "<table>\n<thead>\n<tr><th>name</th><th>note</th></tr>\n</thead>\n"+
+		"<tbody>\n<tr><td>a&lt;b</td><td>x<br>y</td></tr>\n<tr><td>c</td><td></td></tr>\n</tbody>\n</table>",
+		renderTable(rows, TableFormatHTML))
+	assert.Equal(t, "", renderTable(nil, TableFormatMarkdown))
+	assert.Equal(t, []string{"name", "note"}, tableHeader(rows))
 }
@@ -17,9 +17,14 @@
 package xlsx
 
 import (
+	"bufio"
 	"context"
+	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
+	"path"
+	"path/filepath"
 	"strings"
 
 	"github.com/cloudwego/eino/components/document/parser"
@@ -30,12 +35,39 @@ import (
 const (
 	MetaDataRow = "_row"
 	MetaDataExt = "_ext"
+	// MetaDataSheet is the name of the sheet the document comes from, unset for CSV and TSV files.
+	MetaDataSheet = "_sheet"
+	// MetaDataRowStart and MetaDataRowEnd are the 1-based numbers of the first and last rows of the document,
+	// as shown by spreadsheet applications, equal for documents of a single row.
+	MetaDataRowStart = "_row_start"
+	MetaDataRowEnd   = "_row_end"
+)
+
+// Mode is how rows are turned into documents.
+type Mode string
+
+const (
+	// ModeRow builds one document per row, the cells separated by tabs. This is the default.
+	ModeRow Mode = "row"
+	// ModeSheet builds one document per sheet, as a markdown table, see Config.MaxRowsPerDocument.
+	ModeSheet Mode = "sheet"
+)
+
+// Format is the file format of the content.
+type Format string
+
+const (
+	FormatXlsx Format = "xlsx"
+	FormatCSV  Format = "csv"
+	FormatTSV  Format = "tsv"
 )
 
 // XlsxParser Custom parser for parsing Xlsx file content
 // Can be used to work with Xlsx files with headers or without headers
-// You can also select a specific table from the xlsx file in multiple sheet tables
+// You can also select specific tables from the xlsx file in multiple sheet tables
 // You can also customize the prefix of the document ID
+// CSV and TSV files are parsed as a workbook of a single sheet
+// Rows are read one by one, large sheets are buffered to temporary files rather than memory by excelize
 type XlsxParser struct {
 	Config *Config
 }
@@ -44,10 +76,21 @@ type XlsxParser struct {
 type Config struct {
 	// SheetName is set to Sheet1 by default, which means that the first table is processed
 	SheetName string
+	// Sheets selects the sheets to process by name or path.Match pattern, e.g. "*" for all sheets,
+	// in workbook order. It takes precedence over SheetName
+	Sheets []string
 	// NoHeader is set to false by default, which means that the first row is used as the table header
 	NoHeader bool
 	// IDPrefix is set to customize the prefix of document ID, default 1,2,3, ...
+	// Document IDs are prefixed with the sheet name when several sheets are processed, or in ModeSheet
 	IDPrefix string
+	// Mode is set to ModeRow by default, which means one document per row
+	Mode Mode
+	// MaxRowsPerDocument splits sheets into documents of at most this many data rows in ModeSheet,
+	// each with the header. No limit by default
+	MaxRowsPerDocument int
+	// Format is detected from the extension of the URI given by parser.WithURI by default, xlsx if unknown
+	Format Format
 }
 
 // NewXlsxParser Create a new xlsxParser
@@ -56,13 +99,36 @@ func NewXlsxParser(ctx context.Context, config *Config) (xlp parser.Parser, err
 	if config == nil {
 		config = &Config{}
 	}
+
+	switch config.Mode {
+	case "", ModeRow, ModeSheet:
+	default:
+		return nil, fmt.Errorf("unsupported mode %q", config.Mode)
+	}
+	switch config.Format {
+	case "", FormatXlsx, FormatCSV, FormatTSV:
+	default:
+		return nil, fmt.Errorf("unsupported format %q", config.Format)
+	}
+	for _, pattern := range config.Sheets {
+		if _, err = path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid sheet pattern %q: %w", pattern, err)
+		}
+	}
+	if config.MaxRowsPerDocument < 0 {
+		return nil, fmt.Errorf("MaxRowsPerDocument must not be negative, got %d", config.MaxRowsPerDocument)
+	}
+
 	// NoHeader is false by default, which means HasHeader is true by default
 	xlp = &XlsxParser{Config: config}
 	return xlp, nil
 }
 
 // generateID generates document ID based on configuration
-func (xlp *XlsxParser) generateID(i int) string {
+func (xlp *XlsxParser) generateID(sheet string, i int) string {
+	if sheet != "" {
+		return fmt.Sprintf("%s%s_%d", xlp.Config.IDPrefix, sheet, i)
+	}
 	if xlp.Config.IDPrefix == "" {
 		return fmt.Sprintf("%d", i)
 	}
@@ -82,52 +148,163 @@ func (xlp *XlsxParser) buildRowMetaData(row []string, headers []string) map[stri
 	return metaData
 }
 
+// sheet is a table of the workbook, read row by row.
+type sheet struct {
+	name string // empty for CSV and TSV files
+	// rows calls yield with the rows of the sheet, in order, including empty ones
+	rows func(yield func(row []string) error) error
+}
+
 // Parse parses the XLSX content from io.Reader.
 func (xlp *XlsxParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
 	option := parser.GetCommonOptions(&parser.Options{}, opts...)
-	xlFile, err := excelize.OpenReader(reader)
-	if err != nil {
-		return nil, err
+
+	var sheets []sheet
+	switch format := xlp.format(option.URI); format {
+	case FormatCSV, FormatTSV:
+		sheets = []sheet{{rows: delimitedRows(reader, format)}}
+	default:
+		xlFile, err := excelize.OpenReader(reader)
+		if err != nil {
+			return nil, err
+		}
+		defer xlFile.Close()
+
+		names, err := xlp.selectSheets(xlFile.GetSheetList())
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			sheets = append(sheets, sheet{name: name, rows: workbookRows(xlFile, name)})
+		}
+	}
+
+	var ret []*schema.Document
+	for _, s := range sheets {
+		docs, err := xlp.parseSheet(s, option.ExtraMeta, len(sheets) > 1)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, docs...)
+	}
+
+	return ret, nil
+}
+
+// format returns the configured format, or the one of the extension of the uri.
+func (xlp *XlsxParser) format(uri string) Format {
+	if xlp.Config.Format != "" {
+		return xlp.Config.Format
 	}
-	defer xlFile.Close()
+	// strip the query of urls
+	if i := strings.IndexAny(uri, "?#"); i >= 0 {
+		uri = uri[:i]
+	}
+	switch strings.ToLower(filepath.Ext(uri)) {
+	case ".csv":
+		return FormatCSV
+	case ".tsv":
+		return FormatTSV
+	default:
+		return FormatXlsx
+	}
+}
 
-	// Get all worksheets
-	sheets := xlFile.GetSheetList()
-	if len(sheets) == 0 {
+// selectSheets returns the sheets to process, in workbook order.
+func (xlp *XlsxParser) selectSheets(all []string) ([]string, error) {
+	if len(all) == 0 {
 		return nil, nil
 	}
 
-	// Default
-	sheetName := sheets[0]
-	if xlp.Config.SheetName != "" {
-		sheetName = xlp.Config.SheetName
+	if len(xlp.Config.Sheets) == 0 {
+		// Default
+		if xlp.Config.SheetName != "" {
+			return []string{xlp.Config.SheetName}, nil
+		}
+		return all[:1], nil
 	}
 
-	// Get all rows, header + data rows
-	rows, err := xlFile.GetRows(sheetName)
-	if err != nil {
-		return nil, err
+	var selected []string
+	for _, name := range all {
+		for _, pattern := range xlp.Config.Sheets {
+			if ok, _ := path.Match(pattern, name); ok {
+				selected = append(selected, name)
+				break
+			}
+		}
 	}
-	if len(rows) == 0 {
-		return nil, nil
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no sheet matches %v, sheets: %v", xlp.Config.Sheets, all)
 	}
 
-	var ret []*schema.Document
+	return selected, nil
+}
 
-	// Process the header
-	startIdx := 0
-	var headers []string
-	if !xlp.Config.NoHeader && len(rows) > 0 {
-		headers = rows[0]
-		startIdx = 1
+// parseSheet builds the documents of the sheet.
+func (xlp *XlsxParser) parseSheet(s sheet, extraMeta map[string]any, multiSheet bool) ([]*schema.Document, error) {
+	var (
+		ret     []*schema.Document
+		headers []string
+		i       = -1
+
+		// rows of the pending document of ModeSheet, and their row indexes
+		tableRows [][]string
+		rowIdx    []int
+	)
+
+	idSheet := ""
+	if multiSheet || xlp.Config.Mode == ModeSheet {
+		idSheet = s.name
 	}
 
-	// Process rows of data
-	for i := startIdx; i < len(rows); i++ {
-		row := rows[i]
+	newMeta := func(start, end int) map[string]any {
+		meta := make(map[string]any)
+		// Get the Common ExtraMeta
+		if extraMeta != nil {
+			meta[MetaDataExt] = extraMeta
+		}
+		if s.name != "" {
+			meta[MetaDataSheet] = s.name
+		}
+		meta[MetaDataRowStart] = start + 1
+		meta[MetaDataRowEnd] = end + 1
+		return meta
+	}
+
+	flush := func() {
+		if len(tableRows) == 0 {
+			return
+		}
+		ret = append(ret, &schema.Document{
+			ID:       xlp.generateID(idSheet, len(ret)+1),
+			Content:  markdownTable(headers, tableRows),
+			MetaData: newMeta(rowIdx[0], rowIdx[len(rowIdx)-1]),
+		})
+		tableRows, rowIdx = nil, nil
+	}
+
+	err := s.rows(func(row []string) error {
+		i++
+
+		// Process the header
+		if i == 0 && !xlp.Config.NoHeader {
+			headers = row
+			return nil
+		}
+
 		if len(row) == 0 {
-			continue
+			return nil
+		}
+
+		if xlp.Config.Mode == ModeSheet {
+			tableRows = append(tableRows, row)
+			rowIdx = append(rowIdx, i)
+			if xlp.Config.MaxRowsPerDocument > 0 && len(tableRows) >= xlp.Config.MaxRowsPerDocument {
+				flush()
+			}
+			return nil
 		}
+
 		// Convert row data to strings
 		contentParts := make([]string, len(row))
 		for j, cell := range row {
@@ -135,26 +312,131 @@ func (xlp *XlsxParser) Parse(ctx context.Context, reader io.Reader, opts ...pars
 		}
 		content := strings.Join(contentParts, "\t")
 
-		meta := make(map[string]any)
+		meta := newMeta(i, i)
 
 		// Build the row's Meta
 		rowMeta := xlp.buildRowMetaData(row, headers)
 		meta[MetaDataRow] = rowMeta
 
-		// Get the Common ExtraMeta
-		if option.ExtraMeta != nil {
-			meta[MetaDataExt] = option.ExtraMeta
-		}
-
 		// Create New Document
 		nDoc := &schema.Document{
-			ID:       xlp.generateID(i),
+			ID:       xlp.generateID(idSheet, i),
 			Content:  content,
 			MetaData: meta,
 		}
 
 		ret = append(ret, nDoc)
+		return nil
+	})
+	if err != nil {
+		if s.name != "" {
+			return nil, fmt.Errorf("read sheet %s failed: %w", s.name, err)
+		}
+		return nil, err
 	}
+	flush()
 
 	return ret, nil
 }
+
+// workbookRows iterates the rows of the sheet with the streaming row reader of excelize.
+func workbookRows(xlFile *excelize.File, name string) func(yield func(row []string) error) error {
+	return func(yield func(row []string) error) error {
+		rows, err := xlFile.Rows(name)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			row, err := rows.Columns()
+			if err != nil {
+				return err
+			}
+			if err = yield(row); err != nil {
+				return err
+			}
+		}
+
+		return rows.Error()
+	}
+}
+
+// delimitedRows iterates the records of a CSV or TSV file.
+func delimitedRows(reader io.Reader, format Format) func(yield func(row []string) error) error {
+	return func(yield func(row []string) error) error {
+		br := bufio.NewReader(reader)
+		// skip the UTF-8 byte order mark written by spreadsheet applications
+		if bom, err := br.Peek(3); err == nil && string(bom) == "\xef\xbb\xbf" {
+			_, _ = br.Discard(3)
+		}
+
+		r := csv.NewReader(br)
+		if format == FormatTSV {
+			r.Comma = '\t'
+		}
+		r.FieldsPerRecord = -1
+		r.LazyQuotes = true
+
+		for {
+			record, err := r.Read()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			// a line of empty cells is an empty row
+			if strings.TrimSpace(strings.Join(record, "")) == "" {
+				record = nil
+			}
+			if err = yield(record); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// markdownTable renders the rows as a markdown table, with the header, or column names
+// such as A, B, C when there is none. Rows shorter than the widest one are padded with empty cells.
+func markdownTable(headers []string, rows [][]string) string {
+	width := len(headers)
+	for _, row := range rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+
+	header := make([]string, width)
+	for j := range header {
+		if j < len(headers) && strings.TrimSpace(headers[j]) != "" {
+			header[j] = headers[j]
+		} else if len(headers) == 0 {
+			header[j], _ = excelize.ColumnNumberToName(j + 1)
+		}
+	}
+
+	var sb strings.Builder
+	writeRow := func(row []string) {
+		sb.WriteString("|")
+		for j := 0; j < width; j++ {
+			cell := ""
+			if j < len(row) {
+				cell = strings.ReplaceAll(strings.Join(strings.Fields(row[j]), " "), "|", `\|`)
+			}
+			if cell == "" {
+				cell = " " // Empty cell placeholder
+			}
+			sb.WriteString(" " + cell + " |")
+		}
+		sb.WriteString("\n")
+	}
+
+	writeRow(header)
+	sb.WriteString("|" + strings.Repeat(" --- |", width) + "\n")
+	for _, row := range rows {
+		writeRow(row)
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -19,10 +19,12 @@ package xlsx
 import (
 	"context"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/cloudwego/eino/components/document/parser"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestXlsxParser_Parse(t *testing.T) {
@@ -102,4 +104,128 @@ func TestXlsxParser_Parse(t *testing.T) {
 		assert.Equal(t, map[string]any{}, docs[0].MetaData[MetaDataRow])
 		assert.Equal(t, map[string]any{"test": "test"}, docs[0].MetaData[MetaDataExt])
 	})
+
+	t.Run("TestXlsxParser_WithSheets", func(t *testing.T) {
+		ctx := context.Background()
+
+		f, err := os.Open("./examples/testdata/test.xlsx")
+		require.NoError(t, err)
+		defer f.Close()
+
+		p, err := NewXlsxParser(ctx, &Config{
+			Sheets: []string{"Sheet[23]"},
+		})
+		require.NoError(t, err)
+
+		docs, err := p.Parse(ctx, f)
+		require.NoError(t, err)
+		require.Len(t, docs, 5)
+		assert.Equal(t, "Sheet2_1", docs[0].ID)
+		assert.Equal(t, "张三\t男\t21", docs[0].Content)
+		assert.Equal(t, "Sheet2", docs[0].MetaData[MetaDataSheet])
+		assert.Equal(t, 2, docs[0].MetaData[MetaDataRowStart])
+		assert.Equal(t, 2, docs[0].MetaData[MetaDataRowEnd])
+		assert.Equal(t, "Sheet3_1", docs[4].ID)
+		assert.Equal(t, "good\tgame", docs[4].Content)
+		assert.Equal(t, "Sheet3", docs[4].MetaData[MetaDataSheet])
+	})
+
+	t.Run("TestXlsxParser_WithUnknownSheet", func(t *testing.T) {
+		ctx := context.Background()
+
+		f, err := os.Open("./examples/testdata/test.xlsx")
+		require.NoError(t, err)
+		defer f.Close()
+
+		p, err := NewXlsxParser(ctx, &Config{Sheets: []string{"missing*"}})
+		require.NoError(t, err)
+
+		_, err = p.Parse(ctx, f)
+		assert.Error(t, err)
+	})
+
+	t.Run("TestXlsxParser_WithSheetMode", func(t *testing.T) {
+		ctx := context.Background()
+
+		f, err := os.Open("./examples/testdata/test.xlsx")
+		require.NoError(t, err)
+		defer f.Close()
+
+		p, err := NewXlsxParser(ctx, &Config{
+			Sheets:             []string{"*"},
+			Mode:               ModeSheet,
+			MaxRowsPerDocument: 3,
+		})
+		require.NoError(t, err)
+
+		docs, err := p.Parse(ctx, f, parser.WithExtraMeta(map[string]any{"test": "test"}))
+		require.NoError(t, err)
+		require.Len(t, docs, 5)
+
+		assert.Equal(t, "Sheet1_1", docs[0].ID)
+		assert.Equal(t, "| 姓名 | 性别 | 年龄 |\n| --- | --- | --- |\n| 张三 | 男 | 21 |\n| 李四 | 男 | 22 |\n| 李华 | 男 | 23 |", docs[0].Content)
+		assert.Equal(t, "Sheet1", docs[0].MetaData[MetaDataSheet])
+		assert.Equal(t, 2, docs[0].MetaData[MetaDataRowStart])
+		assert.Equal(t, 4, docs[0].MetaData[MetaDataRowEnd])
+		assert.Equal(t, map[string]any{"test": "test"}, docs[0].MetaData[MetaDataExt])
+
+		assert.Equal(t, "Sheet1_2", docs[1].ID)
+		assert.Equal(t, "| 姓名 | 性别 | 年龄 |\n| --- | --- | --- |\n| 王丽 | 女 | 22 |", docs[1].Content)
+		assert.Equal(t, 5, docs[1].MetaData[MetaDataRowStart])
+		assert.Equal(t, 5, docs[1].MetaData[MetaDataRowEnd])
+
+		assert.Equal(t, "Sheet3", docs[4].MetaData[MetaDataSheet])
+		assert.Equal(t, "| hello | world |\n| --- | --- |\n| good | game |", docs[4].Content)
+	})
+
+	t.Run("TestXlsxParser_WithCSV", func(t *testing.T) {
+		ctx := context.Background()
+
+		p, err := NewXlsxParser(ctx, nil)
+		require.NoError(t, err)
+
+		content := "\xef\xbb\xbfname,note\n\"lihua\",\"a, b\"\n,\nwang,\"x|y\"\n"
+		docs, err := p.Parse(ctx, strings.NewReader(content), parser.WithURI("data/test.CSV"))
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+		assert.Equal(t, "1", docs[0].ID)
+		assert.Equal(t, "lihua\ta, b", docs[0].Content)
+		assert.Equal(t, map[string]any{"name": "lihua", "note": "a, b"}, docs[0].MetaData[MetaDataRow])
+		assert.NotContains(t, docs[0].MetaData, MetaDataSheet)
+		assert.Equal(t, 4, docs[1].MetaData[MetaDataRowStart])
+	})
+
+	t.Run("TestXlsxParser_WithTSVSheetMode", func(t *testing.T) {
+		ctx := context.Background()
+
+		p, err := NewXlsxParser(ctx, &Config{
+			Format:   FormatTSV,
+			Mode:     ModeSheet,
+			NoHeader: true,
+			IDPrefix: "tsv_",
+		})
+		require.NoError(t, err)
+
+		docs, err := p.Parse(ctx, strings.NewReader("a\tb|c\n1\n"))
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, "tsv_1", docs[0].ID)
+		assert.Equal(t, "| A | B |\n| --- | --- |\n| a | b\\|c |\n| 1 |   |", docs[0].Content)
+		assert.Equal(t, 1, docs[0].MetaData[MetaDataRowStart])
+		assert.Equal(t, 2, docs[0].MetaData[MetaDataRowEnd])
+	})
+
+	t.Run("TestXlsxParser_WithInvalidConfig", func(t *testing.T) {
+		ctx := context.Background()
+
+		for _, config := range []*Config{
+			{Mode: "cell"},
+			{Format: "ods"},
+			{Sheets: []string{"["}},
+			{MaxRowsPerDocument: -1},
+		} {
+			_, err := NewXlsxParser(ctx, config)
+			assert.Error(t, err)
+		}
+	})
 }
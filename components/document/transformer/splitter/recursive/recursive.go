@@ -25,6 +25,13 @@ import (
 	"github.com/cloudwego/eino/schema"
 )
 
+const (
+	// MetaKeyChunkStart and MetaKeyChunkEnd are the byte offsets of the chunk in the content of the
+	// original document, the end being exclusive, set when Config.RecordOffsets is true.
+	MetaKeyChunkStart = "_chunk_start"
+	MetaKeyChunkEnd   = "_chunk_end"
+)
+
 type KeepType uint8
 
 const (
@@ -53,12 +60,16 @@ type Config struct {
 	// ["\n", ".", "?", "!"] by default.
 	Separators []string
 	// LenFunc is used to calculate string length. Use builtin function len() by default.
+	// Use NewTokenLenFunc to measure ChunkSize and OverlapSize in model tokens.
 	LenFunc func(string) int
 	// KeepType specifies if separator will be kept in split chunks. Discard separator by default.
 	KeepType KeepType
 	// IDGenerator is an optional function to generate new IDs for split chunks.
 	// If nil, the original document ID will be used for all splits.
 	IDGenerator IDGenerator
+	// RecordOffsets records the offsets of each chunk in the original content in its metadata,
+	// see MetaKeyChunkStart and MetaKeyChunkEnd.
+	RecordOffsets bool
 }
 
 // NewSplitter create a recursive splitter.
@@ -89,6 +100,7 @@ func NewSplitter(ctx context.Context, config *Config) (document.Transformer, err
 		separators:  seps,
 		keepType:    config.KeepType,
 		idGenerator: idGenerator,
+		offsets:     config.RecordOffsets,
 	}, nil
 }
 
@@ -99,17 +111,33 @@ type splitter struct {
 	separators  []string
 	keepType    KeepType
 	idGenerator IDGenerator
+	offsets     bool
 }
 
 func (s *splitter) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
 	ret := make([]*schema.Document, 0, len(docs))
 	for _, doc := range docs {
 		splits := s.splitText(ctx, doc.Content, s.separators)
+		from := 0
 		for i, split := range splits {
+			meta := deepCopyMap(doc.MetaData)
+			if s.offsets {
+				// chunks are substrings of the content in order, overlapping ones start after the previous one
+				if idx := strings.Index(doc.Content[from:], split); idx >= 0 {
+					start := from + idx
+					if meta == nil {
+						meta = make(map[string]interface{}, 2)
+					}
+					meta[MetaKeyChunkStart] = start
+					meta[MetaKeyChunkEnd] = start + len(split)
+					from = start + 1
+				}
+			}
+
 			ret = append(ret, &schema.Document{
 				ID:       s.idGenerator(ctx, doc.ID, i),
 				Content:  split,
-				MetaData: deepCopyMap(doc.MetaData),
+				MetaData: meta,
 			})
 		}
 	}
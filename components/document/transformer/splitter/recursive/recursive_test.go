@@ -127,3 +127,38 @@ func TestRecursiveSplitter(t *testing.T) {
 		})
 	}
 }
+
+func TestRecursiveSplitter_Offsets(t *testing.T) {
+	ctx := context.Background()
+	content := "1a23a45a67890c1a234b5678a90"
+
+	for _, keepType := range []KeepType{KeepTypeNone, KeepTypeStart, KeepTypeEnd} {
+		s, err := NewSplitter(ctx, &Config{
+			ChunkSize:     5,
+			OverlapSize:   2,
+			Separators:    []string{"a", "b", "c"},
+			KeepType:      keepType,
+			RecordOffsets: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		docs, err := s.Transform(ctx, []*schema.Document{{Content: content}})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		prev := -1
+		for _, doc := range docs {
+			start, ok1 := doc.MetaData[MetaKeyChunkStart].(int)
+			end, ok2 := doc.MetaData[MetaKeyChunkEnd].(int)
+			if !ok1 || !ok2 {
+				t.Fatalf("keep type %d: missing offsets in %v", keepType, doc.MetaData)
+			}
+			if content[start:end] != doc.Content || start <= prev {
+				t.Errorf("keep type %d: offsets [%d, %d) don't point to %q", keepType, start, end, doc.Content)
+			}
+			prev = start
+		}
+	}
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recursive
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkoukk/tiktoken-go"
+)
+
+// Encoding is the name of a BPE encoding of OpenAI models.
+type Encoding string
+
+const (
+	// EncodingCL100kBase is the encoding of gpt-4, gpt-3.5-turbo and text-embedding-3 models.
+	EncodingCL100kBase Encoding = "cl100k_base"
+	// EncodingO200kBase is the encoding of gpt-4o and o-series models.
+	EncodingO200kBase Encoding = "o200k_base"
+)
+
+// encodingPatterns are the regexps splitting texts into words before merging their bytes.
+var encodingPatterns = map[Encoding]string{
+	EncodingCL100kBase: `(?i:'s|'t|'re|'ve|'m|'ll|'d)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]+|\s+(?!\S)|\s+`,
+	EncodingO200kBase: strings.Join([]string{
+		`[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]*[\p{Ll}\p{Lm}\p{Lo}\p{M}]+(?i:'s|'t|'re|'ve|'m|'ll|'d)?`,
+		`[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]+[\p{Ll}\p{Lm}\p{Lo}\p{M}]*(?i:'s|'t|'re|'ve|'m|'ll|'d)?`,
+		`\p{N}{1,3}`,
+		` ?[^\s\p{L}\p{N}]+[\r\n/]*`,
+		`\s*[\r\n]+`,
+		`\s+(?!\S)`,
+		`\s+`,
+	}, "|"),
+}
+
+// NewTokenLenFunc returns a LenFunc counting the tokens of texts with the BPE encoding, so that ChunkSize and
+// OverlapSize are measured in model tokens. Special tokens such as <|endoftext|> are counted as plain text.
+//
+// vocab is the vocabulary of the encoding in the tiktoken format, one base64 token and its rank per line,
+// as published at https://openaipublic.blob.core.windows.net/encodings/<encoding>.tiktoken.
+// It can be embedded in the binary with go:embed, or read from a local file with NewTokenLenFuncFromFile.
+func NewTokenLenFunc(encoding Encoding, vocab io.Reader) (func(string) int, error) {
+	pattern, ok := encodingPatterns[encoding]
+	if !ok {
+		return nil, fmt.Errorf("unsupported encoding: %s", encoding)
+	}
+
+	ranks, err := readVocab(vocab)
+	if err != nil {
+		return nil, fmt.Errorf("read %s vocab failed: %w", encoding, err)
+	}
+
+	bpe, err := tiktoken.NewCoreBPE(ranks, map[string]int{}, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("create %s tokenizer failed: %w", encoding, err)
+	}
+	tk := tiktoken.NewTiktoken(bpe, &tiktoken.Encoding{
+		Name:           string(encoding),
+		PatStr:         pattern,
+		MergeableRanks: ranks,
+	}, map[string]any{})
+
+	return func(s string) int {
+		return len(tk.EncodeOrdinary(s))
+	}, nil
+}
+
+// NewTokenLenFuncFromFile is NewTokenLenFunc with the vocabulary read from a local file.
+func NewTokenLenFuncFromFile(encoding Encoding, vocabPath string) (func(string) int, error) {
+	f, err := os.Open(vocabPath)
+	if err != nil {
+		return nil, fmt.Errorf("open vocab file failed: %w", err)
+	}
+	defer f.Close()
+
+	return NewTokenLenFunc(encoding, f)
+}
+
+// readVocab reads the mergeable ranks of a tiktoken file.
+func readVocab(r io.Reader) (map[string]int, error) {
+	ranks := make(map[string]int)
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		token, rank, ok := strings.Cut(text, " ")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expect a token and its rank", line)
+		}
+		decoded, err := base64.StdEncoding.DecodeString(token)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid token: %w", line, err)
+		}
+		n, err := strconv.Atoi(rank)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid rank: %w", line, err)
+		}
+		ranks[string(decoded)] = n
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(ranks) == 0 {
+		return nil, fmt.Errorf("empty vocab")
+	}
+
+	return ranks, nil
+}
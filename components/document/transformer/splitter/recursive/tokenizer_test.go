@@ -0,0 +1,144 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recursive
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// testVocab builds a tiny tiktoken vocab of single bytes and the merges of the words.
+func testVocab(words ...string) string {
+	var sb strings.Builder
+	rank := 0
+	for b := 0; b < 256; b++ {
+		sb.WriteString(fmt.Sprintf("%s %d\n", base64.StdEncoding.EncodeToString([]byte{byte(b)}), rank))
+		rank++
+	}
+	for _, w := range words {
+		for i := 2; i <= len(w); i++ {
+			sb.WriteString(fmt.Sprintf("%s %d\n", base64.StdEncoding.EncodeToString([]byte(w[:i])), rank))
+			rank++
+		}
+	}
+	return sb.String()
+}
+
+func TestNewTokenLenFunc(t *testing.T) {
+	vocab := testVocab("the", " the", " cat")
+
+	for _, encoding := range []Encoding{EncodingCL100kBase, EncodingO200kBase} {
+		lenFunc, err := NewTokenLenFunc(encoding, strings.NewReader(vocab))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for text, want := range map[string]int{
+			"":                0,
+			"the":             1,
+			"the cat":         2,
+			"the cat the cat": 4,
+			"the the dog":     6, // " dog" is not in the vocab, one token per byte
+			"the\n\nthe cat":  5,
+			"中":               3,
+			"<|endoftext|>":   len("<|endoftext|>"),
+		} {
+			if got := lenFunc(text); got != want {
+				t.Errorf("%s: len(%q) = %d, want %d", encoding, text, got, want)
+			}
+		}
+	}
+
+	t.Run("from file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "test.tiktoken")
+		if err := os.WriteFile(path, []byte(vocab), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		lenFunc, err := NewTokenLenFuncFromFile(EncodingCL100kBase, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := lenFunc("the cat"); got != 2 {
+			t.Errorf("len = %d, want 2", got)
+		}
+
+		if _, err = NewTokenLenFuncFromFile(EncodingCL100kBase, filepath.Join(t.TempDir(), "missing")); err == nil {
+			t.Error("expect error for missing file")
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			encoding Encoding
+			vocab    string
+		}{
+			"unknown encoding": {encoding: "p50k_base", vocab: vocab},
+			"empty vocab":      {encoding: EncodingCL100kBase, vocab: "\n"},
+			"missing rank":     {encoding: EncodingCL100kBase, vocab: "YQ==\n"},
+			"invalid token":    {encoding: EncodingCL100kBase, vocab: "!!! 1\n"},
+			"invalid rank":     {encoding: EncodingCL100kBase, vocab: "YQ== one\n"},
+		} {
+			if _, err := NewTokenLenFunc(tc.encoding, strings.NewReader(tc.vocab)); err == nil {
+				t.Errorf("%s: expect error", name)
+			}
+		}
+	})
+
+	t.Run("splitter", func(t *testing.T) {
+		lenFunc, err := NewTokenLenFunc(EncodingCL100kBase, strings.NewReader(vocab))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx := context.Background()
+		s, err := NewSplitter(ctx, &Config{
+			ChunkSize:     3,
+			Separators:    []string{"."},
+			LenFunc:       lenFunc,
+			RecordOffsets: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		content := "the cat.the cat.the the the."
+		docs, err := s.Transform(ctx, []*schema.Document{{ID: "doc", Content: content, MetaData: map[string]any{"k": "v"}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []*schema.Document{
+			{ID: "doc", Content: "the cat", MetaData: map[string]any{"k": "v", MetaKeyChunkStart: 0, MetaKeyChunkEnd: 7}},
+			{ID: "doc", Content: "the cat", MetaData: map[string]any{"k": "v", MetaKeyChunkStart: 8, MetaKeyChunkEnd: 15}},
+			{ID: "doc", Content: "the the the", MetaData: map[string]any{"k": "v", MetaKeyChunkStart: 16, MetaKeyChunkEnd: 27}},
+		}
+		if !reflect.DeepEqual(docs, want) {
+			t.Errorf("Transform() = %v, want %v", docs, want)
+		}
+		for _, doc := range docs {
+			start, end := doc.MetaData[MetaKeyChunkStart].(int), doc.MetaData[MetaKeyChunkEnd].(int)
+			if content[start:end] != doc.Content {
+				t.Errorf("offsets [%d, %d) don't point to %q", start, end, doc.Content)
+			}
+		}
+	})
+}
@@ -28,6 +28,18 @@ import (
 	"github.com/cloudwego/eino/schema"
 )
 
+const (
+	// MetaKeyChunkStart and MetaKeyChunkEnd are the byte offsets of the chunk in the content of the
+	// original document, the end being exclusive.
+	MetaKeyChunkStart = "_chunk_start"
+	MetaKeyChunkEnd   = "_chunk_end"
+	// MetaKeyDistance is the cosine distance between the first sentence of the chunk and the last one of the
+	// previous chunk, where the split happened. It's unset for the first chunk and for chunks split by MaxChunkSize.
+	MetaKeyDistance = "_distance"
+	// MetaKeyThreshold is the distance threshold of the document computed from Percentile.
+	MetaKeyThreshold = "_threshold"
+)
+
 // IDGenerator generates new IDs for split chunks
 type IDGenerator func(ctx context.Context, originalID string, splitIndex int) string
 
@@ -43,6 +55,12 @@ type Config struct {
 	BufferSize int
 	// MinChunkSize specifies the minimum chunk's size. Chunks with size smaller than MinChunkSize will be concatenated to their adjacent chunks.
 	MinChunkSize int
+	// MaxChunkSize specifies the maximum chunk's size, no limit by default. Chunks with size greater than MaxChunkSize
+	// are split recursively, by sentences, then words, then characters, as the recursive splitter does.
+	// It takes precedence over MinChunkSize.
+	MaxChunkSize int
+	// BatchSize specifies how many sentences are embedded per Embedding call, all sentences of a document at once by default.
+	BatchSize int
 	// Separators are sequentially used to split text. ["\n", ".", "?", "!"] by default.
 	Separators []string
 	// LenFunc is used to calculate string length. Use builtin function len() by default.
@@ -58,6 +76,12 @@ func NewSplitter(ctx context.Context, config *Config) (document.Transformer, err
 	if config.Embedding == nil {
 		return nil, fmt.Errorf("embedding should not be nil")
 	}
+	if config.MaxChunkSize < 0 || config.BatchSize < 0 || config.BufferSize < 0 {
+		return nil, fmt.Errorf("max chunk size, batch size and buffer size must be greater than or equal to zero")
+	}
+	if config.MaxChunkSize > 0 && config.MinChunkSize > config.MaxChunkSize {
+		return nil, fmt.Errorf("min chunk size must not be greater than max chunk size")
+	}
 	lenFunc := config.LenFunc
 	if lenFunc == nil {
 		lenFunc = func(s string) int { return len(s) }
@@ -78,6 +102,8 @@ func NewSplitter(ctx context.Context, config *Config) (document.Transformer, err
 		embedding:    config.Embedding,
 		bufferSize:   config.BufferSize,
 		minChunkSize: config.MinChunkSize,
+		maxChunkSize: config.MaxChunkSize,
+		batchSize:    config.BatchSize,
 		separators:   seps,
 		lenFunc:      lenFunc,
 		percentile:   percentile,
@@ -89,6 +115,8 @@ type splitter struct {
 	embedding    embedding.Embedder
 	bufferSize   int
 	minChunkSize int
+	maxChunkSize int
+	batchSize    int
 	separators   []string
 	lenFunc      func(s string) int
 	percentile   float64
@@ -98,35 +126,48 @@ type splitter struct {
 func (s *splitter) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
 	ret := make([]*schema.Document, 0, len(docs))
 	for _, doc := range docs {
-		splits, err := s.splitText(ctx, doc.Content, s.separators)
+		splits, threshold, err := s.splitText(ctx, doc.Content, s.separators)
 		if err != nil {
 			return nil, fmt.Errorf("split document[%s] fail: %w", doc.ID, err)
 		}
 		for i, split := range splits {
+			meta := deepCopyMap(doc.MetaData)
+			if meta == nil {
+				meta = make(map[string]interface{}, 4)
+			}
+			meta[MetaKeyChunkStart] = split.start
+			meta[MetaKeyChunkEnd] = split.start + len(split.text)
+			meta[MetaKeyThreshold] = threshold
+			if split.distance != nil {
+				meta[MetaKeyDistance] = *split.distance
+			}
+
 			ret = append(ret, &schema.Document{
 				ID:       s.idGenerator(ctx, doc.ID, i),
-				Content:  split,
-				MetaData: deepCopyMap(doc.MetaData),
+				Content:  split.text,
+				MetaData: meta,
 			})
 		}
 	}
 	return ret, nil
 }
 
-func (s *splitter) splitText(ctx context.Context, text string, separators []string) ([]string, error) {
+// chunk is a split of the text.
+type chunk struct {
+	text     string
+	start    int      // byte offset in the text
+	distance *float64 // distance to the previous chunk when split on it
+}
+
+func (s *splitter) splitText(ctx context.Context, text string, separators []string) ([]chunk, float64, error) {
 	texts := []string{text}
 	// split
 	for i := range s.separators {
 		texts = splitTexts(texts, separators[i])
 	}
 
-	sentencesLength := make([]int, len(texts))
-	for i := range texts {
-		sentencesLength[i] = s.lenFunc(texts[i])
-	}
-
 	if len(texts) == 1 {
-		return texts, nil
+		return s.limitSize([]chunk{{text: text}}), 0, nil
 	}
 
 	// combine
@@ -143,13 +184,9 @@ func (s *splitter) splitText(ctx context.Context, text string, separators []stri
 	}
 
 	// embedding
-	var vectors [][]float64
-	v, err := s.embedding.EmbedStrings(ctx, combinedSentences)
+	vectors, err := s.embed(ctx, combinedSentences)
 	if err != nil {
-		return nil, err
-	}
-	for i := range v {
-		vectors = append(vectors, v[i])
+		return nil, 0, err
 	}
 
 	// cosine distances
@@ -165,18 +202,140 @@ func (s *splitter) splitText(ctx context.Context, text string, separators []stri
 			splitIndexes = append(splitIndexes, i)
 		}
 	}
-	var ret []string
+
+	// offsets of the sentences, which are consecutive substrings of the text
+	offsets := make([]int, len(texts)+1)
+	for i := range texts {
+		offsets[i+1] = offsets[i] + len(texts[i])
+	}
+	newChunk := func(from, to int) chunk {
+		c := chunk{text: strings.Join(texts[from:to], ""), start: offsets[from]}
+		if from > 0 {
+			c.distance = &distances[from]
+		}
+		return c
+	}
+
+	var ret []chunk
 	var startIndex int
 	for i := range splitIndexes {
-		chunk := strings.Join(texts[startIndex:splitIndexes[i]], "")
-		if len(chunk) < s.minChunkSize {
+		if s.lenFunc(strings.Join(texts[startIndex:splitIndexes[i]], "")) < s.minChunkSize {
 			continue
 		}
-		ret = append(ret, chunk)
+		ret = append(ret, newChunk(startIndex, splitIndexes[i]))
 		startIndex = splitIndexes[i]
 	}
-	ret = append(ret, strings.Join(texts[startIndex:], ""))
-	return ret, nil
+	last := newChunk(startIndex, len(texts))
+	if len(ret) > 0 && s.lenFunc(last.text) < s.minChunkSize {
+		// too small, concatenated to the previous chunk
+		prev := ret[len(ret)-1]
+		prev.text += last.text
+		ret[len(ret)-1] = prev
+	} else {
+		ret = append(ret, last)
+	}
+
+	return s.limitSize(ret), threshold, nil
+}
+
+// embed embeds the texts in batches of batchSize.
+func (s *splitter) embed(ctx context.Context, texts []string) ([][]float64, error) {
+	batchSize := s.batchSize
+	if batchSize <= 0 {
+		batchSize = len(texts)
+	}
+
+	vectors := make([][]float64, 0, len(texts))
+	for start := 0; start < len(texts); start += batchSize {
+		end := start + batchSize
+		if end > len(texts) {
+			end = len(texts)
+		}
+		v, err := s.embedding.EmbedStrings(ctx, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		if len(v) != end-start {
+			return nil, fmt.Errorf("embedding returned %d vectors for %d texts", len(v), end-start)
+		}
+		vectors = append(vectors, v...)
+	}
+	return vectors, nil
+}
+
+// fallbackSeparators are used to split chunks larger than maxChunkSize, after the separators.
+// The empty separator splits into characters.
+var fallbackSeparators = []string{" ", ""}
+
+// limitSize splits the chunks larger than maxChunkSize.
+func (s *splitter) limitSize(chunks []chunk) []chunk {
+	if s.maxChunkSize <= 0 {
+		return chunks
+	}
+
+	seps := append(append([]string{}, s.separators...), fallbackSeparators...)
+	ret := make([]chunk, 0, len(chunks))
+	for _, c := range chunks {
+		if s.lenFunc(c.text) <= s.maxChunkSize {
+			ret = append(ret, c)
+			continue
+		}
+		start := c.start
+		for i, piece := range s.splitBySize(c.text, seps) {
+			p := chunk{text: piece, start: start}
+			if i == 0 {
+				p.distance = c.distance
+			}
+			ret = append(ret, p)
+			start += len(piece)
+		}
+	}
+	return ret
+}
+
+// splitBySize splits the text on the first separator into pieces merged up to maxChunkSize,
+// recursively splitting the pieces still too large with the next separators.
+// Separators are kept, so that the pieces are consecutive substrings of the text.
+func (s *splitter) splitBySize(text string, separators []string) []string {
+	if s.lenFunc(text) <= s.maxChunkSize || len(separators) == 0 {
+		return []string{text}
+	}
+
+	var pieces []string
+	if separators[0] == "" {
+		for _, r := range text {
+			pieces = append(pieces, string(r))
+		}
+	} else {
+		pieces = strings.SplitAfter(text, separators[0])
+	}
+
+	var (
+		ret     []string
+		current string
+	)
+	for _, piece := range pieces {
+		if piece == "" {
+			continue
+		}
+		if s.lenFunc(piece) > s.maxChunkSize {
+			if current != "" {
+				ret = append(ret, current)
+				current = ""
+			}
+			ret = append(ret, s.splitBySize(piece, separators[1:])...)
+			continue
+		}
+		if current != "" && s.lenFunc(current+piece) > s.maxChunkSize {
+			ret = append(ret, current)
+			current = ""
+		}
+		current += piece
+	}
+	if current != "" {
+		ret = append(ret, current)
+	}
+	return ret
 }
 
 func (s *splitter) GetType() string {
@@ -138,3 +138,85 @@ func TestSemanticSplitter(t *testing.T) {
 		})
 	}
 }
+
+type countingEmbedding struct {
+	randomEmbedding
+	batches []int
+}
+
+func (c *countingEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	c.batches = append(c.batches, len(texts))
+	return c.randomEmbedding.EmbedStrings(ctx, texts, opts...)
+}
+
+func TestSemanticSplitter_Bounds(t *testing.T) {
+	ctx := context.Background()
+	content := "1234567890.1234567890.1234567890.1234567890.1234567890.1234567890 1234567890 1234567890 1234567890."
+
+	emb := &countingEmbedding{randomEmbedding: randomEmbedding{vecLen: 5}}
+	s, err := NewSplitter(ctx, &Config{
+		Embedding:    emb,
+		BufferSize:   1,
+		MinChunkSize: 5,
+		MaxChunkSize: 25,
+		BatchSize:    2,
+		Separators:   []string{"."},
+		Percentile:   0.5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.Transform(ctx, []*schema.Document{{Content: content, MetaData: map[string]any{"k": "v"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 7 sentences, the last one being empty
+	if !reflect.DeepEqual(emb.batches, []int{2, 2, 2, 1}) {
+		t.Errorf("batches = %v", emb.batches)
+	}
+
+	var rebuilt string
+	for i, doc := range got {
+		if len(doc.Content) > 25 {
+			t.Errorf("chunk %d is larger than MaxChunkSize: %q", i, doc.Content)
+		}
+		start, end := doc.MetaData[MetaKeyChunkStart].(int), doc.MetaData[MetaKeyChunkEnd].(int)
+		if content[start:end] != doc.Content {
+			t.Errorf("chunk %d: offsets [%d, %d) don't point to %q", i, start, end, doc.Content)
+		}
+		if _, ok := doc.MetaData[MetaKeyThreshold].(float64); !ok {
+			t.Errorf("chunk %d: missing threshold", i)
+		}
+		// chunks split by MaxChunkSize have no distance
+		if _, ok := doc.MetaData[MetaKeyDistance]; ok && i == 0 {
+			t.Errorf("chunk %d: unexpected distance %v", i, doc.MetaData[MetaKeyDistance])
+		}
+		if doc.MetaData["k"] != "v" {
+			t.Errorf("chunk %d: missing document metadata", i)
+		}
+		rebuilt += doc.Content
+	}
+	if last := got[len(got)-1].Content; last != "1234567890 1234567890." {
+		t.Errorf("last chunk = %q", last)
+	}
+	if rebuilt != content {
+		t.Errorf("chunks don't cover the content: %q", rebuilt)
+	}
+}
+
+func TestNewSplitter_InvalidConfig(t *testing.T) {
+	ctx := context.Background()
+	emb := &randomEmbedding{vecLen: 5}
+	for _, config := range []*Config{
+		{},
+		{Embedding: emb, MaxChunkSize: -1},
+		{Embedding: emb, BatchSize: -1},
+		{Embedding: emb, MinChunkSize: 10, MaxChunkSize: 5},
+	} {
+		if _, err := NewSplitter(ctx, config); err == nil {
+			t.Errorf("NewSplitter(%+v) expects error", config)
+		}
+	}
+}
@@ -31,3 +31,19 @@ type Cacher interface {
 	// If the value is not of type []float64, it returns an error.
 	Get(ctx context.Context, key string) ([]float64, bool, error)
 }
+
+// BatchCacher is an optional extension of [Cacher] for backends that can read and
+// write many keys in a single round trip. The [Embedder] detects it and uses it
+// in place of per-key Get and Set calls.
+type BatchCacher interface {
+	Cacher
+
+	// MSet stores the values in the cache with the given keys.
+	// keys and values must have the same length, existing keys will be overwritten.
+	MSet(ctx context.Context, keys []string, values [][]float64, expire time.Duration) error
+
+	// MGet retrieves the values from the cache with the given keys.
+	// The returned slices are aligned with keys, a key that does not exist
+	// yields a nil value and false at its position.
+	MGet(ctx context.Context, keys []string) ([][]float64, []bool, error)
+}
@@ -19,6 +19,7 @@ package cache
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/cloudwego/eino/components/embedding"
@@ -91,12 +92,7 @@ func NewEmbedder(embedder embedding.Embedder, opts ...Option) (*Embedder, error)
 }
 
 func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
-	var (
-		embeddingsByKey = make(map[int][]float64)
-		embeddingOpts   = embedding.GetCommonOptions(nil, opts...)
-		uncached        []int
-		uncachedTexts   []string
-	)
+	embeddingOpts := embedding.GetCommonOptions(nil, opts...)
 
 	// generate options for the generator
 	var generatorOpt GeneratorOption
@@ -104,18 +100,39 @@ func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...emb
 		generatorOpt.Model = *embeddingOpts.Model
 	}
 
-	// Get cached embeddings and find uncached texts
+	// Collapse duplicate texts, so that each distinct key is looked up and embedded only once
+	var (
+		keys      []string                  // distinct keys, in order of first appearance
+		keyTexts  []string                  // the text of each distinct key
+		positions = make([]int, len(texts)) // the position in keys of each text
+		seen      = make(map[string]int, len(texts))
+	)
 	for idx, text := range texts {
 		key := e.generator.Generate(ctx, text, generatorOpt)
-		emb, ok, err := e.cacher.Get(ctx, key)
-		if err != nil {
-			return nil, err
-		} else if ok {
-			embeddingsByKey[idx] = emb
-		} else {
-			// If the key is not found, we consider it as uncached
-			uncached = append(uncached, idx)
-			uncachedTexts = append(uncachedTexts, text)
+		pos, ok := seen[key]
+		if !ok {
+			pos = len(keys)
+			seen[key] = pos
+			keys = append(keys, key)
+			keyTexts = append(keyTexts, text)
+		}
+		positions[idx] = pos
+	}
+
+	// Get cached embeddings and find uncached texts
+	embeddings, found, err := e.getCached(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		uncached      []int
+		uncachedTexts []string
+	)
+	for pos := range keys {
+		if !found[pos] {
+			uncached = append(uncached, pos)
+			uncachedTexts = append(uncachedTexts, keyTexts[pos])
 		}
 	}
 
@@ -125,26 +142,66 @@ func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...emb
 		if err != nil {
 			return nil, err
 		}
+		if len(uncachedEmbeddings) != len(uncachedTexts) {
+			return nil, fmt.Errorf("embedding/cache: embedder returned %d embeddings for %d texts",
+				len(uncachedEmbeddings), len(uncachedTexts))
+		}
 
-		// Cache the uncachedEmbeddings
-		for i, idx := range uncached {
-			key := e.generator.Generate(ctx, texts[idx], generatorOpt)
-			if err := e.cacher.Set(ctx, key, uncachedEmbeddings[i], e.expiration); err != nil {
-				_ = err // skip caching if there's an error
-			}
-			embeddingsByKey[idx] = uncachedEmbeddings[i]
+		uncachedKeys := make([]string, len(uncached))
+		for i, pos := range uncached {
+			uncachedKeys[i] = keys[pos]
+			embeddings[pos] = uncachedEmbeddings[i]
 		}
+
+		// Cache the uncachedEmbeddings, skip caching if there's an error
+		e.setCached(ctx, uncachedKeys, uncachedEmbeddings)
 	}
 
-	// Convert the map to a slice
+	// Expand the distinct embeddings back to the order of texts
 	result := make([][]float64, len(texts))
-	for i := range texts {
-		if emb, ok := embeddingsByKey[i]; ok {
-			result[i] = emb
-		} else {
-			result[i] = nil // it seems that such a case should not happen
-		}
+	for idx, pos := range positions {
+		result[idx] = embeddings[pos]
 	}
 
 	return result, nil
 }
+
+// getCached looks up keys in the cacher, in a single call if it is a [BatchCacher].
+func (e *Embedder) getCached(ctx context.Context, keys []string) ([][]float64, []bool, error) {
+	if bc, ok := e.cacher.(BatchCacher); ok && len(keys) > 0 {
+		values, found, err := bc.MGet(ctx, keys)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(values) != len(keys) || len(found) != len(keys) {
+			return nil, nil, fmt.Errorf("embedding/cache: cacher returned %d values for %d keys", len(values), len(keys))
+		}
+		return values, found, nil
+	}
+
+	values := make([][]float64, len(keys))
+	found := make([]bool, len(keys))
+	for i, key := range keys {
+		emb, ok, err := e.cacher.Get(ctx, key)
+		if err != nil {
+			return nil, nil, err
+		}
+		values[i], found[i] = emb, ok
+	}
+	return values, found, nil
+}
+
+// setCached stores the embeddings in the cacher, in a single call if it is a [BatchCacher].
+// Errors are ignored, a failed write only costs a cache miss later.
+func (e *Embedder) setCached(ctx context.Context, keys []string, values [][]float64) {
+	if bc, ok := e.cacher.(BatchCacher); ok {
+		_ = bc.MSet(ctx, keys, values, e.expiration)
+		return
+	}
+
+	for i, key := range keys {
+		if err := e.cacher.Set(ctx, key, values[i], e.expiration); err != nil {
+			_ = err // skip caching if there's an error
+		}
+	}
+}
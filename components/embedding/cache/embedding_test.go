@@ -199,3 +199,112 @@ func TestEmbedder_EmbedStrings(t *testing.T) {
 		me.AssertExpectations(t)
 	})
 }
+
+type mockBatchCacher struct {
+	mockCacher
+}
+
+var _ BatchCacher = (*mockBatchCacher)(nil)
+
+func (m *mockBatchCacher) MGet(ctx context.Context, keys []string) ([][]float64, []bool, error) {
+	args := m.Called(ctx, keys)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([][]float64), args.Get(1).([]bool), args.Error(2)
+}
+
+func (m *mockBatchCacher) MSet(ctx context.Context, keys []string, values [][]float64, expire time.Duration) error {
+	args := m.Called(ctx, keys, values, expire)
+	return args.Error(0)
+}
+
+func TestEmbedder_EmbedStrings_Duplicates(t *testing.T) {
+	ctx := context.Background()
+	texts := []string{"foo", "bar", "foo", "foo"}
+	expiration := time.Minute
+	generatorOpt := GeneratorOption{}
+
+	mc := new(mockCacher)
+	me := new(mockEmbedder)
+	e, err := NewEmbedder(me, WithCacher(mc), WithGenerator(NewSimpleGenerator()), WithExpiration(expiration))
+	require.NoError(t, err)
+
+	key0 := e.generator.Generate(ctx, "foo", generatorOpt)
+	key1 := e.generator.Generate(ctx, "bar", generatorOpt)
+
+	mc.On("Get", mock.Anything, key0).Return(nil, false, nil).Once()
+	mc.On("Get", mock.Anything, key1).Return([]float64{3.3}, true, nil).Once()
+	me.On("EmbedStrings", mock.Anything, []string{"foo"}, mock.Anything).Return([][]float64{{1.1}}, nil).Once()
+	mc.On("Set", mock.Anything, key0, []float64{1.1}, expiration).Return(nil).Once()
+
+	result, err := e.EmbedStrings(ctx, texts)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float64{{1.1}, {3.3}, {1.1}, {1.1}}, result)
+	mc.AssertExpectations(t)
+	me.AssertExpectations(t)
+}
+
+func TestEmbedder_EmbedStrings_BatchCacher(t *testing.T) {
+	ctx := context.Background()
+	texts := []string{"foo", "bar", "baz", "bar"}
+	expiration := time.Minute
+	generatorOpt := GeneratorOption{}
+
+	t.Run("partial cache hit", func(t *testing.T) {
+		mc := new(mockBatchCacher)
+		me := new(mockEmbedder)
+		e, err := NewEmbedder(me, WithCacher(mc), WithGenerator(NewSimpleGenerator()), WithExpiration(expiration))
+		require.NoError(t, err)
+
+		keys := []string{
+			e.generator.Generate(ctx, "foo", generatorOpt),
+			e.generator.Generate(ctx, "bar", generatorOpt),
+			e.generator.Generate(ctx, "baz", generatorOpt),
+		}
+
+		mc.On("MGet", mock.Anything, keys).Return([][]float64{nil, {2.2}, nil}, []bool{false, true, false}, nil).Once()
+		me.On("EmbedStrings", mock.Anything, []string{"foo", "baz"}, mock.Anything).Return([][]float64{{1.1}, {3.3}}, nil).Once()
+		mc.On("MSet", mock.Anything, []string{keys[0], keys[2]}, [][]float64{{1.1}, {3.3}}, expiration).Return(nil).Once()
+
+		result, err := e.EmbedStrings(ctx, texts)
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{{1.1}, {2.2}, {3.3}, {2.2}}, result)
+		mc.AssertExpectations(t)
+		me.AssertExpectations(t)
+		mc.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+		mc.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("cache mget error", func(t *testing.T) {
+		mc := new(mockBatchCacher)
+		me := new(mockEmbedder)
+		e, err := NewEmbedder(me, WithCacher(mc), WithGenerator(NewSimpleGenerator()), WithExpiration(expiration))
+		require.NoError(t, err)
+
+		mc.On("MGet", mock.Anything, mock.Anything).Return(nil, nil, errors.New("cache error"))
+
+		_, err = e.EmbedStrings(ctx, texts)
+		assert.Error(t, err)
+		mc.AssertExpectations(t)
+		me.AssertExpectations(t)
+	})
+
+	t.Run("cache mset error, ignore", func(t *testing.T) {
+		mc := new(mockBatchCacher)
+		me := new(mockEmbedder)
+		e, err := NewEmbedder(me, WithCacher(mc), WithGenerator(NewSimpleGenerator()), WithExpiration(expiration))
+		require.NoError(t, err)
+
+		key := e.generator.Generate(ctx, "foo", generatorOpt)
+		mc.On("MGet", mock.Anything, []string{key}).Return([][]float64{nil}, []bool{false}, nil)
+		me.On("EmbedStrings", mock.Anything, []string{"foo"}, mock.Anything).Return([][]float64{{1.1}}, nil)
+		mc.On("MSet", mock.Anything, []string{key}, [][]float64{{1.1}}, expiration).Return(errors.New("set error"))
+
+		result, err := e.EmbedStrings(ctx, []string{"foo"})
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{{1.1}}, result)
+		mc.AssertExpectations(t)
+		me.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,235 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino-ext/components/embedding/cache"
+)
+
+// entryOverhead is the estimated number of bytes an entry costs besides its key and vector.
+const entryOverhead = 64
+
+// Cacher is an in-process, least-recently-used [cache.Cacher].
+// It can be bounded by the number of entries, by the estimated memory size, or both.
+// Once a bound is exceeded, the least recently used entries are evicted.
+type Cacher struct {
+	mu         sync.Mutex
+	ll         *list.List // front is the most recently used
+	items      map[string]*list.Element
+	bytes      int64
+	maxEntries int
+	maxBytes   int64
+	now        func() time.Time
+
+	hits        uint64
+	misses      uint64
+	evictions   uint64
+	expirations uint64
+}
+
+type entry struct {
+	key      string
+	value    []float64
+	expireAt time.Time // zero means never expire
+	size     int64
+}
+
+// Stats is a snapshot of the counters of a [Cacher].
+type Stats struct {
+	// Hits is the number of lookups that found a live entry.
+	Hits uint64
+	// Misses is the number of lookups that found no entry or an expired one.
+	Misses uint64
+	// Evictions is the number of entries removed to respect the size bounds.
+	Evictions uint64
+	// Expirations is the number of entries removed because their expiration passed.
+	Expirations uint64
+	// Entries is the number of entries currently held.
+	Entries int
+	// Bytes is the estimated memory size of the entries currently held.
+	Bytes int64
+}
+
+type Option interface {
+	apply(*Cacher)
+}
+
+type optionFunc func(*Cacher)
+
+func (f optionFunc) apply(c *Cacher) {
+	f(c)
+}
+
+// WithMaxEntries bounds the number of entries held by the [Cacher].
+// Zero or a negative value means no bound, which is the default.
+func WithMaxEntries(n int) Option {
+	return optionFunc(func(c *Cacher) {
+		c.maxEntries = n
+	})
+}
+
+// WithMaxBytes bounds the estimated memory size of the entries held by the [Cacher].
+// The size of an entry is estimated as its key length plus 8 bytes per dimension plus a small overhead.
+// Zero or a negative value means no bound, which is the default.
+func WithMaxBytes(n int64) Option {
+	return optionFunc(func(c *Cacher) {
+		c.maxBytes = n
+	})
+}
+
+var _ cache.BatchCacher = (*Cacher)(nil)
+
+// NewCacher creates a new in-memory [Cacher].
+func NewCacher(opts ...Option) *Cacher {
+	c := &Cacher{
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+		now:   time.Now,
+	}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c
+}
+
+// Set stores a copy of value with the given key.
+// An expire of zero or less means the entry never expires, as in Redis.
+func (c *Cacher) Set(_ context.Context, key string, value []float64, expire time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(key, value, expire)
+	return nil
+}
+
+// Get retrieves a copy of the value with the given key.
+func (c *Cacher) Get(_ context.Context, key string) ([]float64, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	value, ok := c.get(key)
+	return value, ok, nil
+}
+
+func (c *Cacher) MSet(_ context.Context, keys []string, values [][]float64, expire time.Duration) error {
+	if len(keys) != len(values) {
+		return fmt.Errorf("memory cacher: got %d keys but %d values", len(keys), len(values))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, key := range keys {
+		c.set(key, values[i], expire)
+	}
+	return nil
+}
+
+func (c *Cacher) MGet(_ context.Context, keys []string) ([][]float64, []bool, error) {
+	values := make([][]float64, len(keys))
+	found := make([]bool, len(keys))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, key := range keys {
+		values[i], found[i] = c.get(key)
+	}
+	return values, found, nil
+}
+
+// Stats returns a snapshot of the counters of the [Cacher].
+func (c *Cacher) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return Stats{
+		Hits:        c.hits,
+		Misses:      c.misses,
+		Evictions:   c.evictions,
+		Expirations: c.expirations,
+		Entries:     c.ll.Len(),
+		Bytes:       c.bytes,
+	}
+}
+
+func (c *Cacher) set(key string, value []float64, expire time.Duration) {
+	e := &entry{
+		key:   key,
+		value: append([]float64(nil), value...),
+		size:  int64(len(key)+8*len(value)) + entryOverhead,
+	}
+	if expire > 0 {
+		e.expireAt = c.now().Add(expire)
+	}
+
+	if elem, ok := c.items[key]; ok {
+		c.bytes -= elem.Value.(*entry).size
+		elem.Value = e
+		c.ll.MoveToFront(elem)
+	} else {
+		c.items[key] = c.ll.PushFront(e)
+	}
+	c.bytes += e.size
+
+	for c.overflow() {
+		c.removeElement(c.ll.Back())
+		c.evictions++
+	}
+}
+
+func (c *Cacher) get(key string) ([]float64, bool) {
+	elem, ok := c.items[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+
+	e := elem.Value.(*entry)
+	if !e.expireAt.IsZero() && !c.now().Before(e.expireAt) {
+		c.removeElement(elem)
+		c.expirations++
+		c.misses++
+		return nil, false
+	}
+
+	c.ll.MoveToFront(elem)
+	c.hits++
+	return append([]float64(nil), e.value...), true
+}
+
+// overflow reports whether a bound is exceeded. The most recently set entry
+// is always kept, even if it alone exceeds the byte bound.
+func (c *Cacher) overflow() bool {
+	if c.ll.Len() <= 1 {
+		return false
+	}
+	return (c.maxEntries > 0 && c.ll.Len() > c.maxEntries) ||
+		(c.maxBytes > 0 && c.bytes > c.maxBytes)
+}
+
+func (c *Cacher) removeElement(elem *list.Element) {
+	e := c.ll.Remove(elem).(*entry)
+	delete(c.items, e.key)
+	c.bytes -= e.size
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacher(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Set and Get", func(t *testing.T) {
+		c := NewCacher()
+		value := []float64{1.1, 2.2}
+
+		require.NoError(t, c.Set(ctx, "k", value, 0))
+		value[0] = 9.9 // the cacher keeps its own copy
+
+		got, ok, err := c.Get(ctx, "k")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []float64{1.1, 2.2}, got)
+
+		got, ok, err = c.Get(ctx, "missing")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.Nil(t, got)
+
+		assert.Equal(t, Stats{Hits: 1, Misses: 1, Entries: 1, Bytes: 1 + 16 + entryOverhead}, c.Stats())
+	})
+
+	t.Run("expiration", func(t *testing.T) {
+		now := time.Unix(0, 0)
+		c := NewCacher()
+		c.now = func() time.Time { return now }
+
+		require.NoError(t, c.Set(ctx, "short", []float64{1}, time.Second))
+		require.NoError(t, c.Set(ctx, "forever", []float64{2}, 0))
+
+		now = now.Add(time.Second)
+
+		_, ok, _ := c.Get(ctx, "short")
+		assert.False(t, ok)
+		_, ok, _ = c.Get(ctx, "forever")
+		assert.True(t, ok)
+
+		stats := c.Stats()
+		assert.Equal(t, uint64(1), stats.Expirations)
+		assert.Equal(t, 1, stats.Entries)
+	})
+
+	t.Run("evict by entries", func(t *testing.T) {
+		c := NewCacher(WithMaxEntries(2))
+
+		require.NoError(t, c.Set(ctx, "a", []float64{1}, 0))
+		require.NoError(t, c.Set(ctx, "b", []float64{2}, 0))
+		_, _, _ = c.Get(ctx, "a") // b becomes the least recently used
+		require.NoError(t, c.Set(ctx, "c", []float64{3}, 0))
+
+		_, ok, _ := c.Get(ctx, "b")
+		assert.False(t, ok)
+		_, ok, _ = c.Get(ctx, "a")
+		assert.True(t, ok)
+		_, ok, _ = c.Get(ctx, "c")
+		assert.True(t, ok)
+
+		stats := c.Stats()
+		assert.Equal(t, uint64(1), stats.Evictions)
+		assert.Equal(t, 2, stats.Entries)
+	})
+
+	t.Run("evict by bytes", func(t *testing.T) {
+		size := int64(1 + 8*4 + entryOverhead)
+		c := NewCacher(WithMaxBytes(2 * size))
+
+		vec := []float64{1, 2, 3, 4}
+		require.NoError(t, c.Set(ctx, "a", vec, 0))
+		require.NoError(t, c.Set(ctx, "b", vec, 0))
+		require.NoError(t, c.Set(ctx, "c", vec, 0))
+
+		_, ok, _ := c.Get(ctx, "a")
+		assert.False(t, ok)
+
+		stats := c.Stats()
+		assert.Equal(t, uint64(1), stats.Evictions)
+		assert.Equal(t, 2*size, stats.Bytes)
+	})
+
+	t.Run("overwrite", func(t *testing.T) {
+		c := NewCacher()
+
+		require.NoError(t, c.Set(ctx, "a", []float64{1, 2}, 0))
+		require.NoError(t, c.Set(ctx, "a", []float64{3}, 0))
+
+		got, ok, _ := c.Get(ctx, "a")
+		assert.True(t, ok)
+		assert.Equal(t, []float64{3}, got)
+		assert.Equal(t, int64(1+8+entryOverhead), c.Stats().Bytes)
+	})
+
+	t.Run("MSet and MGet", func(t *testing.T) {
+		c := NewCacher()
+
+		require.NoError(t, c.MSet(ctx, []string{"a", "b"}, [][]float64{{1}, {2}}, 0))
+		assert.Error(t, c.MSet(ctx, []string{"a"}, nil, 0))
+
+		values, found, err := c.MGet(ctx, []string{"a", "x", "b"})
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{{1}, nil, {2}}, values)
+		assert.Equal(t, []bool{true, false, true}, found)
+	})
+}
@@ -19,6 +19,7 @@ package redis
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -29,7 +30,7 @@ import (
 type Cacher struct {
 	rdb    redis.UniversalClient
 	prefix string
-	codec  codec
+	codec  Codec
 }
 
 type Option interface {
@@ -48,7 +49,15 @@ func WithPrefix(prefix string) Option {
 	})
 }
 
-var _ cache.Cacher = (*Cacher)(nil)
+// WithCodec sets the [Codec] used to store vectors, default is [NewJSONCodec].
+// Use [NewBinaryCodec] for a compact encoding, entries already stored as JSON keep decoding.
+func WithCodec(codec Codec) Option {
+	return optionFunc(func(c *Cacher) {
+		c.codec = codec
+	})
+}
+
+var _ cache.BatchCacher = (*Cacher)(nil)
 
 func NewCacher(rdb redis.UniversalClient, opts ...Option) *Cacher {
 	cacher := &Cacher{
@@ -79,9 +88,69 @@ func (c *Cacher) Get(ctx context.Context, key string) ([]float64, bool, error) {
 		return nil, false, err
 	}
 
-	var value []float64
-	if err := c.codec.Unmarshal(data, &value); err != nil {
+	value, err := c.codec.Unmarshal(data)
+	if err != nil {
 		return nil, false, err
 	}
 	return value, true, nil
 }
+
+// MSet stores all values with a single pipelined round trip.
+func (c *Cacher) MSet(ctx context.Context, keys []string, values [][]float64, expire time.Duration) error {
+	if len(keys) != len(values) {
+		return fmt.Errorf("redis cacher: got %d keys but %d values", len(keys), len(values))
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	pipe := c.rdb.Pipeline()
+	for i, key := range keys {
+		data, err := c.codec.Marshal(values[i])
+		if err != nil {
+			return err
+		}
+		pipe.Set(ctx, c.prefix+key, data, expire)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// MGet retrieves all keys with a single pipelined round trip.
+// A pipeline of GET commands is used instead of MGET so that keys
+// hashing to different slots are supported on cluster clients.
+func (c *Cacher) MGet(ctx context.Context, keys []string) ([][]float64, []bool, error) {
+	values := make([][]float64, len(keys))
+	found := make([]bool, len(keys))
+	if len(keys) == 0 {
+		return values, found, nil
+	}
+
+	pipe := c.rdb.Pipeline()
+	cmds := make([]*redis.StringCmd, len(keys))
+	for i, key := range keys {
+		cmds[i] = pipe.Get(ctx, c.prefix+key)
+	}
+	// Exec reports the first failed command, which is redis.Nil for a missing key.
+	// Errors are checked per command below.
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, nil, err
+	}
+
+	for i, cmd := range cmds {
+		data, err := cmd.Bytes()
+		if err != nil {
+			if errors.Is(err, redis.Nil) {
+				continue
+			}
+			return nil, nil, err
+		}
+
+		value, err := c.codec.Unmarshal(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		values[i], found[i] = value, true
+	}
+	return values, found, nil
+}
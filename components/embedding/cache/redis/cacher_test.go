@@ -34,21 +34,24 @@ type mockRedisClient struct {
 }
 
 type mockCodec struct {
-	codec
+	Codec
 	mock.Mock
 }
 
-func (m *mockCodec) Marshal(v any) ([]byte, error) {
-	args := m.Called(v)
+func (m *mockCodec) Marshal(value []float64) ([]byte, error) {
+	args := m.Called(value)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]byte), args.Error(1)
 }
 
-func (m *mockCodec) Unmarshal(data []byte, v any) error {
-	args := m.Called(data, v)
-	return args.Error(0)
+func (m *mockCodec) Unmarshal(data []byte) ([]float64, error) {
+	args := m.Called(data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]float64), args.Error(1)
 }
 
 var _ redis.UniversalClient = (*mockRedisClient)(nil)
@@ -69,6 +72,36 @@ func (m *mockRedisClient) Get(ctx context.Context, key string) *redis.StringCmd
 	return cmd
 }
 
+func (m *mockRedisClient) Pipeline() redis.Pipeliner {
+	args := m.Called()
+	return args.Get(0).(redis.Pipeliner)
+}
+
+type mockPipeliner struct {
+	redis.Pipeliner
+	mock.Mock
+}
+
+var _ redis.Pipeliner = (*mockPipeliner)(nil)
+
+func (m *mockPipeliner) Set(ctx context.Context, key string, value any, expiration time.Duration) *redis.StatusCmd {
+	m.Called(ctx, key, value, expiration)
+	return redis.NewStatusCmd(ctx)
+}
+
+func (m *mockPipeliner) Get(ctx context.Context, key string) *redis.StringCmd {
+	args := m.Called(ctx, key)
+	cmd := redis.NewStringCmd(ctx)
+	cmd.SetVal(args.String(0))
+	cmd.SetErr(args.Error(1))
+	return cmd
+}
+
+func (m *mockPipeliner) Exec(ctx context.Context) ([]redis.Cmder, error) {
+	args := m.Called(ctx)
+	return nil, args.Error(0)
+}
+
 func TestCacher(t *testing.T) {
 	ctx := context.Background()
 	key := "test_key"
@@ -140,7 +173,7 @@ func TestCacher(t *testing.T) {
 
 		mockRdb.On("Get", mock.Anything, mock.Anything).Return(string(valueBytes), nil)
 		mc.On("Marshal", value).Return(nil, errors.New("marshal error"))
-		mc.On("Unmarshal", mock.Anything, mock.Anything).Return(errors.New("unmarshal error"))
+		mc.On("Unmarshal", mock.Anything).Return(nil, errors.New("unmarshal error"))
 
 		// Simulate marshal error
 		err = c.Set(ctx, key, value, expire)
@@ -159,8 +192,100 @@ func TestCacher(t *testing.T) {
 	})
 }
 
+func TestWithCodec(t *testing.T) {
+	assert.Equal(t, defaultCodec, NewCacher(nil).codec)
+	assert.Equal(t, NewBinaryCodec(PrecisionFloat32), NewCacher(nil, WithCodec(NewBinaryCodec(PrecisionFloat32))).codec)
+}
+
 func TestWithPrefix(t *testing.T) {
 	assert.Equal(t, "eino:", NewCacher(nil).prefix)
 	assert.Equal(t, "custom:", NewCacher(nil, WithPrefix("custom:")).prefix)
 	assert.Equal(t, "custom:", NewCacher(nil, WithPrefix("custom")).prefix)
 }
+
+func TestCacher_Batch(t *testing.T) {
+	ctx := context.Background()
+	keys := []string{"k1", "k2", "k3"}
+	values := [][]float64{{1.1, 2.2}, {3.3}, {4.4}}
+	expire := time.Second * 10
+
+	t.Run("MSet", func(t *testing.T) {
+		mockRdb := new(mockRedisClient)
+		pipe := new(mockPipeliner)
+		c := NewCacher(mockRdb)
+
+		mockRdb.On("Pipeline").Return(pipe).Once()
+		for i, key := range keys {
+			data, err := defaultCodec.Marshal(values[i])
+			require.NoError(t, err)
+			pipe.On("Set", mock.Anything, "eino:"+key, data, expire).Once()
+		}
+		pipe.On("Exec", mock.Anything).Return(nil).Once()
+
+		assert.NoError(t, c.MSet(ctx, keys, values, expire))
+		mockRdb.AssertExpectations(t)
+		pipe.AssertExpectations(t)
+	})
+
+	t.Run("MSet length mismatch", func(t *testing.T) {
+		mockRdb := new(mockRedisClient)
+		c := NewCacher(mockRdb)
+
+		assert.Error(t, c.MSet(ctx, keys, values[:1], expire))
+		mockRdb.AssertExpectations(t)
+	})
+
+	t.Run("MGet with misses", func(t *testing.T) {
+		mockRdb := new(mockRedisClient)
+		pipe := new(mockPipeliner)
+		c := NewCacher(mockRdb)
+
+		data0, err := defaultCodec.Marshal(values[0])
+		require.NoError(t, err)
+		data2, err := defaultCodec.Marshal(values[2])
+		require.NoError(t, err)
+
+		mockRdb.On("Pipeline").Return(pipe).Once()
+		pipe.On("Get", mock.Anything, "eino:k1").Return(string(data0), nil).Once()
+		pipe.On("Get", mock.Anything, "eino:k2").Return("", redis.Nil).Once()
+		pipe.On("Get", mock.Anything, "eino:k3").Return(string(data2), nil).Once()
+		pipe.On("Exec", mock.Anything).Return(redis.Nil).Once()
+
+		got, found, err := c.MGet(ctx, keys)
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{values[0], nil, values[2]}, got)
+		assert.Equal(t, []bool{true, false, true}, found)
+		mockRdb.AssertExpectations(t)
+		pipe.AssertExpectations(t)
+	})
+
+	t.Run("MGet error", func(t *testing.T) {
+		mockRdb := new(mockRedisClient)
+		pipe := new(mockPipeliner)
+		c := NewCacher(mockRdb)
+		getErr := errors.New("get error")
+
+		mockRdb.On("Pipeline").Return(pipe).Once()
+		pipe.On("Get", mock.Anything, mock.Anything).Return("", getErr)
+		pipe.On("Exec", mock.Anything).Return(getErr).Once()
+
+		got, found, err := c.MGet(ctx, keys)
+		assert.Equal(t, getErr, err)
+		assert.Nil(t, got)
+		assert.Nil(t, found)
+		mockRdb.AssertExpectations(t)
+		pipe.AssertExpectations(t)
+	})
+
+	t.Run("empty keys", func(t *testing.T) {
+		mockRdb := new(mockRedisClient)
+		c := NewCacher(mockRdb)
+
+		got, found, err := c.MGet(ctx, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+		assert.Empty(t, found)
+		assert.NoError(t, c.MSet(ctx, nil, nil, expire))
+		mockRdb.AssertExpectations(t)
+	})
+}
@@ -16,21 +16,155 @@
 
 package redis
 
-import "github.com/bytedance/sonic"
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
 
-var defaultCodec codec = &sonicCodec{}
+	"github.com/bytedance/sonic"
+)
 
-type codec interface {
-	Marshal(v any) ([]byte, error)
-	Unmarshal(data []byte, v any) error
+var defaultCodec Codec = &sonicCodec{}
+
+// Codec converts embedding vectors to the bytes stored in Redis and back.
+type Codec interface {
+	Marshal(value []float64) ([]byte, error)
+	Unmarshal(data []byte) ([]float64, error)
+}
+
+// Precision is the element type used by the binary codec. Its value is
+// written as the leading version byte of every encoded vector.
+type Precision uint8
+
+const (
+	// PrecisionFloat64 stores each dimension as a little-endian float64, lossless.
+	PrecisionFloat64 Precision = 1
+	// PrecisionFloat32 stores each dimension as a little-endian float32, half the size of float64.
+	PrecisionFloat32 Precision = 2
+	// PrecisionInt8 quantizes each dimension to an int8 scaled by the largest absolute value
+	// of the vector, an eighth of the size of float64 at the cost of precision.
+	PrecisionInt8 Precision = 3
+)
+
+var errInvalidData = errors.New("redis cacher: invalid encoded vector")
+
+// NewJSONCodec returns the [Codec] that stores vectors as JSON arrays, which is the default.
+func NewJSONCodec() Codec {
+	return &sonicCodec{}
+}
+
+// NewBinaryCodec returns a [Codec] that stores vectors as a version byte followed by
+// the dimensions in the given precision.
+//
+// Every codec of this package decodes JSON and all binary precisions, so existing
+// entries keep decoding after switching codecs.
+func NewBinaryCodec(precision Precision) Codec {
+	return &binaryCodec{precision: precision}
 }
 
 type sonicCodec struct{}
 
-func (*sonicCodec) Marshal(v any) ([]byte, error) {
-	return sonic.Marshal(v)
+func (*sonicCodec) Marshal(value []float64) ([]byte, error) {
+	return sonic.Marshal(value)
+}
+
+func (*sonicCodec) Unmarshal(data []byte) ([]float64, error) {
+	return decode(data)
+}
+
+type binaryCodec struct {
+	precision Precision
 }
 
-func (*sonicCodec) Unmarshal(data []byte, v any) error {
-	return sonic.Unmarshal(data, v)
+func (c *binaryCodec) Marshal(value []float64) ([]byte, error) {
+	switch c.precision {
+	case PrecisionFloat64:
+		data := make([]byte, 1, 1+8*len(value))
+		data[0] = byte(PrecisionFloat64)
+		for _, v := range value {
+			data = binary.LittleEndian.AppendUint64(data, math.Float64bits(v))
+		}
+		return data, nil
+	case PrecisionFloat32:
+		data := make([]byte, 1, 1+4*len(value))
+		data[0] = byte(PrecisionFloat32)
+		for _, v := range value {
+			data = binary.LittleEndian.AppendUint32(data, math.Float32bits(float32(v)))
+		}
+		return data, nil
+	case PrecisionInt8:
+		var maxAbs float64
+		for _, v := range value {
+			maxAbs = math.Max(maxAbs, math.Abs(v))
+		}
+		scale := float32(maxAbs / math.MaxInt8)
+
+		data := make([]byte, 5, 5+len(value))
+		data[0] = byte(PrecisionInt8)
+		binary.LittleEndian.PutUint32(data[1:], math.Float32bits(scale))
+		for _, v := range value {
+			var q int8
+			if scale != 0 {
+				q = int8(math.Round(v / float64(scale)))
+			}
+			data = append(data, byte(q))
+		}
+		return data, nil
+	default:
+		return nil, fmt.Errorf("redis cacher: unknown precision %d", c.precision)
+	}
+}
+
+func (*binaryCodec) Unmarshal(data []byte) ([]float64, error) {
+	return decode(data)
+}
+
+// decode decodes any format written by the codecs of this package,
+// telling them apart by the leading byte.
+func decode(data []byte) ([]float64, error) {
+	if len(data) == 0 {
+		return nil, errInvalidData
+	}
+
+	switch Precision(data[0]) {
+	case PrecisionFloat64:
+		data = data[1:]
+		if len(data)%8 != 0 {
+			return nil, errInvalidData
+		}
+		value := make([]float64, len(data)/8)
+		for i := range value {
+			value[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[8*i:]))
+		}
+		return value, nil
+	case PrecisionFloat32:
+		data = data[1:]
+		if len(data)%4 != 0 {
+			return nil, errInvalidData
+		}
+		value := make([]float64, len(data)/4)
+		for i := range value {
+			value[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(data[4*i:])))
+		}
+		return value, nil
+	case PrecisionInt8:
+		if len(data) < 5 {
+			return nil, errInvalidData
+		}
+		scale := float64(math.Float32frombits(binary.LittleEndian.Uint32(data[1:])))
+		data = data[5:]
+		value := make([]float64, len(data))
+		for i, b := range data {
+			value[i] = float64(int8(b)) * scale
+		}
+		return value, nil
+	default:
+		// entries written before the binary codecs existed are JSON arrays
+		var value []float64
+		if err := sonic.Unmarshal(data, &value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
 }
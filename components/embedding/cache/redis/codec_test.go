@@ -17,6 +17,7 @@
 package redis
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -33,12 +34,98 @@ func TestCodec_Sonic(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotEmpty(t, data)
 
-	var out []float64
-	err = c.Unmarshal(data, &out)
+	out, err := c.Unmarshal(data)
 	require.NoError(t, err)
 	assert.Equal(t, v, out)
 }
 
 func TestCodec_Default(t *testing.T) {
 	assert.Equal(t, &sonicCodec{}, defaultCodec)
+	assert.Equal(t, defaultCodec, NewJSONCodec())
+}
+
+func TestCodec_Binary(t *testing.T) {
+	v := []float64{0.125, -1.5, 3.0, 0, -0.0078125}
+
+	t.Run("float64", func(t *testing.T) {
+		c := NewBinaryCodec(PrecisionFloat64)
+		data, err := c.Marshal(v)
+		require.NoError(t, err)
+		assert.Len(t, data, 1+8*len(v))
+		assert.Equal(t, byte(PrecisionFloat64), data[0])
+
+		out, err := c.Unmarshal(data)
+		require.NoError(t, err)
+		assert.Equal(t, v, out)
+	})
+
+	t.Run("float32", func(t *testing.T) {
+		c := NewBinaryCodec(PrecisionFloat32)
+		data, err := c.Marshal(v)
+		require.NoError(t, err)
+		assert.Len(t, data, 1+4*len(v))
+		assert.Equal(t, byte(PrecisionFloat32), data[0])
+
+		out, err := c.Unmarshal(data)
+		require.NoError(t, err)
+		assert.Equal(t, v, out) // all values of v are exact in float32
+	})
+
+	t.Run("int8", func(t *testing.T) {
+		c := NewBinaryCodec(PrecisionInt8)
+		data, err := c.Marshal(v)
+		require.NoError(t, err)
+		assert.Len(t, data, 5+len(v))
+		assert.Equal(t, byte(PrecisionInt8), data[0])
+
+		out, err := c.Unmarshal(data)
+		require.NoError(t, err)
+		require.Len(t, out, len(v))
+		for i := range v {
+			assert.InDelta(t, v[i], out[i], 3.0/math.MaxInt8/2+1e-6)
+		}
+
+		data, err = c.Marshal([]float64{0, 0})
+		require.NoError(t, err)
+		out, err = c.Unmarshal(data)
+		require.NoError(t, err)
+		assert.Equal(t, []float64{0, 0}, out)
+	})
+
+	t.Run("unknown precision", func(t *testing.T) {
+		_, err := NewBinaryCodec(Precision(42)).Marshal(v)
+		assert.Error(t, err)
+	})
+
+	t.Run("decode legacy JSON", func(t *testing.T) {
+		for _, c := range []Codec{NewBinaryCodec(PrecisionFloat32), NewJSONCodec()} {
+			out, err := c.Unmarshal([]byte("[1.5,2.5]"))
+			require.NoError(t, err)
+			assert.Equal(t, []float64{1.5, 2.5}, out)
+		}
+	})
+
+	t.Run("decode any precision", func(t *testing.T) {
+		data, err := NewBinaryCodec(PrecisionFloat64).Marshal(v)
+		require.NoError(t, err)
+		for _, c := range []Codec{NewBinaryCodec(PrecisionInt8), NewJSONCodec()} {
+			out, err := c.Unmarshal(data)
+			require.NoError(t, err)
+			assert.Equal(t, v, out)
+		}
+	})
+
+	t.Run("invalid data", func(t *testing.T) {
+		c := NewBinaryCodec(PrecisionFloat64)
+		for _, data := range [][]byte{
+			nil,
+			{byte(PrecisionFloat64), 1, 2, 3},
+			{byte(PrecisionFloat32), 1, 2, 3},
+			{byte(PrecisionInt8), 1, 2},
+			[]byte("[1,"),
+		} {
+			_, err := c.Unmarshal(data)
+			assert.Error(t, err)
+		}
+	})
 }
@@ -0,0 +1,240 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tiered
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudwego/eino-ext/components/embedding/cache"
+)
+
+var ErrTierRequired = errors.New("embedding/cache/tiered: at least one tier is required")
+
+// Cacher is a [cache.Cacher] composed of several tiers ordered from the fastest to the slowest,
+// e.g. an in-memory cacher in front of a Redis cacher.
+//
+// Get reads through the tiers in order and, on a hit in a slower tier, backfills all faster tiers.
+// Set writes to every tier.
+type Cacher struct {
+	tiers          []cache.Cacher
+	backfillExpire time.Duration
+
+	hits   []atomic.Uint64
+	misses atomic.Uint64
+}
+
+// Stats is a snapshot of the counters of a [Cacher].
+type Stats struct {
+	// Hits is the number of lookups answered by each tier, in tier order.
+	Hits []uint64
+	// Misses is the number of lookups that no tier could answer.
+	Misses uint64
+}
+
+type Option interface {
+	apply(*Cacher)
+}
+
+type optionFunc func(*Cacher)
+
+func (f optionFunc) apply(c *Cacher) {
+	f(c)
+}
+
+// WithBackfillExpiration sets the expiration of the entries copied into faster tiers on a hit in a slower tier.
+// A slower tier does not report the remaining lifetime of its entries, so a fixed duration is used.
+// Default is 10 minutes.
+func WithBackfillExpiration(expire time.Duration) Option {
+	return optionFunc(func(c *Cacher) {
+		c.backfillExpire = expire
+	})
+}
+
+var _ cache.BatchCacher = (*Cacher)(nil)
+
+// NewCacher creates a new [Cacher] from tiers ordered from the fastest to the slowest.
+func NewCacher(tiers []cache.Cacher, opts ...Option) (*Cacher, error) {
+	if len(tiers) == 0 {
+		return nil, ErrTierRequired
+	}
+
+	c := &Cacher{
+		tiers:          tiers,
+		backfillExpire: 10 * time.Minute,
+		hits:           make([]atomic.Uint64, len(tiers)),
+	}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c, nil
+}
+
+// Set writes the value to every tier. All tiers are attempted even if one fails.
+func (c *Cacher) Set(ctx context.Context, key string, value []float64, expire time.Duration) error {
+	var errs []error
+	for _, tier := range c.tiers {
+		if err := tier.Set(ctx, key, value, expire); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Get reads through the tiers and backfills the faster tiers on a hit in a slower one.
+func (c *Cacher) Get(ctx context.Context, key string) ([]float64, bool, error) {
+	for i, tier := range c.tiers {
+		value, ok, err := tier.Get(ctx, key)
+		if err != nil {
+			return nil, false, err
+		}
+		if !ok {
+			continue
+		}
+
+		c.hits[i].Add(1)
+		for _, faster := range c.tiers[:i] {
+			_ = faster.Set(ctx, key, value, c.backfillExpire) // a failed backfill only costs a later miss
+		}
+		return value, true, nil
+	}
+
+	c.misses.Add(1)
+	return nil, false, nil
+}
+
+// MSet writes the values to every tier, in a single call per tier if it is a [cache.BatchCacher].
+func (c *Cacher) MSet(ctx context.Context, keys []string, values [][]float64, expire time.Duration) error {
+	if len(keys) != len(values) {
+		return fmt.Errorf("tiered cacher: got %d keys but %d values", len(keys), len(values))
+	}
+
+	var errs []error
+	for _, tier := range c.tiers {
+		if err := mset(ctx, tier, keys, values, expire); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// MGet reads the keys through the tiers. Each tier is only asked for the keys
+// that the faster tiers missed, and the hits are backfilled into the faster tiers.
+func (c *Cacher) MGet(ctx context.Context, keys []string) ([][]float64, []bool, error) {
+	values := make([][]float64, len(keys))
+	found := make([]bool, len(keys))
+
+	pending := make([]int, len(keys)) // positions in keys not found yet
+	for i := range keys {
+		pending[i] = i
+	}
+
+	for i, tier := range c.tiers {
+		if len(pending) == 0 {
+			break
+		}
+
+		tierKeys := make([]string, len(pending))
+		for j, pos := range pending {
+			tierKeys[j] = keys[pos]
+		}
+
+		tierValues, tierFound, err := mget(ctx, tier, tierKeys)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var (
+			hitKeys   []string
+			hitValues [][]float64
+			missed    []int
+		)
+		for j, pos := range pending {
+			if !tierFound[j] {
+				missed = append(missed, pos)
+				continue
+			}
+			values[pos], found[pos] = tierValues[j], true
+			hitKeys = append(hitKeys, keys[pos])
+			hitValues = append(hitValues, tierValues[j])
+		}
+		pending = missed
+
+		c.hits[i].Add(uint64(len(hitKeys)))
+		if len(hitKeys) > 0 {
+			for _, faster := range c.tiers[:i] {
+				_ = mset(ctx, faster, hitKeys, hitValues, c.backfillExpire) // a failed backfill only costs a later miss
+			}
+		}
+	}
+
+	c.misses.Add(uint64(len(pending)))
+	return values, found, nil
+}
+
+// Stats returns a snapshot of the counters of the [Cacher].
+func (c *Cacher) Stats() Stats {
+	s := Stats{
+		Hits:   make([]uint64, len(c.hits)),
+		Misses: c.misses.Load(),
+	}
+	for i := range c.hits {
+		s.Hits[i] = c.hits[i].Load()
+	}
+	return s
+}
+
+func mget(ctx context.Context, tier cache.Cacher, keys []string) ([][]float64, []bool, error) {
+	if bc, ok := tier.(cache.BatchCacher); ok {
+		values, found, err := bc.MGet(ctx, keys)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(values) != len(keys) || len(found) != len(keys) {
+			return nil, nil, fmt.Errorf("tiered cacher: tier returned %d values for %d keys", len(values), len(keys))
+		}
+		return values, found, nil
+	}
+
+	values := make([][]float64, len(keys))
+	found := make([]bool, len(keys))
+	for i, key := range keys {
+		value, ok, err := tier.Get(ctx, key)
+		if err != nil {
+			return nil, nil, err
+		}
+		values[i], found[i] = value, ok
+	}
+	return values, found, nil
+}
+
+func mset(ctx context.Context, tier cache.Cacher, keys []string, values [][]float64, expire time.Duration) error {
+	if bc, ok := tier.(cache.BatchCacher); ok {
+		return bc.MSet(ctx, keys, values, expire)
+	}
+
+	var errs []error
+	for i, key := range keys {
+		if err := tier.Set(ctx, key, values[i], expire); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
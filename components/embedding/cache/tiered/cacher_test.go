@@ -0,0 +1,127 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tiered
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino-ext/components/embedding/cache"
+	"github.com/cloudwego/eino-ext/components/embedding/cache/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// plainCacher hides the batch methods of the wrapped cacher.
+type plainCacher struct {
+	cache.Cacher
+	getErr error
+}
+
+func (p *plainCacher) Get(ctx context.Context, key string) ([]float64, bool, error) {
+	if p.getErr != nil {
+		return nil, false, p.getErr
+	}
+	return p.Cacher.Get(ctx, key)
+}
+
+func TestNewCacher(t *testing.T) {
+	c, err := NewCacher(nil)
+	assert.Equal(t, ErrTierRequired, err)
+	assert.Nil(t, c)
+}
+
+func TestCacher(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Get reads through and backfills", func(t *testing.T) {
+		fast, slow := memory.NewCacher(), memory.NewCacher()
+		c, err := NewCacher([]cache.Cacher{fast, &plainCacher{Cacher: slow}}, WithBackfillExpiration(time.Minute))
+		require.NoError(t, err)
+
+		require.NoError(t, slow.Set(ctx, "k", []float64{1}, 0))
+
+		got, ok, err := c.Get(ctx, "k")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []float64{1}, got)
+
+		got, ok, _ = fast.Get(ctx, "k")
+		assert.True(t, ok)
+		assert.Equal(t, []float64{1}, got)
+
+		_, ok, _ = c.Get(ctx, "k")
+		assert.True(t, ok)
+		_, ok, _ = c.Get(ctx, "missing")
+		assert.False(t, ok)
+
+		assert.Equal(t, Stats{Hits: []uint64{1, 1}, Misses: 1}, c.Stats())
+	})
+
+	t.Run("Set writes every tier", func(t *testing.T) {
+		fast, slow := memory.NewCacher(), memory.NewCacher()
+		c, err := NewCacher([]cache.Cacher{fast, &plainCacher{Cacher: slow}})
+		require.NoError(t, err)
+
+		require.NoError(t, c.Set(ctx, "k", []float64{1}, time.Minute))
+		_, ok, _ := fast.Get(ctx, "k")
+		assert.True(t, ok)
+		_, ok, _ = slow.Get(ctx, "k")
+		assert.True(t, ok)
+
+		require.NoError(t, c.MSet(ctx, []string{"a", "b"}, [][]float64{{2}, {3}}, time.Minute))
+		assert.Error(t, c.MSet(ctx, []string{"a"}, nil, time.Minute))
+		values, _, _ := slow.MGet(ctx, []string{"a", "b"})
+		assert.Equal(t, [][]float64{{2}, {3}}, values)
+	})
+
+	t.Run("MGet asks each tier only for missed keys", func(t *testing.T) {
+		fast, slow := memory.NewCacher(), memory.NewCacher()
+		c, err := NewCacher([]cache.Cacher{fast, slow})
+		require.NoError(t, err)
+
+		require.NoError(t, fast.Set(ctx, "a", []float64{1}, 0))
+		require.NoError(t, slow.Set(ctx, "b", []float64{2}, 0))
+
+		values, found, err := c.MGet(ctx, []string{"a", "b", "c"})
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{{1}, {2}, nil}, values)
+		assert.Equal(t, []bool{true, true, false}, found)
+		assert.Equal(t, Stats{Hits: []uint64{1, 1}, Misses: 1}, c.Stats())
+
+		// the slow tier was not asked for "a"
+		assert.Equal(t, uint64(1), slow.Stats().Hits)
+		assert.Equal(t, uint64(1), slow.Stats().Misses)
+
+		// "b" was backfilled
+		_, ok, _ := fast.Get(ctx, "b")
+		assert.True(t, ok)
+	})
+
+	t.Run("tier error", func(t *testing.T) {
+		getErr := errors.New("get error")
+		c, err := NewCacher([]cache.Cacher{memory.NewCacher(), &plainCacher{Cacher: memory.NewCacher(), getErr: getErr}})
+		require.NoError(t, err)
+
+		_, _, err = c.Get(ctx, "k")
+		assert.Equal(t, getErr, err)
+		_, _, err = c.MGet(ctx, []string{"k"})
+		assert.Equal(t, getErr, err)
+	})
+}
@@ -35,21 +35,74 @@ type Config struct {
 	// Name specifies the prompt name to use from MCP service
 	// Required
 	Name string
+	// ValidateArguments fetches the arguments of the prompt from MCP service when creating the template,
+	// then Format fails without calling GetPrompt if a required argument is missing
+	// Optional. Default: false
+	ValidateArguments bool
 }
 
-func NewPromptTemplate(_ context.Context, conf *Config) (prompt.ChatTemplate, error) {
-	return &chatTemplate{
+func NewPromptTemplate(ctx context.Context, conf *Config) (prompt.ChatTemplate, error) {
+	tpl := &chatTemplate{
 		cli:  conf.Cli,
 		name: conf.Name,
-	}, nil
+	}
+
+	if conf.ValidateArguments {
+		prompts, err := ListPrompts(ctx, conf.Cli)
+		if err != nil {
+			return nil, err
+		}
+		for i := range prompts {
+			if prompts[i].Name == conf.Name {
+				tpl.arguments = prompts[i].Arguments
+				tpl.validate = true
+				break
+			}
+		}
+		if !tpl.validate {
+			return nil, fmt.Errorf("mcp prompt not found: %s", conf.Name)
+		}
+	}
+
+	return tpl, nil
+}
+
+// ListPrompts lists all prompts of MCP service, with the arguments each prompt accepts.
+func ListPrompts(ctx context.Context, cli client.MCPClient) ([]mcp.Prompt, error) {
+	var (
+		prompts []mcp.Prompt
+		cursor  mcp.Cursor
+	)
+	for {
+		req := mcp.ListPromptsRequest{}
+		req.Params.Cursor = cursor
+		result, err := cli.ListPrompts(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("list mcp prompts fail: %w", err)
+		}
+		prompts = append(prompts, result.Prompts...)
+		if result.NextCursor == "" {
+			return prompts, nil
+		}
+		cursor = result.NextCursor
+	}
 }
 
 type chatTemplate struct {
 	cli  client.MCPClient
 	name string
+
+	validate  bool
+	arguments []mcp.PromptArgument
 }
 
 func (c *chatTemplate) Format(ctx context.Context, vs map[string]any, _ ...prompt.Option) ([]*schema.Message, error) {
+	if c.validate {
+		if err := checkArguments(c.arguments, vs); err != nil {
+			return nil, fmt.Errorf("invalid arguments of mcp prompt %s: %w", c.name, err)
+		}
+	}
+
 	arg := make(map[string]string, len(vs))
 	for k, v := range vs {
 		arg[k] = fmt.Sprint(v)
@@ -84,6 +137,22 @@ func (c *chatTemplate) GetType() string {
 	return "MCP"
 }
 
+func checkArguments(arguments []mcp.PromptArgument, vs map[string]any) error {
+	var missing []string
+	for _, a := range arguments {
+		if !a.Required {
+			continue
+		}
+		if v, ok := vs[a.Name]; !ok || v == nil {
+			missing = append(missing, a.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required arguments: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
 func convRole(role mcp.Role) (schema.RoleType, error) {
 	switch role {
 	case mcp.RoleUser:
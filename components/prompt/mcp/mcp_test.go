@@ -164,6 +164,33 @@ func TestFormat(t *testing.T) {
 	assert.Equal(t, "hello world", result[0].Content)
 }
 
+func TestListPrompts(t *testing.T) {
+	ctx := context.Background()
+	prompts, err := ListPrompts(ctx, &mockMCPClient{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(prompts))
+	assert.Equal(t, "other", prompts[0].Name)
+	assert.Equal(t, "test", prompts[1].Name)
+	assert.Equal(t, []mcp.PromptArgument{{Name: "persona", Required: true}, {Name: "style"}}, prompts[1].Arguments)
+}
+
+func TestValidateArguments(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewPromptTemplate(ctx, &Config{Cli: &mockMCPClient{}, Name: "missing", ValidateArguments: true})
+	assert.ErrorContains(t, err, "mcp prompt not found: missing")
+
+	tpl, err := NewPromptTemplate(ctx, &Config{Cli: &mockMCPClient{}, Name: "test", ValidateArguments: true})
+	assert.NoError(t, err)
+
+	_, err = tpl.Format(ctx, map[string]any{"style": "short"})
+	assert.ErrorContains(t, err, "missing required arguments: persona")
+
+	result, err := tpl.Format(ctx, map[string]any{"persona": "teacher"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result))
+}
+
 type mockMCPClient struct{}
 
 func (m *mockMCPClient) ListResourcesByPage(ctx context.Context, request mcp.ListResourcesRequest) (*mcp.ListResourcesResult, error) {
@@ -215,7 +242,25 @@ func (m *mockMCPClient) Unsubscribe(ctx context.Context, request mcp.Unsubscribe
 }
 
 func (m *mockMCPClient) ListPrompts(ctx context.Context, request mcp.ListPromptsRequest) (*mcp.ListPromptsResult, error) {
-	panic("implement me")
+	// two pages
+	if request.Params.Cursor == "" {
+		result := &mcp.ListPromptsResult{
+			Prompts: []mcp.Prompt{{Name: "other"}},
+		}
+		result.NextCursor = "page2"
+		return result, nil
+	}
+	return &mcp.ListPromptsResult{
+		Prompts: []mcp.Prompt{
+			{
+				Name: "test",
+				Arguments: []mcp.PromptArgument{
+					{Name: "persona", Required: true},
+					{Name: "style"},
+				},
+			},
+		},
+	}, nil
 }
 
 func (m *mockMCPClient) GetPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
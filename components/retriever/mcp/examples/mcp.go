@@ -0,0 +1,77 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	mcpr "github.com/cloudwego/eino-ext/components/retriever/mcp"
+)
+
+func main() {
+	ctx := context.Background()
+
+	svr := server.NewMCPServer("demo", "1.0.0", server.WithResourceCapabilities(false, false))
+	svr.AddResource(mcp.NewResource("docs://install", "install guide", mcp.WithResourceDescription("how to install the server")),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return []mcp.ResourceContents{mcp.TextResourceContents{URI: "docs://install", Text: "run go install"}}, nil
+		})
+	svr.AddResourceTemplate(mcp.NewResourceTemplate("weather://{city}", "weather"),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			city := strings.TrimPrefix(request.Params.URI, "weather://")
+			return []mcp.ResourceContents{mcp.TextResourceContents{URI: request.Params.URI, Text: "sunny in " + city}}, nil
+		})
+
+	cli, err := client.NewInProcessClient(svr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{
+		Name:    "example-client",
+		Version: "1.0.0",
+	}
+	if _, err = cli.Initialize(ctx, initRequest); err != nil {
+		log.Fatal(err)
+	}
+
+	r, err := mcpr.NewRetriever(ctx, &mcpr.RetrieverConfig{
+		Cli:          cli,
+		TopK:         3,
+		UseTemplates: true,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, query := range []string{"install", "paris"} {
+		docs, err := r.Retrieve(ctx, query)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, doc := range docs {
+			fmt.Printf("%s: %s (score %.2f)\n", doc.ID, doc.Content, doc.Score())
+		}
+	}
+}
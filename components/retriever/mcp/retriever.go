@@ -0,0 +1,297 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/yosida95/uritemplate/v3"
+)
+
+const (
+	typ = "MCP"
+
+	defaultTopK = 5
+)
+
+const (
+	// MetaKeyName is the name of the listed resource or resource template a document is read from
+	MetaKeyName = "_name"
+	// MetaKeyMIMEType is the mime type of the resource contents
+	MetaKeyMIMEType = "_mime_type"
+	// MetaKeyBlob is the base64 encoded data of a binary resource, whose document content is empty
+	MetaKeyBlob = "_blob"
+)
+
+var _ retriever.Retriever = (*Retriever)(nil)
+
+// RetrieverConfig is the config for MCP resource Retriever.
+type RetrieverConfig struct {
+	// Cli is the MCP (Model Control Protocol) client, ref: https://github.com/mark3labs/mcp-go
+	// Notice: should Initialize with server before use
+	// Required
+	Cli client.MCPClient
+	// TopK is the max number of docs returned for a query, can be overridden by retriever.WithTopK
+	// Optional. Default: 5
+	TopK int
+	// Matcher scores how well a listed resource matches the query, resources scoring 0 are skipped
+	// Optional. Default: the fraction of the query terms found in the name, description or uri of the resource
+	Matcher func(ctx context.Context, query string, resource mcp.Resource) float64
+	// UseTemplates also reads the resources built by expanding the resource templates of the server with the query
+	// Template resources have score 1, those failing to read are skipped, as the expanded uri may not exist
+	// Optional. Default: false
+	UseTemplates bool
+	// TemplateArguments returns the arguments to expand a resource template with, ok=false skips the template
+	// Optional. Default: templates with exactly one variable are expanded with the query as its value
+	TemplateArguments func(ctx context.Context, query string, template mcp.ResourceTemplate) (args map[string]string, ok bool)
+}
+
+// Retriever lists the resources of an MCP server and reads the ones matching the query.
+type Retriever struct {
+	config *RetrieverConfig
+}
+
+func NewRetriever(ctx context.Context, config *RetrieverConfig) (*Retriever, error) {
+	if config == nil || config.Cli == nil {
+		return nil, errors.New("mcp client is required")
+	}
+
+	conf := *config
+	if conf.TopK <= 0 {
+		conf.TopK = defaultTopK
+	}
+	if conf.Matcher == nil {
+		conf.Matcher = matchTerms
+	}
+	if conf.TemplateArguments == nil {
+		conf.TemplateArguments = singleVariableArguments
+	}
+
+	return &Retriever{config: &conf}, nil
+}
+
+type candidate struct {
+	uri      string
+	name     string
+	score    float64
+	template bool
+}
+
+// Retrieve reads the resources matching the query, in descending order of score, until topK docs are collected.
+// The template expansions that fail to read are skipped.
+func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	options := retriever.GetCommonOptions(&retriever.Options{}, opts...)
+	topK := r.config.TopK
+	if options.TopK != nil && *options.TopK > 0 {
+		topK = *options.TopK
+	}
+
+	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
+	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
+		Query:          query,
+		TopK:           topK,
+		ScoreThreshold: options.ScoreThreshold,
+	})
+	defer func() {
+		if err != nil {
+			_ = callbacks.OnError(ctx, err)
+		}
+	}()
+
+	candidates, err := r.candidates(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if options.ScoreThreshold != nil {
+		filtered := candidates[:0]
+		for _, c := range candidates {
+			if c.score >= *options.ScoreThreshold {
+				filtered = append(filtered, c)
+			}
+		}
+		candidates = filtered
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	// the candidates are read until topK docs are collected, so that the templates expanding to missing
+	// resources do not take the place of other matching resources
+	for _, c := range candidates {
+		if len(docs) >= topK {
+			break
+		}
+		read, readErr := r.read(ctx, c)
+		if readErr != nil {
+			if c.template {
+				continue
+			}
+			return nil, readErr
+		}
+		docs = append(docs, read...)
+	}
+	if len(docs) > topK {
+		docs = docs[:topK]
+	}
+
+	_ = callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
+
+	return docs, nil
+}
+
+func (r *Retriever) candidates(ctx context.Context, query string) ([]*candidate, error) {
+	var ret []*candidate
+
+	var cursor mcp.Cursor
+	for {
+		req := mcp.ListResourcesRequest{}
+		req.Params.Cursor = cursor
+		result, err := r.config.Cli.ListResources(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("list mcp resources fail: %w", err)
+		}
+		for _, resource := range result.Resources {
+			if score := r.config.Matcher(ctx, query, resource); score > 0 {
+				ret = append(ret, &candidate{uri: resource.URI, name: resource.Name, score: score})
+			}
+		}
+		if result.NextCursor == "" {
+			break
+		}
+		cursor = result.NextCursor
+	}
+
+	if !r.config.UseTemplates {
+		return ret, nil
+	}
+
+	cursor = ""
+	for {
+		req := mcp.ListResourceTemplatesRequest{}
+		req.Params.Cursor = cursor
+		result, err := r.config.Cli.ListResourceTemplates(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("list mcp resource templates fail: %w", err)
+		}
+		for _, template := range result.ResourceTemplates {
+			if template.URITemplate == nil || template.URITemplate.Template == nil {
+				continue
+			}
+			args, ok := r.config.TemplateArguments(ctx, query, template)
+			if !ok {
+				continue
+			}
+			values := uritemplate.Values{}
+			for k, v := range args {
+				values.Set(k, uritemplate.String(v))
+			}
+			uri, err := template.URITemplate.Expand(values)
+			if err != nil {
+				return nil, fmt.Errorf("expand mcp resource template [%s] fail: %w", template.URITemplate.Raw(), err)
+			}
+			ret = append(ret, &candidate{uri: uri, name: template.Name, score: 1, template: true})
+		}
+		if result.NextCursor == "" {
+			break
+		}
+		cursor = result.NextCursor
+	}
+
+	return ret, nil
+}
+
+func (r *Retriever) read(ctx context.Context, c *candidate) ([]*schema.Document, error) {
+	result, err := r.config.Cli.ReadResource(ctx, mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{
+			URI: c.uri,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read mcp resource [%s] fail: %w", c.uri, err)
+	}
+
+	docs := make([]*schema.Document, 0, len(result.Contents))
+	for _, content := range result.Contents {
+		doc := &schema.Document{
+			ID:       c.uri,
+			MetaData: map[string]any{MetaKeyName: c.name},
+		}
+		switch rc := content.(type) {
+		case mcp.TextResourceContents:
+			if rc.URI != "" {
+				doc.ID = rc.URI
+			}
+			doc.Content = rc.Text
+			doc.MetaData[MetaKeyMIMEType] = rc.MIMEType
+		case mcp.BlobResourceContents:
+			if rc.URI != "" {
+				doc.ID = rc.URI
+			}
+			doc.MetaData[MetaKeyMIMEType] = rc.MIMEType
+			doc.MetaData[MetaKeyBlob] = rc.Blob
+		default:
+			return nil, fmt.Errorf("unknown mcp resource contents type: %T", content)
+		}
+		docs = append(docs, doc.WithScore(c.score))
+	}
+	return docs, nil
+}
+
+func (r *Retriever) GetType() string {
+	return typ
+}
+
+func (r *Retriever) IsCallbacksEnabled() bool {
+	return true
+}
+
+// matchTerms returns the fraction of the query terms found in the name, description or uri of the resource, case-insensitively.
+func matchTerms(_ context.Context, query string, resource mcp.Resource) float64 {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return 0
+	}
+
+	text := strings.ToLower(resource.Name + "\n" + resource.Description + "\n" + resource.URI)
+	matched := 0
+	for _, term := range terms {
+		if strings.Contains(text, term) {
+			matched++
+		}
+	}
+	return float64(matched) / float64(len(terms))
+}
+
+// singleVariableArguments expands templates with exactly one variable, using the trimmed query as its value.
+func singleVariableArguments(_ context.Context, query string, template mcp.ResourceTemplate) (map[string]string, bool) {
+	query = strings.TrimSpace(query)
+	names := template.URITemplate.Varnames()
+	if query == "" || len(names) != 1 {
+		return nil, false
+	}
+	return map[string]string{names[0]: query}, true
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetriever(t *testing.T) {
+	ctx := context.Background()
+
+	svr := server.NewMCPServer("test", "1.0.0", server.WithResourceCapabilities(false, false))
+	addText := func(uri, name, desc, text string) {
+		svr.AddResource(mcp.NewResource(uri, name, mcp.WithResourceDescription(desc)), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return []mcp.ResourceContents{mcp.TextResourceContents{URI: uri, MIMEType: "text/plain", Text: text}}, nil
+		})
+	}
+	addText("docs://install", "install guide", "how to install the server", "run go install")
+	addText("docs://deploy", "deploy guide", "how to deploy to production", "use docker")
+	addText("docs://faq", "faq", "frequently asked questions", "see the guides")
+	svr.AddResource(mcp.NewResource("docs://logo", "logo image"), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		return []mcp.ResourceContents{mcp.BlobResourceContents{URI: "docs://logo", MIMEType: "image/png", Blob: "cG5n"}}, nil
+	})
+	svr.AddResourceTemplate(mcp.NewResourceTemplate("weather://{city}", "weather"), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		city := strings.TrimPrefix(request.Params.URI, "weather://")
+		return []mcp.ResourceContents{mcp.TextResourceContents{URI: request.Params.URI, Text: "sunny in " + city}}, nil
+	})
+	svr.AddResourceTemplate(mcp.NewResourceTemplate("files://{path}", "files"), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		return nil, fmt.Errorf("file %s not found", request.Params.URI)
+	})
+
+	cli, err := client.NewInProcessClient(svr)
+	require.NoError(t, err)
+	defer cli.Close()
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	_, err = cli.Initialize(ctx, initRequest)
+	require.NoError(t, err)
+
+	_, err = NewRetriever(ctx, &RetrieverConfig{})
+	assert.Error(t, err)
+
+	t.Run("resources", func(t *testing.T) {
+		r, err := NewRetriever(ctx, &RetrieverConfig{Cli: cli})
+		require.NoError(t, err)
+
+		docs, err := r.Retrieve(ctx, "deploy guide")
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+		assert.Equal(t, "docs://deploy", docs[0].ID)
+		assert.Equal(t, "use docker", docs[0].Content)
+		assert.Equal(t, 1.0, docs[0].Score())
+		assert.Equal(t, "deploy guide", docs[0].MetaData[MetaKeyName])
+		assert.Equal(t, "text/plain", docs[0].MetaData[MetaKeyMIMEType])
+		assert.Equal(t, "docs://install", docs[1].ID)
+		assert.Equal(t, 0.5, docs[1].Score())
+
+		docs, err = r.Retrieve(ctx, "deploy guide", retriever.WithTopK(1))
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+
+		docs, err = r.Retrieve(ctx, "deploy guide", retriever.WithScoreThreshold(0.8))
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+
+		docs, err = r.Retrieve(ctx, "LOGO")
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, "", docs[0].Content)
+		assert.Equal(t, "cG5n", docs[0].MetaData[MetaKeyBlob])
+
+		docs, err = r.Retrieve(ctx, "paris")
+		require.NoError(t, err)
+		assert.Empty(t, docs)
+	})
+
+	t.Run("templates", func(t *testing.T) {
+		r, err := NewRetriever(ctx, &RetrieverConfig{Cli: cli, UseTemplates: true})
+		require.NoError(t, err)
+
+		docs, err := r.Retrieve(ctx, "paris")
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, "weather://paris", docs[0].ID)
+		assert.Equal(t, "sunny in paris", docs[0].Content)
+		assert.Equal(t, "weather", docs[0].MetaData[MetaKeyName])
+
+		r, err = NewRetriever(ctx, &RetrieverConfig{
+			Cli:          cli,
+			UseTemplates: true,
+			Matcher: func(ctx context.Context, query string, resource mcp.Resource) float64 {
+				return 0
+			},
+			TemplateArguments: func(ctx context.Context, query string, template mcp.ResourceTemplate) (map[string]string, bool) {
+				return map[string]string{"city": "tokyo"}, true
+			},
+		})
+		require.NoError(t, err)
+		docs, err = r.Retrieve(ctx, "deploy")
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, "sunny in tokyo", docs[0].Content)
+
+		// the expansions of the missing template resources score first, the matching resources fill topK
+		r, err = NewRetriever(ctx, &RetrieverConfig{
+			Cli:          cli,
+			UseTemplates: true,
+			TemplateArguments: func(ctx context.Context, query string, template mcp.ResourceTemplate) (map[string]string, bool) {
+				return map[string]string{"path": "missing"}, template.Name == "files"
+			},
+		})
+		require.NoError(t, err)
+		docs, err = r.Retrieve(ctx, "deploy guide", retriever.WithTopK(2))
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+		assert.Equal(t, "docs://deploy", docs[0].ID)
+		assert.Equal(t, "docs://install", docs[1].ID)
+	})
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/schema"
@@ -47,6 +48,9 @@ const (
 	StrReplaceCommand Command = "str_replace"
 	InsertCommand     Command = "insert"
 	UndoEditCommand   Command = "undo_edit"
+	ApplyPatchCommand Command = "apply_patch"
+	GrepCommand       Command = "grep"
+	FindCommand       Command = "find"
 )
 
 const StrReplaceEditorDescription = `Custom editing tool for viewing, creating and editing files
@@ -55,6 +59,9 @@ const StrReplaceEditorDescription = `Custom editing tool for viewing, creating a
 * The 'create' command cannot be used if the specified 'path' already exists as a file
 * If a 'command' generates a long output, it will be truncated and marked with '<response clipped>'
 * The 'undo_edit' command will revert the last edit made to the file at 'path'
+* The 'apply_patch' command applies the unified diff in 'patch' to one or more files, relative paths in the diff are resolved against the directory 'path'. Either all files are changed or none
+* The 'grep' command searches file contents under 'path' for the regular expression 'pattern', optionally only in files whose name matches the glob 'include'
+* The 'find' command lists files and directories under 'path' whose name matches the glob 'pattern'
 
 Notes for using the 'str_replace' command:
 * The 'old_str' parameter should match EXACTLY one or more consecutive lines from the original file. Be mindful of whitespaces!
@@ -63,9 +70,9 @@ Notes for using the 'str_replace' command:
 
 // StrReplaceEditor struct definition
 type StrReplaceEditor struct {
-	fileHistory map[string][]string
-	operator    Operator
-	info        *schema.ToolInfo
+	history  HistoryStore
+	operator Operator
+	info     *schema.ToolInfo
 }
 
 type StrReplaceEditorParams struct {
@@ -76,10 +83,15 @@ type StrReplaceEditorParams struct {
 	OldStr     *string `json:"old_str,omitempty"`
 	NewStr     *string `json:"new_str,omitempty"`
 	InsertLine *int    `json:"insert_line,omitempty"`
+	Patch      *string `json:"patch,omitempty"`
+	Pattern    *string `json:"pattern,omitempty"`
+	Include    *string `json:"include,omitempty"`
 }
 
 type EditorConfig struct {
 	Operator Operator
+	// HistoryStore keeps the content of files before edits for undo_edit. Default is NewMemoryHistoryStore().
+	HistoryStore HistoryStore
 }
 
 // NewStrReplaceEditor creates a new editor instance
@@ -90,6 +102,10 @@ func NewStrReplaceEditor(ctx context.Context, cfg *EditorConfig) (*StrReplaceEdi
 	if cfg.Operator == nil {
 		return nil, errors.New("operator is required")
 	}
+	history := cfg.HistoryStore
+	if history == nil {
+		history = NewMemoryHistoryStore()
+	}
 
 	return &StrReplaceEditor{
 		info: &schema.ToolInfo{
@@ -100,14 +116,14 @@ func NewStrReplaceEditor(ctx context.Context, cfg *EditorConfig) (*StrReplaceEdi
 				Properties: map[string]*openapi3.SchemaRef{
 					"command": {
 						Value: &openapi3.Schema{
-							Description: "The commands to run. Allowed options are: `view`, `create`, `str_replace`, `insert`, `undo_edit`.",
-							Enum:        []interface{}{"view", "create", "str_replace", "insert", "undo_edit"},
+							Description: "The commands to run. Allowed options are: `view`, `create`, `str_replace`, `insert`, `undo_edit`, `apply_patch`, `grep`, `find`.",
+							Enum:        []interface{}{"view", "create", "str_replace", "insert", "undo_edit", "apply_patch", "grep", "find"},
 							Type:        openapi3.TypeString,
 						},
 					},
 					"path": {
 						Value: &openapi3.Schema{
-							Description: "Absolute path to file or directory.",
+							Description: "Absolute path to file or directory. For `apply_patch`, the directory relative paths in the patch are resolved against.",
 							Type:        openapi3.TypeString,
 						},
 					},
@@ -135,6 +151,24 @@ func NewStrReplaceEditor(ctx context.Context, cfg *EditorConfig) (*StrReplaceEdi
 							Type:        openapi3.TypeInteger,
 						},
 					},
+					"patch": {
+						Value: &openapi3.Schema{
+							Description: "Required parameter of `apply_patch` command, a unified diff with `---`, `+++` and `@@` lines for each changed file, as produced by `diff -u` or `git diff`. New files use `--- /dev/null`.",
+							Type:        openapi3.TypeString,
+						},
+					},
+					"pattern": {
+						Value: &openapi3.Schema{
+							Description: "Required parameter of `grep` command containing the extended regular expression to search for, and of `find` command containing the glob that file names must match, e.g. `*.go`.",
+							Type:        openapi3.TypeString,
+						},
+					},
+					"include": {
+						Value: &openapi3.Schema{
+							Description: "Optional parameter of `grep` command, only files whose name matches this glob are searched, e.g. `*.py`.",
+							Type:        openapi3.TypeString,
+						},
+					},
 					"view_range": {
 						Value: &openapi3.Schema{
 							Description: "Optional parameter of `view` command when `path` points to a file. If none is given, the full file is shown. If provided, the file will be shown in the indicated line number range, e.g. [11, 12] will show lines 11 and 12. Indexing at 1 to start. Setting `[start_line, -1]` shows all lines from `start_line` to the end of the file.",
@@ -150,8 +184,8 @@ func NewStrReplaceEditor(ctx context.Context, cfg *EditorConfig) (*StrReplaceEdi
 				Required: []string{"command", "path"},
 			}),
 		},
-		fileHistory: make(map[string][]string),
-		operator:    cfg.Operator,
+		history:  history,
+		operator: cfg.Operator,
 	}, nil
 }
 
@@ -192,7 +226,9 @@ func (e *StrReplaceEditor) Execute(ctx context.Context, params *StrReplaceEditor
 		if err != nil {
 			return "", err
 		}
-		e.fileHistory[params.Path] = append(e.fileHistory[params.Path], *params.FileText)
+		if err = e.pushHistory(ctx, params.Path, CreateCommand, *params.FileText, false); err != nil {
+			return "", err
+		}
 		result = fmt.Sprintf("file successfully created at: %s", params.Path)
 	case StrReplaceCommand:
 		if params.OldStr == nil {
@@ -213,8 +249,27 @@ func (e *StrReplaceEditor) Execute(ctx context.Context, params *StrReplaceEditor
 		result, err = e.insert(ctx, params.Path, *params.InsertLine, *params.NewStr)
 	case UndoEditCommand:
 		result, err = e.undoEdit(ctx, params.Path)
+	case ApplyPatchCommand:
+		if params.Patch == nil {
+			return "", errors.New("parameter `patch` is required for apply_patch command")
+		}
+		result, err = e.applyPatch(ctx, params.Path, *params.Patch)
+	case GrepCommand:
+		if params.Pattern == nil {
+			return "", errors.New("parameter `pattern` is required for grep command")
+		}
+		var include string
+		if params.Include != nil {
+			include = *params.Include
+		}
+		result, err = e.grep(ctx, params.Path, *params.Pattern, include)
+	case FindCommand:
+		if params.Pattern == nil {
+			return "", errors.New("parameter `pattern` is required for find command")
+		}
+		result, err = e.find(ctx, params.Path, *params.Pattern)
 	default:
-		return "", fmt.Errorf("unrecognized command %s. Allowed commands are: view, create, str_replace, insert, undo_edit", params.Command)
+		return "", fmt.Errorf("unrecognized command %s. Allowed commands are: view, create, str_replace, insert, undo_edit, apply_patch, grep, find", params.Command)
 	}
 
 	if err != nil {
@@ -376,7 +431,9 @@ func (e *StrReplaceEditor) strReplace(ctx context.Context, path string, oldStr s
 	}
 
 	// Save original content to history
-	e.fileHistory[path] = append(e.fileHistory[path], fileContent)
+	if err = e.pushHistory(ctx, path, StrReplaceCommand, fileContent, false); err != nil {
+		return "", err
+	}
 
 	// Create snippet of edited part
 	parts := strings.Split(fileContent, oldStr)
@@ -437,7 +494,9 @@ func (e *StrReplaceEditor) insert(ctx context.Context, path string, insertLine i
 	if err != nil {
 		return "", err
 	}
-	e.fileHistory[path] = append(e.fileHistory[path], fileText)
+	if err = e.pushHistory(ctx, path, InsertCommand, fileText, false); err != nil {
+		return "", err
+	}
 
 	// Prepare success messages
 	successMsg := fmt.Sprintf("File %s has been edited. ", path)
@@ -449,20 +508,195 @@ func (e *StrReplaceEditor) insert(ctx context.Context, path string, insertLine i
 
 // Undo last edit to file
 func (e *StrReplaceEditor) undoEdit(ctx context.Context, path string) (string, error) {
-	if len(e.fileHistory[path]) == 0 {
+	entry, err := e.history.Peek(ctx, path)
+	if err != nil {
+		return "", fmt.Errorf("failed to load edit history of %s: %w", path, err)
+	}
+	if entry == nil {
 		return "", fmt.Errorf("no edit history found for %s", path)
 	}
 
-	lastIdx := len(e.fileHistory[path]) - 1
-	oldText := e.fileHistory[path][lastIdx]
-	e.fileHistory[path] = e.fileHistory[path][:lastIdx]
+	// the entry is marked undone only once the file is restored, so that a failed undo can be retried
+	// the entry is marked undone once the file is restored only, so that a failed undo can be retried
+	if err = e.restoreFile(ctx, path, entry.Content, entry.Created); err != nil {
+		return "", err
+	}
+	if err = e.history.MarkUndone(ctx, path); err != nil {
+		return "", fmt.Errorf("file %s has been restored, but failed to save edit history: %w", path, err)
+	}
+
+	if entry.Created {
+		return fmt.Sprintf("Successfully undid last edit to %s, the file it created has been removed.", path), nil
+	}
+	return fmt.Sprintf("Successfully undid last edit to %s. %s", path, e.makeOutput(entry.Content, path, 1)), nil
+}
+
+// Restore the content of path before an edit, or remove the file if the edit created it
+func (e *StrReplaceEditor) restoreFile(ctx context.Context, path string, content string, created bool) error {
+	if !created {
+		return e.operator.WriteFile(ctx, path, content)
+	}
+	remover, ok := e.operator.(FileRemover)
+	if !ok {
+		return fmt.Errorf("failed to remove %s created by the edit: the operator does not support removing files", path)
+	}
+	return remover.RemoveFile(ctx, path)
+}
+
+// Save the content of path before an edit, created tells whether the edit created the file
+func (e *StrReplaceEditor) pushHistory(ctx context.Context, path string, command Command, content string, created bool) error {
+	err := e.history.Push(ctx, &HistoryEntry{
+		Path:    path,
+		Command: command,
+		Content: content,
+		Time:    time.Now(),
+		Created: created,
+	})
+	if err != nil {
+		return fmt.Errorf("file %s has been edited, but failed to save edit history: %w", path, err)
+	}
+	return nil
+}
+
+// Apply a unified diff to one or more files, all files are written only if every hunk applies
+func (e *StrReplaceEditor) applyPatch(ctx context.Context, baseDir string, patch string) (string, error) {
+	if !filepath.IsAbs(baseDir) {
+		return "", fmt.Errorf("path %s is not an absolute path", baseDir)
+	}
+
+	filePatches, err := parsePatch(patch)
+	if err != nil {
+		return "", err
+	}
+
+	type fileChange struct {
+		path       string
+		oldContent string
+		newContent string
+		created    bool
+		added      int
+		removed    int
+	}
+
+	// Compute all changes before writing anything
+	var changes []*fileChange
+	seen := make(map[string]bool)
+	for _, fp := range filePatches {
+		if fp.newPath == "" {
+			return "", fmt.Errorf("patch deletes %s, deleting files is not supported", fp.oldPath)
+		}
+		if fp.oldPath != "" && fp.oldPath != fp.newPath {
+			return "", fmt.Errorf("patch renames %s to %s, renaming files is not supported", fp.oldPath, fp.newPath)
+		}
+
+		path := fp.newPath
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+		path = filepath.Clean(path)
+		if seen[path] {
+			return "", fmt.Errorf("patch changes %s more than once", path)
+		}
+		seen[path] = true
+
+		change := &fileChange{path: path, created: fp.oldPath == ""}
+		if change.created {
+			if err = e.validatePath(ctx, CreateCommand, path); err != nil {
+				return "", err
+			}
+		} else {
+			if err = e.validatePath(ctx, ApplyPatchCommand, path); err != nil {
+				return "", err
+			}
+			if change.oldContent, err = e.operator.ReadFile(ctx, path); err != nil {
+				return "", err
+			}
+		}
+
+		if change.newContent, err = applyHunks(change.oldContent, fp.hunks); err != nil {
+			return "", fmt.Errorf("patch not applied, %s: %w", path, err)
+		}
+		for _, h := range fp.hunks {
+			for _, l := range h.lines {
+				switch l.op {
+				case '+':
+					change.added++
+				case '-':
+					change.removed++
+				}
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	// Write all changes, and restore the written files if any write fails
+	for i, change := range changes {
+		if err = e.operator.WriteFile(ctx, change.path, change.newContent); err != nil {
+			for _, written := range changes[:i] {
+				_ = e.restoreFile(ctx, written.path, written.oldContent, written.created)
+			}
+			return "", fmt.Errorf("patch not applied, failed to write %s: %w", change.path, err)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Patch applied to %d file(s):\n", len(changes)))
+	for _, change := range changes {
+		if err = e.pushHistory(ctx, change.path, ApplyPatchCommand, change.oldContent, change.created); err != nil {
+			return "", err
+		}
+		status := "edited"
+		if change.created {
+			status = "created"
+		}
+		sb.WriteString(fmt.Sprintf("%s %s (+%d -%d)\n", status, change.path, change.added, change.removed))
+	}
+	sb.WriteString("Review the changes with the `view` command if necessary.")
+
+	return sb.String(), nil
+}
+
+// Search file contents with grep
+func (e *StrReplaceEditor) grep(ctx context.Context, path string, pattern string, include string) (string, error) {
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("path %s is not an absolute path", path)
+	}
+
+	grepCmd := "grep -rnIE"
+	if include != "" {
+		grepCmd += " --include=" + shellQuote(include)
+	}
+	// grep exits with 1 when nothing matches, which is not an error here
+	grepCmd += fmt.Sprintf(" -- %s %s; test $? -le 1", shellQuote(pattern), shellQuote(path))
+
+	stdout, err := e.operator.RunCommand(ctx, grepCmd)
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(stdout) == "" {
+		return fmt.Sprintf("No matches found for pattern `%s` in %s", pattern, path), nil
+	}
+
+	return truncate(stdout, MaxResponseLen), nil
+}
+
+// List files and directories by name
+func (e *StrReplaceEditor) find(ctx context.Context, path string, pattern string) (string, error) {
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("path %s is not an absolute path", path)
+	}
+
+	findCmd := fmt.Sprintf("find %s -not -path '*/\\.*' -name %s", shellQuote(path), shellQuote(pattern))
 
-	err := e.operator.WriteFile(ctx, path, oldText)
+	stdout, err := e.operator.RunCommand(ctx, findCmd)
 	if err != nil {
 		return "", err
 	}
+	if strings.TrimSpace(stdout) == "" {
+		return fmt.Sprintf("No files matching `%s` found in %s", pattern, path), nil
+	}
 
-	return fmt.Sprintf("Successfully undid last edit to %s. %s", path, e.makeOutput(oldText, path, 1)), nil
+	return truncate(stdout, MaxResponseLen), nil
 }
 
 // Format file content to display line numbers
@@ -485,3 +719,8 @@ func (e *StrReplaceEditor) makeOutput(fileContent string, fileDescriptor string,
 func expandTabs(s string) string {
 	return strings.Replace(s, "\t", "    ", -1)
 }
+
+// Helper function: quote s as a single shell word
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
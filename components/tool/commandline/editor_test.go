@@ -18,6 +18,7 @@ package commandline
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 
@@ -415,6 +416,265 @@ func TestStrReplaceEditor_UndoEdit(t *testing.T) {
 	assert.Contains(t, result, "Successfully undid")
 	assert.Contains(t, result, "line 2")
 	assert.NotContains(t, result, "replaced line 2")
+
+	// Test a failed undo leaves the history as is
+	mockOperator = NewMockFileOperator()
+	mockOperator.On("Exists", mock.Anything, "/test/file.txt").Return(true, nil)
+	mockOperator.On("IsDirectory", mock.Anything, "/test/file.txt").Return(false, nil)
+	mockOperator.On("ReadFile", mock.Anything, "/test/file.txt").Return(fileContent, nil)
+	mockOperator.On("WriteFile", mock.Anything, "/test/file.txt", fileContent).Return(errors.New("disk full"))
+	mockOperator.On("WriteFile", mock.Anything, "/test/file.txt", mock.Anything).Return(nil)
+	editor, _ = NewStrReplaceEditor(ctx, &EditorConfig{Operator: mockOperator})
+	_, err = editor.Execute(ctx, &StrReplaceEditorParams{
+		Command: StrReplaceCommand,
+		Path:    "/test/file.txt",
+		OldStr:  &oldStr,
+		NewStr:  &newStr,
+	})
+	assert.NoError(t, err)
+	_, err = editor.Execute(ctx, &StrReplaceEditorParams{
+		Command: UndoEditCommand,
+		Path:    "/test/file.txt",
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "disk full")
+	entries, err := editor.history.List(ctx, "/test/file.txt")
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.False(t, entries[0].Undone)
+	}
+}
+
+// TestStrReplaceEditor_ApplyPatch tests the apply_patch command
+func TestStrReplaceEditor_ApplyPatch(t *testing.T) {
+	ctx := context.Background()
+
+	patch := `--- a/a.txt
++++ b/a.txt
+@@ -1,2 +1,2 @@
+ line 1
+-line 2
++line two
+--- a/sub/b.txt
++++ b/sub/b.txt
+@@ -1 +1,2 @@
+ hello
++world
+--- /dev/null
++++ b/new.txt
+@@ -0,0 +1 @@
++new file
+`
+
+	setup := func() *MockFileOperator {
+		mockOperator := NewMockFileOperator()
+		mockOperator.On("Exists", mock.Anything, "/repo/a.txt").Return(true, nil)
+		mockOperator.On("IsDirectory", mock.Anything, "/repo/a.txt").Return(false, nil)
+		mockOperator.On("ReadFile", mock.Anything, "/repo/a.txt").Return("line 1\nline 2\n", nil)
+		mockOperator.On("Exists", mock.Anything, "/repo/sub/b.txt").Return(true, nil)
+		mockOperator.On("IsDirectory", mock.Anything, "/repo/sub/b.txt").Return(false, nil)
+		mockOperator.On("ReadFile", mock.Anything, "/repo/sub/b.txt").Return("hello\n", nil)
+		mockOperator.On("Exists", mock.Anything, "/repo/new.txt").Return(false, nil)
+		return mockOperator
+	}
+
+	// Test applying to several files
+	mockOperator := setup()
+	mockOperator.On("WriteFile", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	editor, _ := NewStrReplaceEditor(ctx, &EditorConfig{Operator: mockOperator})
+	result, err := editor.Execute(ctx, &StrReplaceEditorParams{
+		Command: ApplyPatchCommand,
+		Path:    "/repo",
+		Patch:   &patch,
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, result, "Patch applied to 3 file(s)")
+	assert.Contains(t, result, "edited /repo/a.txt (+1 -1)")
+	assert.Contains(t, result, "created /repo/new.txt (+1 -0)")
+	mockOperator.AssertCalled(t, "WriteFile", mock.Anything, "/repo/a.txt", "line 1\nline two\n")
+	mockOperator.AssertCalled(t, "WriteFile", mock.Anything, "/repo/sub/b.txt", "hello\nworld\n")
+	mockOperator.AssertCalled(t, "WriteFile", mock.Anything, "/repo/new.txt", "new file\n")
+
+	entries, err := editor.history.List(ctx, "")
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+
+	// Test undo of one of the files
+	result, err = editor.Execute(ctx, &StrReplaceEditorParams{
+		Command: UndoEditCommand,
+		Path:    "/repo/a.txt",
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, result, "Successfully undid")
+	mockOperator.AssertCalled(t, "WriteFile", mock.Anything, "/repo/a.txt", "line 1\nline 2\n")
+
+	// Test the created file is not emptied if it can not be removed
+	_, err = editor.Execute(ctx, &StrReplaceEditorParams{
+		Command: UndoEditCommand,
+		Path:    "/repo/new.txt",
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "does not support removing files")
+	mockOperator.AssertNotCalled(t, "WriteFile", mock.Anything, "/repo/new.txt", "")
+
+	// Test nothing is written if a hunk doesn't match
+	mockOperator = setup()
+	editor, _ = NewStrReplaceEditor(ctx, &EditorConfig{Operator: mockOperator})
+	badPatch := strings.Replace(patch, " hello", " goodbye", 1)
+	_, err = editor.Execute(ctx, &StrReplaceEditorParams{
+		Command: ApplyPatchCommand,
+		Path:    "/repo",
+		Patch:   &badPatch,
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "/repo/sub/b.txt")
+	mockOperator.AssertNotCalled(t, "WriteFile", mock.Anything, mock.Anything, mock.Anything)
+
+	// Test written files are restored if a write fails
+	mockOperator = setup()
+	mockOperator.On("WriteFile", mock.Anything, "/repo/a.txt", mock.Anything).Return(nil)
+	mockOperator.On("WriteFile", mock.Anything, "/repo/sub/b.txt", mock.Anything).Return(errors.New("disk full"))
+	editor, _ = NewStrReplaceEditor(ctx, &EditorConfig{Operator: mockOperator})
+	_, err = editor.Execute(ctx, &StrReplaceEditorParams{
+		Command: ApplyPatchCommand,
+		Path:    "/repo",
+		Patch:   &patch,
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "disk full")
+	mockOperator.AssertCalled(t, "WriteFile", mock.Anything, "/repo/a.txt", "line 1\nline 2\n")
+	mockOperator.AssertNotCalled(t, "WriteFile", mock.Anything, "/repo/new.txt", mock.Anything)
+	entries, err = editor.history.List(ctx, "")
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	// Test unsupported changes
+	for _, p := range []string{
+		"--- a/a.txt\n+++ /dev/null\n@@ -1,2 +0,0 @@\n-line 1\n-line 2\n",
+		"--- a/a.txt\n+++ b/c.txt\n@@ -1 +1 @@\n-line 1\n+line one\n",
+		"--- a/a.txt\n+++ b/a.txt\n@@ -1 +1 @@\n-line 1\n+line one\n--- a/a.txt\n+++ b/a.txt\n@@ -2 +2 @@\n-line 2\n+line two\n",
+	} {
+		_, err = editor.Execute(ctx, &StrReplaceEditorParams{
+			Command: ApplyPatchCommand,
+			Path:    "/repo",
+			Patch:   &p,
+		})
+		assert.Error(t, err)
+	}
+
+	// Test missing parameters
+	_, err = editor.Execute(ctx, &StrReplaceEditorParams{Command: ApplyPatchCommand, Path: "/repo"})
+	assert.Error(t, err)
+	_, err = editor.Execute(ctx, &StrReplaceEditorParams{Command: ApplyPatchCommand, Path: "repo", Patch: &patch})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not an absolute path")
+}
+
+// TestStrReplaceEditor_GrepFind tests the grep and find commands
+func TestStrReplaceEditor_GrepFind(t *testing.T) {
+	mockOperator := NewMockFileOperator()
+	ctx := context.Background()
+
+	mockOperator.SetupCommandOutputs(map[string]string{
+		"grep -rnIE --include='*.go' -- 'func (main|init)' '/repo'; test $? -le 1": "/repo/main.go:3:func main() {\n",
+		"grep -rnIE -- 'it'\\''s' '/repo'; test $? -le 1":                          "",
+		"find '/repo' -not -path '*/\\.*' -name '*.go'":                            "/repo/main.go\n/repo/sub/util.go\n",
+		"find '/repo' -not -path '*/\\.*' -name '*.rs'":                            "",
+	})
+	mockOperator.SetupExpectations()
+
+	editor, _ := NewStrReplaceEditor(ctx, &EditorConfig{Operator: mockOperator})
+
+	pattern, include := "func (main|init)", "*.go"
+	result, err := editor.Execute(ctx, &StrReplaceEditorParams{
+		Command: GrepCommand,
+		Path:    "/repo",
+		Pattern: &pattern,
+		Include: &include,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "/repo/main.go:3:func main() {\n", result)
+
+	pattern = "it's"
+	result, err = editor.Execute(ctx, &StrReplaceEditorParams{
+		Command: GrepCommand,
+		Path:    "/repo",
+		Pattern: &pattern,
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, result, "No matches found")
+
+	pattern = "*.go"
+	result, err = editor.Execute(ctx, &StrReplaceEditorParams{
+		Command: FindCommand,
+		Path:    "/repo",
+		Pattern: &pattern,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "/repo/main.go\n/repo/sub/util.go\n", result)
+
+	pattern = "*.rs"
+	result, err = editor.Execute(ctx, &StrReplaceEditorParams{
+		Command: FindCommand,
+		Path:    "/repo",
+		Pattern: &pattern,
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, result, "No files matching")
+
+	// Test missing pattern
+	_, err = editor.Execute(ctx, &StrReplaceEditorParams{Command: GrepCommand, Path: "/repo"})
+	assert.Error(t, err)
+	_, err = editor.Execute(ctx, &StrReplaceEditorParams{Command: FindCommand, Path: "repo", Pattern: &pattern})
+	assert.Error(t, err)
+}
+
+// TestStrReplaceEditor_PersistentHistory tests undo with a history store that outlives the editor
+func TestStrReplaceEditor_PersistentHistory(t *testing.T) {
+	mockOperator := NewMockFileOperator()
+	ctx := context.Background()
+
+	mockOperator.On("ReadFile", mock.Anything, "/test/file.txt").Return("hello world", nil)
+	mockOperator.On("WriteFile", mock.Anything, "/test/file.txt", mock.Anything).Return(nil)
+
+	dir := t.TempDir()
+	store, err := NewFileHistoryStore(dir)
+	assert.NoError(t, err)
+	editor, _ := NewStrReplaceEditor(ctx, &EditorConfig{Operator: mockOperator, HistoryStore: store})
+
+	oldStr, newStr := "world", "eino"
+	_, err = editor.Execute(ctx, &StrReplaceEditorParams{
+		Command: StrReplaceCommand,
+		Path:    "/test/file.txt",
+		OldStr:  &oldStr,
+		NewStr:  &newStr,
+	})
+	assert.NoError(t, err)
+
+	// Test undo after restart
+	store, err = NewFileHistoryStore(dir)
+	assert.NoError(t, err)
+	editor, _ = NewStrReplaceEditor(ctx, &EditorConfig{Operator: mockOperator, HistoryStore: store})
+	_, err = editor.Execute(ctx, &StrReplaceEditorParams{
+		Command: UndoEditCommand,
+		Path:    "/test/file.txt",
+	})
+	assert.NoError(t, err)
+
+	entries, err := store.List(ctx, "/test/file.txt")
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, StrReplaceCommand, entries[0].Command)
+		assert.Equal(t, "hello world", entries[0].Content)
+		assert.True(t, entries[0].Undone)
+	}
+
+	_, err = editor.Execute(ctx, &StrReplaceEditorParams{
+		Command: UndoEditCommand,
+		Path:    "/test/file.txt",
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no edit history found")
 }
 
 // TestTruncate tests the truncate function
@@ -0,0 +1,58 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/cloudwego/eino-ext/components/tool/commandline"
+	"github.com/cloudwego/eino-ext/components/tool/commandline/local"
+)
+
+func main() {
+	ctx := context.Background()
+	op, err := local.NewLocalOperator(ctx, &local.Config{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer op.Cleanup(ctx)
+
+	exec, err := commandline.NewPySessionExecutor(ctx, &commandline.PySessionExecutorConfig{Operator: op}) // use python3 by default
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer exec.Close()
+
+	for _, code := range []string{"import math\nr = 2", "math.pi * r ** 2"} {
+		log.Printf("execute code:\n%s", code)
+		result, err := exec.InvokableRun(ctx, mustMarshal(&commandline.PySessionInput{Code: code}))
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Println("result:\n", result)
+	}
+}
+
+func mustMarshal(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return string(b)
+}
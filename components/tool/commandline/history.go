@@ -0,0 +1,274 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commandline
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HistoryEntry is the content of a file before an edit
+type HistoryEntry struct {
+	Path    string    `json:"path"`
+	Command Command   `json:"command"`
+	Content string    `json:"content"`
+	Time    time.Time `json:"time"`
+	// Created is set if the edit created the file, undo_edit then removes the file instead of restoring Content.
+	Created bool `json:"created,omitempty"`
+	// Undone is set once the entry has been restored by undo_edit, it is kept for auditing.
+	Undone bool `json:"undone,omitempty"`
+}
+
+// HistoryStore keeps the edit history of StrReplaceEditor, so that edits can be undone and audited
+type HistoryStore interface {
+	// Push records an entry
+	Push(ctx context.Context, entry *HistoryEntry) error
+	// Peek returns the latest entry of path that has not been undone, or nil if there is no such entry.
+	Peek(ctx context.Context, path string) (*HistoryEntry, error)
+	// MarkUndone marks the latest entry of path that has not been undone as undone, once undo_edit has restored it.
+	MarkUndone(ctx context.Context, path string) error
+	// List returns the entries of path in the order they were pushed, including undone ones.
+	// Entries of all paths are returned if path is empty.
+	List(ctx context.Context, path string) ([]*HistoryEntry, error)
+}
+
+// NewMemoryHistoryStore creates a history store that is lost when the process exits, it is the default of StrReplaceEditor
+func NewMemoryHistoryStore() HistoryStore {
+	return &memoryHistoryStore{}
+}
+
+type memoryHistoryStore struct {
+	mu      sync.Mutex
+	entries []*HistoryEntry
+}
+
+func (m *memoryHistoryStore) Push(_ context.Context, entry *HistoryEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := *entry
+	m.entries = append(m.entries, &e)
+	return nil
+}
+
+func (m *memoryHistoryStore) Peek(_ context.Context, path string) (*HistoryEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e := m.latest(path); e != nil {
+		ret := *e
+		return &ret, nil
+	}
+	return nil, nil
+}
+
+func (m *memoryHistoryStore) MarkUndone(_ context.Context, path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := m.latest(path)
+	if e == nil {
+		return fmt.Errorf("no edit history found for %s", path)
+	}
+	e.Undone = true
+	return nil
+}
+
+// latest returns the latest entry of path that has not been undone, must be called with the lock held
+func (m *memoryHistoryStore) latest(path string) *HistoryEntry {
+	for i := len(m.entries) - 1; i >= 0; i-- {
+		if e := m.entries[i]; e.Path == path && !e.Undone {
+			return e
+		}
+	}
+	return nil
+}
+
+func (m *memoryHistoryStore) List(_ context.Context, path string) ([]*HistoryEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var entries []*HistoryEntry
+	for _, e := range m.entries {
+		if path == "" || e.Path == path {
+			ret := *e
+			entries = append(entries, &ret)
+		}
+	}
+	return entries, nil
+}
+
+// NewFileHistoryStore creates a history store that keeps one JSON lines file per edited path in dir,
+// so that edits can be undone after restarts.
+func NewFileHistoryStore(dir string) (HistoryStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create history dir: %w", err)
+	}
+	return &fileHistoryStore{dir: dir}, nil
+}
+
+type fileHistoryStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+func (f *fileHistoryStore) Push(_ context.Context, entry *HistoryEntry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal history entry: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.OpenFile(f.fileOf(entry.Path), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err = file.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+	return nil
+}
+
+func (f *fileHistoryStore) Peek(_ context.Context, path string) (*HistoryEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entries, err := readHistoryFile(f.fileOf(path))
+	if err != nil {
+		return nil, err
+	}
+	if i := latestEntry(entries); i >= 0 {
+		return entries[i], nil
+	}
+	return nil, nil
+}
+
+func (f *fileHistoryStore) MarkUndone(_ context.Context, path string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file := f.fileOf(path)
+	entries, err := readHistoryFile(file)
+	if err != nil {
+		return err
+	}
+	i := latestEntry(entries)
+	if i < 0 {
+		return fmt.Errorf("no edit history found for %s", path)
+	}
+	entries[i].Undone = true
+	return writeHistoryFile(file, entries)
+}
+
+// latestEntry returns the index of the latest entry that has not been undone, -1 if there is none
+func latestEntry(entries []*HistoryEntry) int {
+	for i := len(entries) - 1; i >= 0; i-- {
+		if !entries[i].Undone {
+			return i
+		}
+	}
+	return -1
+}
+
+func (f *fileHistoryStore) List(_ context.Context, path string) ([]*HistoryEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if path != "" {
+		return readHistoryFile(f.fileOf(path))
+	}
+
+	files, err := filepath.Glob(filepath.Join(f.dir, "*.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+	var entries []*HistoryEntry
+	for _, file := range files {
+		fileEntries, err := readHistoryFile(file)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, fileEntries...)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+	return entries, nil
+}
+
+// fileOf returns the history file of path, named by the hash of path to stay a valid file name
+func (f *fileHistoryStore) fileOf(path string) string {
+	sum := sha256.Sum256([]byte(path))
+	return filepath.Join(f.dir, hex.EncodeToString(sum[:16])+".jsonl")
+}
+
+func readHistoryFile(file string) ([]*HistoryEntry, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+
+	var entries []*HistoryEntry
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	scanner.Buffer(nil, len(b)+1)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		entry := &HistoryEntry{}
+		if err = json.Unmarshal([]byte(line), entry); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal history entry in %s: %w", file, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+func writeHistoryFile(file string, entries []*HistoryEntry) error {
+	var buf bytes.Buffer
+	for _, e := range entries {
+		b, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("failed to marshal history entry: %w", err)
+		}
+		buf.Write(append(b, '\n'))
+	}
+
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+	if err := os.Rename(tmp, file); err != nil {
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commandline
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testHistoryStore(t *testing.T, store HistoryStore) {
+	ctx := context.Background()
+	now := time.Now()
+
+	assert.NoError(t, store.Push(ctx, &HistoryEntry{Path: "/a.txt", Command: CreateCommand, Content: "v1", Time: now}))
+	assert.NoError(t, store.Push(ctx, &HistoryEntry{Path: "/b.txt", Command: InsertCommand, Content: "b1", Time: now.Add(time.Second)}))
+	assert.NoError(t, store.Push(ctx, &HistoryEntry{Path: "/a.txt", Command: StrReplaceCommand, Content: "v2", Time: now.Add(2 * time.Second)}))
+
+	entry, err := store.Peek(ctx, "/a.txt")
+	assert.NoError(t, err)
+	if assert.NotNil(t, entry) {
+		assert.Equal(t, "v2", entry.Content)
+		assert.Equal(t, StrReplaceCommand, entry.Command)
+		assert.False(t, entry.Undone)
+	}
+	entries, err := store.List(ctx, "/a.txt")
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 2) {
+		assert.False(t, entries[1].Undone)
+	}
+
+	assert.NoError(t, store.MarkUndone(ctx, "/a.txt"))
+	entries, err = store.List(ctx, "/a.txt")
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 2) {
+		assert.False(t, entries[0].Undone)
+		assert.True(t, entries[1].Undone)
+	}
+
+	entry, err = store.Peek(ctx, "/a.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", entry.Content)
+	assert.NoError(t, store.MarkUndone(ctx, "/a.txt"))
+	entry, err = store.Peek(ctx, "/a.txt")
+	assert.NoError(t, err)
+	assert.Nil(t, entry)
+	assert.Error(t, store.MarkUndone(ctx, "/a.txt"))
+
+	entries, err = store.List(ctx, "")
+	assert.NoError(t, err)
+	var contents []string
+	for _, e := range entries {
+		contents = append(contents, e.Content)
+	}
+	assert.Equal(t, []string{"v1", "b1", "v2"}, contents)
+}
+
+func TestMemoryHistoryStore(t *testing.T) {
+	testHistoryStore(t, NewMemoryHistoryStore())
+}
+
+func TestFileHistoryStore(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "history")
+	store, err := NewFileHistoryStore(dir)
+	assert.NoError(t, err)
+	testHistoryStore(t, store)
+
+	// survives restarts
+	assert.NoError(t, store.Push(ctx, &HistoryEntry{Path: "/a.txt", Content: "v3", Time: time.Now()}))
+	store, err = NewFileHistoryStore(dir)
+	assert.NoError(t, err)
+	entry, err := store.Peek(ctx, "/a.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, "v3", entry.Content)
+
+	files, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+}
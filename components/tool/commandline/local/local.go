@@ -0,0 +1,419 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/tool/commandline"
+)
+
+// Config configures the local operator
+type Config struct {
+	// WorkDir is the root directory of the operator. Relative paths are resolved against it,
+	// and file operations outside of it are rejected. A temporary directory is created if empty.
+	WorkDir string
+	// Env is appended to the environment of the current process when running commands.
+	Env []string
+	// Shell is the command line used to run commands, the command is passed as the last argument.
+	// Default is ["/bin/sh", "-c"], or ["cmd", "/C"] on windows.
+	Shell []string
+	// Timeout limits the execution time of each command. Default is 30s.
+	Timeout time.Duration
+	// MaxOutputBytes caps stdout and stderr of each command separately, the rest is dropped. Default is 1MB.
+	MaxOutputBytes int
+}
+
+// LocalOperator runs commands as subprocesses of the current process and operates on the local file system.
+// Note that only file operations are confined to WorkDir, commands can access anything the current user can.
+type LocalOperator struct {
+	config     Config
+	createdDir bool
+}
+
+const (
+	defaultTimeout        = time.Second * 30
+	defaultMaxOutputBytes = 1 << 20
+	streamBufferSize      = 64
+)
+
+// NewLocalOperator creates a new local operator with the given configuration
+func NewLocalOperator(_ context.Context, config *Config) (*LocalOperator, error) {
+	if config == nil {
+		config = &Config{}
+	} else {
+		nConfig := *config
+		config = &nConfig
+	}
+
+	if config.Timeout == 0 {
+		config.Timeout = defaultTimeout
+	}
+	if config.MaxOutputBytes == 0 {
+		config.MaxOutputBytes = defaultMaxOutputBytes
+	}
+	if len(config.Shell) == 0 {
+		if runtime.GOOS == "windows" {
+			config.Shell = []string{"cmd", "/C"}
+		} else {
+			config.Shell = []string{"/bin/sh", "-c"}
+		}
+	}
+
+	createdDir := false
+	if config.WorkDir == "" {
+		dir, err := os.MkdirTemp("", "commandline_")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create work dir: %w", err)
+		}
+		config.WorkDir = dir
+		createdDir = true
+	} else if err := os.MkdirAll(config.WorkDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create work dir: %w", err)
+	}
+
+	// resolve symlinks so that confinement checks compare real paths
+	workDir, err := filepath.Abs(config.WorkDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve work dir: %w", err)
+	}
+	if workDir, err = filepath.EvalSymlinks(workDir); err != nil {
+		return nil, fmt.Errorf("failed to resolve work dir: %w", err)
+	}
+	config.WorkDir = workDir
+
+	return &LocalOperator{
+		config:     *config,
+		createdDir: createdDir,
+	}, nil
+}
+
+// WorkDir returns the resolved root directory of the operator
+func (o *LocalOperator) WorkDir() string {
+	return o.config.WorkDir
+}
+
+// RunCommand executes a command in the work directory
+func (o *LocalOperator) RunCommand(ctx context.Context, command string) (string, error) {
+	result, err := o.ExecCommand(ctx, command)
+	if err != nil {
+		return "", err
+	}
+	if result.TimedOut {
+		return "", fmt.Errorf("command execution timed out after %v", o.config.Timeout)
+	}
+	if result.ExitCode != 0 {
+		return "", fmt.Errorf("command execution failed with exit code %d: %s",
+			result.ExitCode, result.Stderr)
+	}
+
+	if result.Truncated && len(result.Stdout) >= o.config.MaxOutputBytes {
+		return result.Stdout + commandline.OutputTruncatedMessage, nil
+	}
+	return result.Stdout, nil
+}
+
+// ExecCommand executes a command in the work directory, and returns its exit code and output
+func (o *LocalOperator) ExecCommand(ctx context.Context, command string) (*commandline.CommandResult, error) {
+	return o.exec(ctx, command, nil)
+}
+
+// StreamCommand executes a command in the work directory, and yields its output line by line.
+// Closing the stream early kills the command.
+func (o *LocalOperator) StreamCommand(ctx context.Context, command string) (*schema.StreamReader[*commandline.CommandOutput], error) {
+	sr, sw := schema.Pipe[*commandline.CommandOutput](streamBufferSize)
+
+	go func() {
+		defer sw.Close()
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		result, err := o.exec(ctx, command, func(stream commandline.OutputStream, line string) {
+			if closed := sw.Send(&commandline.CommandOutput{Stream: stream, Line: line}, nil); closed {
+				cancel()
+			}
+		})
+		if err != nil {
+			sw.Send(nil, err)
+			return
+		}
+		sw.Send(&commandline.CommandOutput{Result: result}, nil)
+	}()
+
+	return sr, nil
+}
+
+func (o *LocalOperator) exec(ctx context.Context, command string, onLine func(commandline.OutputStream, string)) (*commandline.CommandResult, error) {
+	execCtx, cancel := context.WithTimeout(ctx, o.config.Timeout)
+	defer cancel()
+
+	args := append(append([]string{}, o.config.Shell[1:]...), command)
+	cmd := exec.CommandContext(execCtx, o.config.Shell[0], args...)
+	cmd.Dir = o.config.WorkDir
+	cmd.Env = append(os.Environ(), o.config.Env...)
+	// don't wait forever for pipes held open by orphaned child processes
+	cmd.WaitDelay = time.Second
+
+	stdout := commandline.NewOutputWriter(commandline.StdoutStream, o.config.MaxOutputBytes, onLine)
+	stderr := commandline.NewOutputWriter(commandline.StderrStream, o.config.MaxOutputBytes, onLine)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	start := time.Now()
+	err := cmd.Run()
+	duration := time.Since(start)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	stdout.Flush()
+	stderr.Flush()
+
+	result := &commandline.CommandResult{
+		Stdout:    stdout.String(),
+		Stderr:    stderr.String(),
+		Duration:  duration,
+		Truncated: stdout.Truncated() || stderr.Truncated(),
+	}
+
+	if errors.Is(execCtx.Err(), context.DeadlineExceeded) {
+		result.ExitCode = -1
+		result.TimedOut = true
+		return result, nil
+	}
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("failed to run command: %w", err)
+		}
+		result.ExitCode = exitErr.ExitCode()
+	}
+
+	return result, nil
+}
+
+// StartProcess starts a long-running command in the work directory. Timeout and MaxOutputBytes don't apply,
+// the process runs until it exits or is closed.
+func (o *LocalOperator) StartProcess(_ context.Context, command string) (commandline.Process, error) {
+	args := append(append([]string{}, o.config.Shell[1:]...), command)
+	cmd := exec.Command(o.config.Shell[0], args...)
+	cmd.Dir = o.config.WorkDir
+	cmd.Env = append(os.Environ(), o.config.Env...)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdin pipe: %w", err)
+	}
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+
+	if err = cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start process: %w", err)
+	}
+
+	p := &localProcess{
+		cmd:    cmd,
+		stdin:  stdin,
+		stdout: pr,
+		done:   make(chan struct{}),
+	}
+	go func() {
+		defer close(p.done)
+		_ = pw.CloseWithError(cmd.Wait())
+	}()
+
+	return p, nil
+}
+
+// ReadFile reads a file in the work directory
+func (o *LocalOperator) ReadFile(_ context.Context, path string) (string, error) {
+	resolvedPath, err := o.safeResolvePath(path)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := os.ReadFile(resolvedPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	return string(content), nil
+}
+
+// WriteFile writes content to a file in the work directory, creating parent directories as needed
+func (o *LocalOperator) WriteFile(_ context.Context, path string, content string) error {
+	resolvedPath, err := o.safeResolvePath(path)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(resolvedPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	if err = os.WriteFile(resolvedPath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveFile removes a file in the work directory
+func (o *LocalOperator) RemoveFile(_ context.Context, path string) error {
+	resolvedPath, err := o.safeResolvePath(path)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(resolvedPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove file: %w", err)
+	}
+
+	return nil
+}
+
+// IsDirectory checks if a path in the work directory is a directory
+func (o *LocalOperator) IsDirectory(_ context.Context, path string) (bool, error) {
+	resolvedPath, err := o.safeResolvePath(path)
+	if err != nil {
+		return false, err
+	}
+
+	info, err := os.Stat(resolvedPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check path type: %w", err)
+	}
+
+	return info.IsDir(), nil
+}
+
+// Exists checks if a path exists in the work directory
+func (o *LocalOperator) Exists(_ context.Context, path string) (bool, error) {
+	resolvedPath, err := o.safeResolvePath(path)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = os.Stat(resolvedPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check path existence: %w", err)
+	}
+
+	return true, nil
+}
+
+// Cleanup removes the work directory if it was created by the operator
+func (o *LocalOperator) Cleanup(_ context.Context) error {
+	if !o.createdDir {
+		return nil
+	}
+	return os.RemoveAll(o.config.WorkDir)
+}
+
+// safeResolvePath resolves path against the work directory, and rejects paths that end up outside of it,
+// including through symlinks.
+func (o *LocalOperator) safeResolvePath(path string) (string, error) {
+	if path == "" {
+		return "", errors.New("path is empty")
+	}
+
+	resolved := path
+	if !filepath.IsAbs(resolved) {
+		resolved = filepath.Join(o.config.WorkDir, resolved)
+	}
+	resolved = filepath.Clean(resolved)
+
+	if !o.within(resolved) {
+		return "", fmt.Errorf("path %s is outside of work dir %s", path, o.config.WorkDir)
+	}
+
+	// the longest existing prefix is what symlinks can redirect
+	existing, rest := resolved, ""
+	for {
+		if _, err := os.Lstat(existing); err == nil {
+			break
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			break
+		}
+		rest = filepath.Join(filepath.Base(existing), rest)
+		existing = parent
+	}
+	realPath, err := filepath.EvalSymlinks(existing)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path %s: %w", path, err)
+	}
+	if !o.within(realPath) {
+		return "", fmt.Errorf("path %s is outside of work dir %s", path, o.config.WorkDir)
+	}
+
+	return filepath.Join(realPath, rest), nil
+}
+
+func (o *LocalOperator) within(path string) bool {
+	rel, err := filepath.Rel(o.config.WorkDir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+type localProcess struct {
+	cmd       *exec.Cmd
+	stdin     io.WriteCloser
+	stdout    *io.PipeReader
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (p *localProcess) Read(b []byte) (int, error) {
+	return p.stdout.Read(b)
+}
+
+func (p *localProcess) Write(b []byte) (int, error) {
+	return p.stdin.Write(b)
+}
+
+func (p *localProcess) Close() error {
+	p.closeOnce.Do(func() {
+		_ = p.stdin.Close()
+		_ = p.cmd.Process.Kill()
+		_ = p.stdout.Close()
+		<-p.done
+	})
+	return nil
+}
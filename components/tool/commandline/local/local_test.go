@@ -0,0 +1,307 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package local
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/components/tool/commandline"
+)
+
+var _ commandline.Operator = (*LocalOperator)(nil)
+
+func TestNewLocalOperator(t *testing.T) {
+	ctx := context.Background()
+
+	op, err := NewLocalOperator(ctx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultTimeout, op.config.Timeout)
+	assert.Equal(t, defaultMaxOutputBytes, op.config.MaxOutputBytes)
+	assert.DirExists(t, op.WorkDir())
+	assert.NoError(t, op.Cleanup(ctx))
+	assert.NoDirExists(t, op.WorkDir())
+
+	dir := filepath.Join(t.TempDir(), "work")
+	op, err = NewLocalOperator(ctx, &Config{WorkDir: dir, Timeout: time.Minute})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, op.config.Timeout)
+	assert.NoError(t, op.Cleanup(ctx))
+	assert.DirExists(t, dir)
+}
+
+func TestLocalOperator_Files(t *testing.T) {
+	ctx := context.Background()
+	op, err := NewLocalOperator(ctx, &Config{WorkDir: t.TempDir()})
+	assert.NoError(t, err)
+
+	assert.NoError(t, op.WriteFile(ctx, "a/b.txt", "hello"))
+	content, err := op.ReadFile(ctx, filepath.Join(op.WorkDir(), "a", "b.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", content)
+
+	exists, err := op.Exists(ctx, "a/b.txt")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = op.Exists(ctx, "a/c.txt")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	isDir, err := op.IsDirectory(ctx, "a")
+	assert.NoError(t, err)
+	assert.True(t, isDir)
+	isDir, err = op.IsDirectory(ctx, "a/b.txt")
+	assert.NoError(t, err)
+	assert.False(t, isDir)
+
+	_, err = op.ReadFile(ctx, "missing.txt")
+	assert.Error(t, err)
+}
+
+func TestLocalOperator_Confinement(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	op, err := NewLocalOperator(ctx, &Config{WorkDir: filepath.Join(root, "work")})
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "secret.txt"), []byte("secret"), 0644))
+
+	for _, p := range []string{"../secret.txt", filepath.Join(root, "secret.txt"), "a/../../secret.txt", ""} {
+		_, err = op.ReadFile(ctx, p)
+		assert.Error(t, err, p)
+		assert.Error(t, op.WriteFile(ctx, p, "x"), p)
+		_, err = op.Exists(ctx, p)
+		assert.Error(t, err, p)
+	}
+
+	// ".." inside the work dir is fine
+	assert.NoError(t, op.WriteFile(ctx, "a/../b.txt", "b"))
+	content, err := op.ReadFile(ctx, "b.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, "b", content)
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+	assert.NoError(t, os.Symlink(root, filepath.Join(op.WorkDir(), "escape")))
+	_, err = op.ReadFile(ctx, "escape/secret.txt")
+	assert.Error(t, err)
+	assert.Error(t, op.WriteFile(ctx, "escape/new/file.txt", "x"))
+	assert.NoFileExists(t, filepath.Join(root, "new", "file.txt"))
+}
+
+func TestLocalOperator_RunCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires /bin/sh")
+	}
+	ctx := context.Background()
+	op, err := NewLocalOperator(ctx, &Config{
+		WorkDir:        t.TempDir(),
+		Env:            []string{"GREETING=hi"},
+		Timeout:        time.Second,
+		MaxOutputBytes: 16,
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, op.WriteFile(ctx, "f.txt", "content"))
+	out, err := op.RunCommand(ctx, "cat f.txt && echo $GREETING")
+	assert.NoError(t, err)
+	assert.Equal(t, "contenthi\n", out)
+
+	_, err = op.RunCommand(ctx, "echo oops >&2; exit 3")
+	assert.ErrorContains(t, err, "exit code 3: oops")
+
+	out, err = op.RunCommand(ctx, "printf '%040d' 0")
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("0", 16)+commandline.OutputTruncatedMessage, out)
+
+	start := time.Now()
+	_, err = op.RunCommand(ctx, "sleep 10")
+	assert.ErrorContains(t, err, "timed out")
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestLocalOperator_Editor(t *testing.T) {
+	ctx := context.Background()
+	op, err := NewLocalOperator(ctx, &Config{WorkDir: t.TempDir()})
+	assert.NoError(t, err)
+	editor, err := commandline.NewStrReplaceEditor(ctx, &commandline.EditorConfig{Operator: op})
+	assert.NoError(t, err)
+
+	p := filepath.Join(op.WorkDir(), "main.py")
+	_, err = editor.InvokableRun(ctx, `{"command": "create", "path": "`+p+`", "file_text": "print('a')\n"}`)
+	assert.NoError(t, err)
+	_, err = editor.InvokableRun(ctx, `{"command": "str_replace", "path": "`+p+`", "old_str": "'a'", "new_str": "'b'"}`)
+	assert.NoError(t, err)
+
+	content, err := op.ReadFile(ctx, p)
+	assert.NoError(t, err)
+	assert.Equal(t, "print('b')\n", content)
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+	patch := "--- a/main.py\n+++ b/main.py\n@@ -1 +1,2 @@\n print('b')\n+print('it''s')\n--- /dev/null\n+++ b/pkg/util.py\n@@ -0,0 +1 @@\n+x = 1\n"
+	args, _ := json.Marshal(map[string]string{"command": "apply_patch", "path": op.WorkDir(), "patch": patch})
+	_, err = editor.InvokableRun(ctx, string(args))
+	assert.NoError(t, err)
+
+	out, err := editor.InvokableRun(ctx, `{"command": "grep", "path": "`+op.WorkDir()+`", "pattern": "it''s|x = ", "include": "*.py"}`)
+	assert.NoError(t, err)
+	assert.Contains(t, out, "main.py:2:print('it''s')")
+	assert.Contains(t, out, filepath.Join("pkg", "util.py")+":1:x = 1")
+
+	out, err = editor.InvokableRun(ctx, `{"command": "grep", "path": "`+op.WorkDir()+`", "pattern": "missing"}`)
+	assert.NoError(t, err)
+	assert.Contains(t, out, "No matches found")
+
+	out, err = editor.InvokableRun(ctx, `{"command": "find", "path": "`+op.WorkDir()+`", "pattern": "*.py"}`)
+	assert.NoError(t, err)
+	assert.Contains(t, out, filepath.Join(op.WorkDir(), "pkg", "util.py"))
+	assert.NotContains(t, out, ".txt")
+}
+
+func TestLocalOperator_StartProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires /bin/sh")
+	}
+	ctx := context.Background()
+	op, err := NewLocalOperator(ctx, &Config{WorkDir: t.TempDir()})
+	assert.NoError(t, err)
+
+	p, err := op.StartProcess(ctx, "while read line; do echo \"got $line\"; echo err >&2; done")
+	assert.NoError(t, err)
+	r := bufio.NewReader(p)
+
+	_, err = p.Write([]byte("a\n"))
+	assert.NoError(t, err)
+	line, err := r.ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, "got a\n", line)
+	line, err = r.ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, "err\n", line)
+
+	assert.NoError(t, p.Close())
+	assert.NoError(t, p.Close())
+	_, err = r.ReadString('\n')
+	assert.Error(t, err)
+
+	// a session executor can keep its interpreter in the operator
+	if _, err = exec.LookPath("python3"); err != nil {
+		return
+	}
+	executor, err := commandline.NewPySessionExecutor(ctx, &commandline.PySessionExecutorConfig{Operator: op})
+	assert.NoError(t, err)
+	defer executor.Close()
+	_, err = executor.Execute(ctx, &commandline.PySessionInput{Code: "x = 2"})
+	assert.NoError(t, err)
+	result, err := executor.Execute(ctx, &commandline.PySessionInput{Code: "x * 21"})
+	assert.NoError(t, err)
+	assert.Equal(t, "42", result.Value)
+}
+
+func TestLocalOperator_ExecCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires /bin/sh")
+	}
+	ctx := context.Background()
+	op, err := NewLocalOperator(ctx, &Config{WorkDir: t.TempDir(), Timeout: 500 * time.Millisecond, MaxOutputBytes: 8})
+	assert.NoError(t, err)
+
+	result, err := op.ExecCommand(ctx, "echo out; echo err >&2; exit 2")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.ExitCode)
+	assert.Equal(t, "out\n", result.Stdout)
+	assert.Equal(t, "err\n", result.Stderr)
+	assert.False(t, result.Truncated)
+	assert.Positive(t, result.Duration)
+
+	result, err = op.ExecCommand(ctx, "echo 0123456789")
+	assert.NoError(t, err)
+	assert.Equal(t, "01234567", result.Stdout)
+	assert.True(t, result.Truncated)
+
+	result, err = op.ExecCommand(ctx, "echo started; sleep 10")
+	assert.NoError(t, err)
+	assert.True(t, result.TimedOut)
+	assert.Equal(t, -1, result.ExitCode)
+	assert.Equal(t, "started\n", result.Stdout)
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = op.ExecCommand(cctx, "true")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestLocalOperator_StreamCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires /bin/sh")
+	}
+	ctx := context.Background()
+	op, err := NewLocalOperator(ctx, &Config{WorkDir: t.TempDir()})
+	assert.NoError(t, err)
+
+	sr, err := op.StreamCommand(ctx, "echo one; sleep 0.1; echo two >&2; sleep 0.1; printf three; exit 1")
+	assert.NoError(t, err)
+	var lines []string
+	var result *commandline.CommandResult
+	for {
+		out, err := sr.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		if out.Result != nil {
+			result = out.Result
+			continue
+		}
+		lines = append(lines, string(out.Stream)+": "+out.Line)
+	}
+	assert.Equal(t, []string{"stdout: one", "stderr: two", "stdout: three"}, lines)
+	if assert.NotNil(t, result) {
+		assert.Equal(t, 1, result.ExitCode)
+		assert.Equal(t, "one\nthree", result.Stdout)
+	}
+
+	// closing the stream kills the command
+	sr, err = op.StreamCommand(ctx, "while true; do echo tick; echo x >> ticks.txt; sleep 0.05; done")
+	assert.NoError(t, err)
+	out, err := sr.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, "tick", out.Line)
+	sr.Close()
+	ticks := func() string {
+		content, _ := op.ReadFile(ctx, "ticks.txt")
+		return content
+	}
+	assert.Eventually(t, func() bool {
+		before := ticks()
+		time.Sleep(200 * time.Millisecond)
+		return ticks() == before
+	}, 5*time.Second, 10*time.Millisecond)
+}
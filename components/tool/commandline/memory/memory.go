@@ -0,0 +1,336 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CommandHandler simulates a command run through MemoryOperator.RunCommand.
+// The handler can access the in-memory file system through op.
+type CommandHandler func(ctx context.Context, op *MemoryOperator, command string) (string, error)
+
+// Config configures the in-memory operator
+type Config struct {
+	// WorkDir is the directory relative paths are resolved against. Default is "/".
+	WorkDir string
+	// Files is the initial content of the file system, keyed by path.
+	Files map[string]string
+	// Dirs are empty directories to create initially.
+	Dirs []string
+	// Handlers simulate commands, keyed by program name, i.e. the first word of the command.
+	// A handler for "find" is provided by default, which lists paths in the file system.
+	Handlers map[string]CommandHandler
+}
+
+// MemoryOperator keeps files in memory and simulates commands through registered handlers, it is mainly used in tests.
+// It is safe for concurrent use.
+type MemoryOperator struct {
+	mu       sync.RWMutex
+	workDir  string
+	files    map[string]string
+	dirs     map[string]bool
+	handlers map[string]CommandHandler
+	commands []string
+}
+
+// NewMemoryOperator creates a new in-memory operator with the given configuration
+func NewMemoryOperator(_ context.Context, config *Config) (*MemoryOperator, error) {
+	if config == nil {
+		config = &Config{}
+	}
+
+	workDir := config.WorkDir
+	if workDir == "" {
+		workDir = "/"
+	}
+	if !path.IsAbs(workDir) {
+		return nil, fmt.Errorf("work dir %s is not an absolute path", workDir)
+	}
+
+	m := &MemoryOperator{
+		workDir: path.Clean(workDir),
+		files:   make(map[string]string),
+		dirs:    map[string]bool{"/": true},
+		handlers: map[string]CommandHandler{
+			"find": findHandler,
+		},
+	}
+	m.mkdirAll(m.workDir)
+
+	for _, dir := range config.Dirs {
+		if err := m.Mkdir(dir); err != nil {
+			return nil, err
+		}
+	}
+	for p, content := range config.Files {
+		if err := m.WriteFile(context.Background(), p, content); err != nil {
+			return nil, err
+		}
+	}
+	for name, handler := range config.Handlers {
+		m.handlers[name] = handler
+	}
+
+	return m, nil
+}
+
+// Handle registers the handler for commands whose program name is name, replacing any previous one
+func (m *MemoryOperator) Handle(name string, handler CommandHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handlers[name] = handler
+}
+
+// RunCommand dispatches the command to the handler registered for its program name
+func (m *MemoryOperator) RunCommand(ctx context.Context, command string) (string, error) {
+	name := programName(command)
+
+	m.mu.Lock()
+	m.commands = append(m.commands, command)
+	handler, ok := m.handlers[name]
+	m.mu.Unlock()
+
+	if !ok {
+		return "", fmt.Errorf("no handler registered for command: %s", name)
+	}
+
+	return handler(ctx, m, command)
+}
+
+// Commands returns the commands run so far, in order
+func (m *MemoryOperator) Commands() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]string{}, m.commands...)
+}
+
+// Files returns a copy of all files, keyed by absolute path
+func (m *MemoryOperator) Files() map[string]string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	files := make(map[string]string, len(m.files))
+	for p, content := range m.files {
+		files[p] = content
+	}
+	return files
+}
+
+// ReadFile reads a file
+func (m *MemoryOperator) ReadFile(_ context.Context, p string) (string, error) {
+	resolved := m.resolve(p)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	content, ok := m.files[resolved]
+	if !ok {
+		if m.dirs[resolved] {
+			return "", fmt.Errorf("failed to read file: %s is a directory", p)
+		}
+		return "", fmt.Errorf("failed to read file %s: %w", p, fs.ErrNotExist)
+	}
+	return content, nil
+}
+
+// WriteFile writes content to a file, creating parent directories as needed
+func (m *MemoryOperator) WriteFile(_ context.Context, p string, content string) error {
+	resolved := m.resolve(p)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.dirs[resolved] {
+		return fmt.Errorf("failed to write file: %s is a directory", p)
+	}
+	if err := m.checkParents(resolved); err != nil {
+		return err
+	}
+
+	m.mkdirAll(path.Dir(resolved))
+	m.files[resolved] = content
+	return nil
+}
+
+// RemoveFile removes a file, its parent directories are kept
+func (m *MemoryOperator) RemoveFile(_ context.Context, p string) error {
+	resolved := m.resolve(p)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.dirs[resolved] {
+		return fmt.Errorf("failed to remove file: %s is a directory", p)
+	}
+	delete(m.files, resolved)
+	return nil
+}
+
+// Mkdir creates a directory and its parents
+func (m *MemoryOperator) Mkdir(p string) error {
+	resolved := m.resolve(p)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.files[resolved]; ok {
+		return fmt.Errorf("failed to create directory: %s is a file", p)
+	}
+	if err := m.checkParents(resolved); err != nil {
+		return err
+	}
+
+	m.mkdirAll(resolved)
+	return nil
+}
+
+// IsDirectory checks if a path is a directory
+func (m *MemoryOperator) IsDirectory(_ context.Context, p string) (bool, error) {
+	resolved := m.resolve(p)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.dirs[resolved], nil
+}
+
+// Exists checks if a path exists
+func (m *MemoryOperator) Exists(_ context.Context, p string) (bool, error) {
+	resolved := m.resolve(p)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	_, isFile := m.files[resolved]
+	return isFile || m.dirs[resolved], nil
+}
+
+// resolve returns the clean absolute form of p
+func (m *MemoryOperator) resolve(p string) string {
+	if !path.IsAbs(p) {
+		p = path.Join(m.workDir, p)
+	}
+	return path.Clean(p)
+}
+
+// checkParents fails if any parent of the resolved path is a file, must be called with the lock held
+func (m *MemoryOperator) checkParents(resolved string) error {
+	for dir := path.Dir(resolved); dir != "/"; dir = path.Dir(dir) {
+		if _, ok := m.files[dir]; ok {
+			return fmt.Errorf("%s is not a directory", dir)
+		}
+	}
+	return nil
+}
+
+// mkdirAll marks dir and its parents as directories, must be called with the lock held
+func (m *MemoryOperator) mkdirAll(dir string) {
+	for ; dir != "/"; dir = path.Dir(dir) {
+		m.dirs[dir] = true
+	}
+}
+
+func programName(command string) string {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return ""
+	}
+	return path.Base(fields[0])
+}
+
+// findHandler supports `find [path] [-maxdepth n] [-name glob]`, hidden paths are skipped when the command
+// excludes them with `-not -path`, other expressions are ignored. Arguments may be single-quoted.
+func findHandler(_ context.Context, m *MemoryOperator, command string) (string, error) {
+	fields := strings.Fields(command)[1:]
+	root, maxDepth, name := m.workDir, -1, ""
+	for i := 0; i < len(fields); i++ {
+		switch {
+		case fields[i] == "-maxdepth" && i+1 < len(fields):
+			depth, err := strconv.Atoi(fields[i+1])
+			if err != nil {
+				return "", fmt.Errorf("find: invalid maxdepth %s", fields[i+1])
+			}
+			maxDepth = depth
+			i++
+		case fields[i] == "-name" && i+1 < len(fields):
+			name = unquote(fields[i+1])
+			i++
+		case i == 0 && !strings.HasPrefix(fields[i], "-"):
+			root = m.resolve(unquote(fields[i]))
+		}
+	}
+	skipHidden := strings.Contains(command, "-not -path")
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	_, isFile := m.files[root]
+	if !isFile && !m.dirs[root] {
+		return "", fmt.Errorf("command execution failed with exit code 1: find: %s: No such file or directory", root)
+	}
+
+	var paths []string
+	collect := func(p string) {
+		if p == root {
+			return
+		}
+		rel := strings.TrimPrefix(p, strings.TrimSuffix(root, "/")+"/")
+		if rel == p {
+			return
+		}
+		if maxDepth >= 0 && strings.Count(rel, "/")+1 > maxDepth {
+			return
+		}
+		if skipHidden && (strings.HasPrefix(rel, ".") || strings.Contains(rel, "/.")) {
+			return
+		}
+		if matched, _ := path.Match(name, path.Base(p)); name != "" && !matched {
+			return
+		}
+		paths = append(paths, p)
+	}
+	for p := range m.files {
+		collect(p)
+	}
+	for p := range m.dirs {
+		collect(p)
+	}
+	sort.Strings(paths)
+
+	if matched, _ := path.Match(name, path.Base(root)); name == "" || matched {
+		paths = append([]string{root}, paths...)
+	}
+	if len(paths) == 0 {
+		return "", nil
+	}
+	return strings.Join(paths, "\n") + "\n", nil
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/components/tool/commandline"
+)
+
+var _ commandline.Operator = (*MemoryOperator)(nil)
+
+func TestMemoryOperator_Files(t *testing.T) {
+	ctx := context.Background()
+	op, err := NewMemoryOperator(ctx, &Config{
+		WorkDir: "/workspace",
+		Files:   map[string]string{"a/b.txt": "hello"},
+		Dirs:    []string{"/empty"},
+	})
+	assert.NoError(t, err)
+
+	content, err := op.ReadFile(ctx, "/workspace/a/b.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", content)
+
+	for p, want := range map[string]bool{"/workspace/a": true, "/empty": true, "/": true, "a/b.txt": false, "/missing": false} {
+		isDir, err := op.IsDirectory(ctx, p)
+		assert.NoError(t, err)
+		assert.Equal(t, want, isDir, p)
+	}
+	for p, want := range map[string]bool{"/workspace/a": true, "a/b.txt": true, "a/c.txt": false} {
+		exists, err := op.Exists(ctx, p)
+		assert.NoError(t, err)
+		assert.Equal(t, want, exists, p)
+	}
+
+	_, err = op.ReadFile(ctx, "missing.txt")
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+	_, err = op.ReadFile(ctx, "a")
+	assert.Error(t, err)
+	assert.Error(t, op.WriteFile(ctx, "a", "x"))
+	assert.Error(t, op.WriteFile(ctx, "a/b.txt/c", "x"))
+
+	assert.NoError(t, op.WriteFile(ctx, "../c.txt", "c"))
+	assert.Equal(t, map[string]string{"/workspace/a/b.txt": "hello", "/c.txt": "c"}, op.Files())
+
+	_, err = NewMemoryOperator(ctx, &Config{WorkDir: "relative"})
+	assert.Error(t, err)
+}
+
+func TestMemoryOperator_RunCommand(t *testing.T) {
+	ctx := context.Background()
+	op, err := NewMemoryOperator(ctx, &Config{
+		Files: map[string]string{"/w/main.py": "print('hi')", "/w/.git/config": "", "/w/sub/deep/x.txt": ""},
+		Handlers: map[string]CommandHandler{
+			"python3": func(ctx context.Context, op *MemoryOperator, command string) (string, error) {
+				code, err := op.ReadFile(ctx, strings.Fields(command)[1])
+				if err != nil {
+					return "", err
+				}
+				return strings.TrimSuffix(strings.TrimPrefix(code, "print('"), "')") + "\n", nil
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	out, err := op.RunCommand(ctx, "python3 /w/main.py")
+	assert.NoError(t, err)
+	assert.Equal(t, "hi\n", out)
+
+	_, err = op.RunCommand(ctx, "ls /w")
+	assert.ErrorContains(t, err, "no handler registered for command: ls")
+
+	op.Handle("ls", func(ctx context.Context, op *MemoryOperator, command string) (string, error) {
+		return "main.py\n", nil
+	})
+	out, err = op.RunCommand(ctx, "/bin/ls /w")
+	assert.NoError(t, err)
+	assert.Equal(t, "main.py\n", out)
+
+	out, err = op.RunCommand(ctx, `find /w -maxdepth 2 -not -path '*/\.*'`)
+	assert.NoError(t, err)
+	assert.Equal(t, "/w\n/w/main.py\n/w/sub\n/w/sub/deep\n", out)
+
+	out, err = op.RunCommand(ctx, "find /w/sub")
+	assert.NoError(t, err)
+	assert.Equal(t, "/w/sub\n/w/sub/deep\n/w/sub/deep/x.txt\n", out)
+
+	out, err = op.RunCommand(ctx, `find '/w' -not -path '*/\.*' -name '*.txt'`)
+	assert.NoError(t, err)
+	assert.Equal(t, "/w/sub/deep/x.txt\n", out)
+
+	_, err = op.RunCommand(ctx, "find /missing")
+	assert.Error(t, err)
+
+	assert.Equal(t, []string{
+		"python3 /w/main.py",
+		"ls /w",
+		"/bin/ls /w",
+		`find /w -maxdepth 2 -not -path '*/\.*'`,
+		"find /w/sub",
+		`find '/w' -not -path '*/\.*' -name '*.txt'`,
+		"find /missing",
+	}, op.Commands())
+}
+
+func TestMemoryOperator_Editor(t *testing.T) {
+	ctx := context.Background()
+	op, err := NewMemoryOperator(ctx, &Config{Files: map[string]string{"/w/a.txt": "one\ntwo\n"}})
+	assert.NoError(t, err)
+	editor, err := commandline.NewStrReplaceEditor(ctx, &commandline.EditorConfig{Operator: op})
+	assert.NoError(t, err)
+
+	_, err = editor.InvokableRun(ctx, `{"command": "str_replace", "path": "/w/a.txt", "old_str": "two", "new_str": "three"}`)
+	assert.NoError(t, err)
+	content, _ := op.ReadFile(ctx, "/w/a.txt")
+	assert.Equal(t, "one\nthree\n", content)
+
+	out, err := editor.InvokableRun(ctx, `{"command": "view", "path": "/w"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "/w\n/w/a.txt\n", out)
+
+	// undoing the creation of a file by a patch removes it
+	_, err = editor.InvokableRun(ctx, `{"command": "apply_patch", "path": "/w", "patch": "--- /dev/null\n+++ b/b.txt\n@@ -0,0 +1 @@\n+new\n"}`)
+	assert.NoError(t, err)
+	content, _ = op.ReadFile(ctx, "/w/b.txt")
+	assert.Equal(t, "new\n", content)
+	_, err = editor.InvokableRun(ctx, `{"command": "undo_edit", "path": "/w/b.txt"}`)
+	assert.NoError(t, err)
+	exists, _ := op.Exists(ctx, "/w/b.txt")
+	assert.False(t, exists)
+	assert.Error(t, op.RemoveFile(ctx, "/w"))
+}
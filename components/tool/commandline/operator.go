@@ -18,6 +18,10 @@ package commandline
 
 import (
 	"context"
+	"io"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
 )
 
 // Operator defines the interface for file operations
@@ -28,3 +32,57 @@ type Operator interface {
 	Exists(ctx context.Context, path string) (bool, error)
 	RunCommand(ctx context.Context, command string) (string, error)
 }
+
+// FileRemover is optionally implemented by an Operator that can remove files, undo_edit needs it to undo
+// the creation of a file by apply_patch
+type FileRemover interface {
+	RemoveFile(ctx context.Context, path string) error
+}
+
+// ProcessStarter is optionally implemented by an Operator that can keep a process running across tool calls
+type ProcessStarter interface {
+	// StartProcess starts command in the background, the process lives until it exits or is closed
+	StartProcess(ctx context.Context, command string) (Process, error)
+}
+
+// Process is a running process, writes go to its stdin, and reads come from its combined stdout and stderr.
+// Close kills the process and releases its resources.
+type Process interface {
+	io.ReadWriteCloser
+}
+
+// CommandExecutor is optionally implemented by an Operator that can report structured command results
+type CommandExecutor interface {
+	// ExecCommand runs command and waits for it, a non-zero exit code or a timeout is reported in the result
+	ExecCommand(ctx context.Context, command string) (*CommandResult, error)
+	// StreamCommand runs command and yields its output line by line, the last chunk carries the result
+	StreamCommand(ctx context.Context, command string) (*schema.StreamReader[*CommandOutput], error)
+}
+
+// CommandResult is the result of a command
+type CommandResult struct {
+	ExitCode int           `json:"exit_code"`
+	Stdout   string        `json:"stdout"`
+	Stderr   string        `json:"stderr"`
+	Duration time.Duration `json:"duration_ns"`
+	// Truncated is true if stdout or stderr exceeded the output limit of the operator and was cut.
+	Truncated bool `json:"truncated,omitempty"`
+	// TimedOut is true if the command was killed because it exceeded the timeout of the operator.
+	TimedOut bool `json:"timed_out,omitempty"`
+}
+
+// OutputStream identifies where a line of output comes from
+type OutputStream string
+
+const (
+	StdoutStream OutputStream = "stdout"
+	StderrStream OutputStream = "stderr"
+)
+
+// CommandOutput is a chunk of a command stream, either a line of output or the final result
+type CommandOutput struct {
+	Stream OutputStream `json:"stream,omitempty"`
+	// Line is the line without the trailing newline.
+	Line   string         `json:"line,omitempty"`
+	Result *CommandResult `json:"result,omitempty"`
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commandline
+
+import "bytes"
+
+// OutputTruncatedMessage is appended by the operators to the output of RunCommand when it exceeds their limit
+const OutputTruncatedMessage = "\n<output truncated>"
+
+// OutputWriter keeps the first limit bytes written to it and discards the rest, a limit of zero or less keeps
+// everything. Complete lines of the kept output are passed to onLine if set.
+// It helps operators implement CommandExecutor.
+type OutputWriter struct {
+	stream    OutputStream
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+	onLine    func(OutputStream, string)
+	partial   []byte
+}
+
+// NewOutputWriter creates an OutputWriter for the output of stream
+func NewOutputWriter(stream OutputStream, limit int, onLine func(OutputStream, string)) *OutputWriter {
+	return &OutputWriter{
+		stream: stream,
+		limit:  limit,
+		onLine: onLine,
+	}
+}
+
+func (w *OutputWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	if remain := w.limit - w.buf.Len(); w.limit > 0 && remain < len(p) {
+		w.truncated = true
+		p = p[:max(remain, 0)]
+	}
+	w.buf.Write(p)
+
+	if w.onLine != nil {
+		w.partial = append(w.partial, p...)
+		for {
+			idx := bytes.IndexByte(w.partial, '\n')
+			if idx < 0 {
+				break
+			}
+			w.onLine(w.stream, string(w.partial[:idx]))
+			w.partial = w.partial[idx+1:]
+		}
+	}
+
+	return n, nil
+}
+
+// Flush passes the last incomplete line to onLine
+func (w *OutputWriter) Flush() {
+	if w.onLine != nil && len(w.partial) > 0 {
+		w.onLine(w.stream, string(w.partial))
+		w.partial = nil
+	}
+}
+
+// String returns the kept output
+func (w *OutputWriter) String() string {
+	return w.buf.String()
+}
+
+// Truncated reports whether some output has been discarded
+func (w *OutputWriter) Truncated() bool {
+	return w.truncated
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commandline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputWriter(t *testing.T) {
+	var lines []string
+	w := NewOutputWriter(StdoutStream, 8, func(stream OutputStream, line string) {
+		assert.Equal(t, StdoutStream, stream)
+		lines = append(lines, line)
+	})
+	n, err := w.Write([]byte("one\ntw"))
+	assert.NoError(t, err)
+	assert.Equal(t, 6, n)
+	n, err = w.Write([]byte("o\nthree\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, 8, n)
+	w.Flush()
+	assert.Equal(t, "one\ntwo\n", w.String())
+	assert.True(t, w.Truncated())
+	assert.Equal(t, []string{"one", "two"}, lines)
+
+	// a zero limit keeps everything
+	w = NewOutputWriter(StderrStream, 0, nil)
+	_, _ = w.Write([]byte("one\ntwo"))
+	w.Flush()
+	assert.Equal(t, "one\ntwo", w.String())
+	assert.False(t, w.Truncated())
+}
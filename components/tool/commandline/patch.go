@@ -0,0 +1,245 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commandline
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// filePatch is the change of one file in a unified diff, a path is empty for /dev/null
+type filePatch struct {
+	oldPath string
+	newPath string
+	hunks   []*hunk
+}
+
+type hunk struct {
+	header   string
+	oldStart int
+	oldLines int
+	newStart int
+	newLines int
+	// lines keep their operation in op and their newline in text, if they have one
+	lines []hunkLine
+}
+
+type hunkLine struct {
+	op   byte
+	text string
+}
+
+var hunkHeaderRegexp = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// parsePatch parses a unified diff, lines outside of file changes such as `diff --git` and `index` are ignored
+func parsePatch(patch string) ([]*filePatch, error) {
+	lines := strings.SplitAfter(patch, "\n")
+
+	var patches []*filePatch
+	for i := 0; i < len(lines); {
+		if !strings.HasPrefix(lines[i], "--- ") {
+			i++
+			continue
+		}
+		if i+1 >= len(lines) || !strings.HasPrefix(lines[i+1], "+++ ") {
+			return nil, fmt.Errorf("invalid patch at line %d: `---` must be followed by `+++`", i+1)
+		}
+
+		fp := &filePatch{
+			oldPath: parsePatchPath(lines[i][4:]),
+			newPath: parsePatchPath(lines[i+1][4:]),
+		}
+		i += 2
+
+		for i < len(lines) && strings.HasPrefix(lines[i], "@@") {
+			h, next, err := parseHunk(lines, i)
+			if err != nil {
+				return nil, err
+			}
+			fp.hunks = append(fp.hunks, h)
+			i = next
+		}
+		if len(fp.hunks) == 0 {
+			return nil, fmt.Errorf("invalid patch at line %d: no hunks found for %s", i+1, fp.newPath)
+		}
+
+		patches = append(patches, fp)
+	}
+
+	if len(patches) == 0 {
+		return nil, errors.New("no file changes found in patch, it should be a unified diff with `---`, `+++` and `@@` lines")
+	}
+	return patches, nil
+}
+
+func parsePatchPath(s string) string {
+	s = strings.TrimRight(s, "\r\n")
+	// drop the timestamp of diff -u
+	if idx := strings.IndexByte(s, '\t'); idx >= 0 {
+		s = s[:idx]
+	}
+	s = strings.TrimSpace(s)
+	if s == "/dev/null" {
+		return ""
+	}
+	if strings.HasPrefix(s, "a/") || strings.HasPrefix(s, "b/") {
+		return s[2:]
+	}
+	return s
+}
+
+func parseHunk(lines []string, i int) (*hunk, int, error) {
+	header := strings.TrimRight(lines[i], "\r\n")
+	m := hunkHeaderRegexp.FindStringSubmatch(header)
+	if m == nil {
+		return nil, 0, fmt.Errorf("invalid hunk header at line %d: %s", i+1, header)
+	}
+
+	count := func(s string) int {
+		if s == "" {
+			return 1
+		}
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+	h := &hunk{header: header}
+	h.oldStart, _ = strconv.Atoi(m[1])
+	h.oldLines = count(m[2])
+	h.newStart, _ = strconv.Atoi(m[3])
+	h.newLines = count(m[4])
+	i++
+
+	oldSeen, newSeen := 0, 0
+	for oldSeen < h.oldLines || newSeen < h.newLines {
+		if i >= len(lines) || lines[i] == "" {
+			return nil, 0, fmt.Errorf("invalid patch: hunk `%s` ends early, expected %d old and %d new lines", header, h.oldLines, h.newLines)
+		}
+
+		line := lines[i]
+		if !strings.HasSuffix(line, "\n") {
+			line += "\n"
+		}
+		switch line[0] {
+		case ' ':
+			oldSeen++
+			newSeen++
+			h.lines = append(h.lines, hunkLine{op: ' ', text: line[1:]})
+		case '\n', '\r':
+			// context lines whose trailing space was stripped
+			oldSeen++
+			newSeen++
+			h.lines = append(h.lines, hunkLine{op: ' ', text: line})
+		case '-':
+			oldSeen++
+			h.lines = append(h.lines, hunkLine{op: '-', text: line[1:]})
+		case '+':
+			newSeen++
+			h.lines = append(h.lines, hunkLine{op: '+', text: line[1:]})
+		case '\\':
+			h.stripLastNewline()
+		default:
+			return nil, 0, fmt.Errorf("invalid patch at line %d: unexpected line in hunk `%s`: %s", i+1, header, strings.TrimRight(line, "\r\n"))
+		}
+		i++
+	}
+
+	// `\ No newline at end of file` of the last line
+	if i < len(lines) && strings.HasPrefix(lines[i], "\\") {
+		h.stripLastNewline()
+		i++
+	}
+
+	return h, i, nil
+}
+
+func (h *hunk) stripLastNewline() {
+	if n := len(h.lines); n > 0 {
+		h.lines[n-1].text = strings.TrimSuffix(h.lines[n-1].text, "\n")
+	}
+}
+
+func (h *hunk) side(skip byte) []string {
+	var lines []string
+	for _, l := range h.lines {
+		if l.op != skip {
+			lines = append(lines, l.text)
+		}
+	}
+	return lines
+}
+
+// applyHunks applies the hunks to content in order. A hunk is applied where its old lines match exactly,
+// preferring the position in its header, so that line numbers which are a little off are tolerated.
+func applyHunks(content string, hunks []*hunk) (string, error) {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	var out []string
+	pos := 0
+	for idx, h := range hunks {
+		oldSide, newSide := h.side('+'), h.side('-')
+
+		want := h.oldStart - 1
+		if h.oldLines == 0 {
+			// pure insertions are placed after line oldStart
+			want = h.oldStart
+		}
+		at := findLines(lines, oldSide, want, pos)
+		if at < 0 {
+			return "", fmt.Errorf("hunk #%d `%s` does not match the file content", idx+1, h.header)
+		}
+
+		out = append(out, lines[pos:at]...)
+		out = append(out, newSide...)
+		pos = at + len(oldSide)
+	}
+	out = append(out, lines[pos:]...)
+
+	return strings.Join(out, ""), nil
+}
+
+// findLines returns the position of target in lines at or after from that is closest to want, or -1
+func findLines(lines, target []string, want, from int) int {
+	last := len(lines) - len(target)
+	if last < from {
+		return -1
+	}
+	want = min(max(want, from), last)
+
+	matches := func(at int) bool {
+		for i, t := range target {
+			if lines[at+i] != t {
+				return false
+			}
+		}
+		return true
+	}
+	for d := 0; want-d >= from || want+d <= last; d++ {
+		if at := want - d; at >= from && matches(at) {
+			return at
+		}
+		if at := want + d; d > 0 && at <= last && matches(at) {
+			return at
+		}
+	}
+	return -1
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commandline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePatch(t *testing.T) {
+	patches, err := parsePatch(`diff --git a/src/main.go b/src/main.go
+index 83db48f..bf269f4 100644
+--- a/src/main.go
++++ b/src/main.go
+@@ -1,3 +1,3 @@ package main
+ line 1
+-line 2
++line two
+ line 3
+--- /dev/null	2025-01-01 00:00:00
++++ b/new.txt	2025-01-01 00:00:00
+@@ -0,0 +1 @@
++hello
+\ No newline at end of file
+`)
+	assert.NoError(t, err)
+	if assert.Len(t, patches, 2) {
+		assert.Equal(t, "src/main.go", patches[0].oldPath)
+		assert.Equal(t, "src/main.go", patches[0].newPath)
+		if assert.Len(t, patches[0].hunks, 1) {
+			h := patches[0].hunks[0]
+			assert.Equal(t, []int{1, 3, 1, 3}, []int{h.oldStart, h.oldLines, h.newStart, h.newLines})
+			assert.Equal(t, []string{"line 1\n", "line 2\n", "line 3\n"}, h.side('+'))
+			assert.Equal(t, []string{"line 1\n", "line two\n", "line 3\n"}, h.side('-'))
+		}
+		assert.Equal(t, "", patches[1].oldPath)
+		assert.Equal(t, "new.txt", patches[1].newPath)
+		assert.Equal(t, []string{"hello"}, patches[1].hunks[0].side('-'))
+	}
+
+	for _, patch := range []string{
+		"just text",
+		"--- a/x\n@@ -1 +1 @@\n",
+		"--- a/x\n+++ b/x\n",
+		"--- a/x\n+++ b/x\n@@ bad @@\n",
+		"--- a/x\n+++ b/x\n@@ -1,2 +1,2 @@\n-a\n+b\n",
+		"--- a/x\n+++ b/x\n@@ -1 +1 @@\n*a\n",
+	} {
+		_, err = parsePatch(patch)
+		assert.Error(t, err, patch)
+	}
+}
+
+func TestApplyHunks(t *testing.T) {
+	apply := func(content, patch string) (string, error) {
+		patches, err := parsePatch(patch)
+		if err != nil {
+			return "", err
+		}
+		return applyHunks(content, patches[0].hunks)
+	}
+
+	content := "a\nb\nc\nd\ne\nf\n"
+
+	// line numbers off by two
+	out, err := apply(content, "--- x\n+++ x\n@@ -1,2 +1,3 @@\n d\n+d2\n e\n@@ -6 +7 @@\n-f\n+F\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "a\nb\nc\nd\nd2\ne\nF\n", out)
+
+	// pure insertion after a line, and at the start
+	out, err = apply(content, "--- x\n+++ x\n@@ -0,0 +1 @@\n+start\n@@ -2,0 +4 @@\n+after b\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "start\na\nb\nafter b\nc\nd\ne\nf\n", out)
+
+	// context lines with stripped trailing space
+	out, err = apply("x\n\ny\n", "--- x\n+++ x\n@@ -1,3 +1,3 @@\n x\n\n-y\n+z\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "x\n\nz\n", out)
+
+	// missing newline at end of file on both sides
+	out, err = apply("a\nb", "--- x\n+++ x\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+c\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "a\nc\n", out)
+
+	// hunks apply in order
+	_, err = apply(content, "--- x\n+++ x\n@@ -4 +4 @@\n-d\n+D\n@@ -1 +1 @@\n-a\n+A\n")
+	assert.ErrorContains(t, err, "hunk #2")
+
+	_, err = apply(content, "--- x\n+++ x\n@@ -1 +1 @@\n-z\n+Z\n")
+	assert.ErrorContains(t, err, "does not match")
+}
@@ -0,0 +1,409 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commandline
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultPySessionID          = "default"
+	defaultPySessionIdleTimeout = 10 * time.Minute
+	defaultPySessionExecTimeout = time.Minute
+	pySessionScriptName         = ".eino_py_session.py"
+)
+
+type PySessionExecutorConfig struct {
+	Command string `json:"command"`
+	// Operator runs the interpreter, it must implement ProcessStarter.
+	Operator Operator
+	// IdleTimeout closes a session after it has not been used for this long. Default is 10 minutes.
+	IdleTimeout time.Duration
+	// ExecTimeout limits the execution time of each call, the session is reset when it is exceeded. Default is 1 minute.
+	ExecTimeout time.Duration
+}
+
+// NewPySessionExecutor creates a Python executor that keeps interpreters alive across calls,
+// so that variables, imports and loaded data are kept in the session.
+func NewPySessionExecutor(_ context.Context, cfg *PySessionExecutorConfig) (*PySessionExecutor, error) {
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	if cfg.Operator == nil {
+		return nil, errors.New("operator is required")
+	}
+	starter, ok := cfg.Operator.(ProcessStarter)
+	if !ok {
+		return nil, errors.New("operator must implement ProcessStarter")
+	}
+	command := cfg.Command
+	if len(command) == 0 {
+		command = defaultPythonCommand
+	}
+	idleTimeout := cfg.IdleTimeout
+	if idleTimeout == 0 {
+		idleTimeout = defaultPySessionIdleTimeout
+	}
+	execTimeout := cfg.ExecTimeout
+	if execTimeout == 0 {
+		execTimeout = defaultPySessionExecTimeout
+	}
+
+	return &PySessionExecutor{
+		info: &schema.ToolInfo{
+			Name: "python_session_execute",
+			Desc: "Executes Python code in a persistent session, variables, imports and loaded data are kept between calls. " +
+				"Returns stdout, stderr, the value of the last expression, and images saved or plotted by the code. " +
+				"Set reset to true to start over with a fresh interpreter.",
+			ParamsOneOf: schema.NewParamsOneOfByOpenAPIV3(&openapi3.Schema{
+				Type: openapi3.TypeObject,
+				Properties: map[string]*openapi3.SchemaRef{
+					"code": {
+						Value: &openapi3.Schema{
+							Type:        openapi3.TypeString,
+							Description: "The Python code to execute.",
+						},
+					},
+					"session_id": {
+						Value: &openapi3.Schema{
+							Type:        openapi3.TypeString,
+							Description: "Optional id of the session to execute in, sessions don't share state.",
+						},
+					},
+					"reset": {
+						Value: &openapi3.Schema{
+							Type:        openapi3.TypeBoolean,
+							Description: "Restart the session before executing code, code can be omitted to only reset.",
+						},
+					},
+				},
+			}),
+		},
+		command:     command,
+		operator:    cfg.Operator,
+		starter:     starter,
+		idleTimeout: idleTimeout,
+		execTimeout: execTimeout,
+		sessions:    make(map[string]*pySession),
+	}, nil
+}
+
+type PySessionExecutor struct {
+	info        *schema.ToolInfo
+	command     string
+	operator    Operator
+	starter     ProcessStarter
+	idleTimeout time.Duration
+	execTimeout time.Duration
+
+	mu       sync.Mutex
+	sessions map[string]*pySession
+}
+
+type PySessionInput struct {
+	Code      string `json:"code"`
+	SessionID string `json:"session_id,omitempty"`
+	Reset     bool   `json:"reset,omitempty"`
+}
+
+// PySessionResult is the result of executing code in a session
+type PySessionResult struct {
+	// Value is the repr of the last expression, if it is not None.
+	Value  string `json:"value,omitempty"`
+	Stdout string `json:"stdout,omitempty"`
+	Stderr string `json:"stderr,omitempty"`
+	// Error is the traceback of the exception raised by the code.
+	Error string `json:"error,omitempty"`
+	// Images are image files created or modified by the code, as data urls.
+	Images []schema.ChatMessagePart `json:"images,omitempty"`
+}
+
+func (p *PySessionExecutor) Info(_ context.Context) (*schema.ToolInfo, error) {
+	return p.info, nil
+}
+
+func (p *PySessionExecutor) InvokableRun(ctx context.Context, argumentsInJSON string, _ ...tool.Option) (string, error) {
+	args := &PySessionInput{}
+	if err := json.Unmarshal([]byte(argumentsInJSON), args); err != nil {
+		return "", fmt.Errorf("extract argument fail: %w", err)
+	}
+	if args.Reset && len(args.Code) == 0 {
+		if err := p.Reset(ctx, args.SessionID); err != nil {
+			return "", err
+		}
+		return "session has been reset", nil
+	}
+
+	result, err := p.Execute(ctx, args)
+	if err != nil {
+		return "", fmt.Errorf("execute error: %w", err)
+	}
+
+	b, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("marshal result fail: %w", err)
+	}
+	return string(b), nil
+}
+
+// Execute runs code in the session, starting the session if needed
+func (p *PySessionExecutor) Execute(ctx context.Context, args *PySessionInput) (*PySessionResult, error) {
+	if args.Reset {
+		if err := p.Reset(ctx, args.SessionID); err != nil {
+			return nil, err
+		}
+	}
+
+	s, err := p.getSession(ctx, args.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.run(ctx, args.Code, p.execTimeout)
+	if err != nil {
+		// the interpreter is in an unknown state
+		p.closeSession(s)
+		return nil, err
+	}
+
+	result := &PySessionResult{
+		Value:  resp.Value,
+		Stdout: resp.Stdout,
+		Stderr: resp.Stderr,
+		Error:  resp.Error,
+	}
+	for _, image := range resp.Images {
+		part, err := p.readImage(ctx, image)
+		if err != nil {
+			result.Stderr += fmt.Sprintf("failed to read image %s: %v\n", image, err)
+			continue
+		}
+		result.Images = append(result.Images, part)
+	}
+
+	return result, nil
+}
+
+// Reset closes the session, the next execution starts a fresh interpreter
+func (p *PySessionExecutor) Reset(_ context.Context, sessionID string) error {
+	if sessionID == "" {
+		sessionID = defaultPySessionID
+	}
+
+	p.mu.Lock()
+	s := p.sessions[sessionID]
+	p.mu.Unlock()
+
+	if s != nil {
+		p.closeSession(s)
+	}
+	return nil
+}
+
+// Close closes all sessions
+func (p *PySessionExecutor) Close() error {
+	p.mu.Lock()
+	sessions := make([]*pySession, 0, len(p.sessions))
+	for _, s := range p.sessions {
+		sessions = append(sessions, s)
+	}
+	p.mu.Unlock()
+
+	for _, s := range sessions {
+		p.closeSession(s)
+	}
+	return nil
+}
+
+func (p *PySessionExecutor) getSession(ctx context.Context, sessionID string) (*pySession, error) {
+	if sessionID == "" {
+		sessionID = defaultPySessionID
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if s, ok := p.sessions[sessionID]; ok {
+		return s, nil
+	}
+
+	if err := p.operator.WriteFile(ctx, pySessionScriptName, pySessionScript); err != nil {
+		return nil, fmt.Errorf("failed to create session script: %w", err)
+	}
+
+	marker := "EINO_PY_SESSION_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	proc, err := p.starter.StartProcess(ctx, fmt.Sprintf("%s -u %s %s", p.command, pySessionScriptName, marker))
+	if err != nil {
+		return nil, fmt.Errorf("failed to start python session: %w", err)
+	}
+
+	s := &pySession{
+		id:          sessionID,
+		proc:        proc,
+		reader:      bufio.NewReader(proc),
+		marker:      marker,
+		idleTimeout: p.idleTimeout,
+	}
+	s.idle = time.AfterFunc(p.idleTimeout, func() {
+		p.closeSession(s)
+	})
+	p.sessions[sessionID] = s
+
+	return s, nil
+}
+
+func (p *PySessionExecutor) closeSession(s *pySession) {
+	p.mu.Lock()
+	if p.sessions[s.id] == s {
+		delete(p.sessions, s.id)
+	}
+	p.mu.Unlock()
+
+	s.idle.Stop()
+	_ = s.proc.Close()
+}
+
+func (p *PySessionExecutor) readImage(ctx context.Context, path string) (schema.ChatMessagePart, error) {
+	content, err := p.operator.ReadFile(ctx, path)
+	if err != nil {
+		return schema.ChatMessagePart{}, err
+	}
+
+	mimeType := mime.TypeByExtension(strings.ToLower(filepath.Ext(path)))
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+
+	return schema.ChatMessagePart{
+		Type: schema.ChatMessagePartTypeImageURL,
+		ImageURL: &schema.ChatMessageImageURL{
+			URL:      fmt.Sprintf("data:%s;base64,%s", mimeType, base64.StdEncoding.EncodeToString([]byte(content))),
+			MIMEType: mimeType,
+			Extra:    map[string]any{"path": path},
+		},
+	}, nil
+}
+
+type pySession struct {
+	id     string
+	proc   Process
+	reader *bufio.Reader
+	marker string
+
+	idle        *time.Timer
+	idleTimeout time.Duration
+
+	mu  sync.Mutex
+	seq int
+}
+
+type pySessionRequest struct {
+	ID   int    `json:"id"`
+	Code string `json:"code"`
+}
+
+type pySessionResponse struct {
+	ID     int      `json:"id"`
+	Value  string   `json:"value"`
+	Stdout string   `json:"stdout"`
+	Stderr string   `json:"stderr"`
+	Error  string   `json:"error"`
+	Images []string `json:"images"`
+}
+
+func (s *pySession) run(ctx context.Context, code string, timeout time.Duration) (*pySessionResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// don't expire while running, the timer is restarted once done
+	if !s.idle.Stop() {
+		return nil, errors.New("python session has expired")
+	}
+	defer s.idle.Reset(s.idleTimeout)
+
+	s.seq++
+	req, err := json.Marshal(&pySessionRequest{ID: s.seq, Code: code})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = s.proc.Write(append(req, '\n')); err != nil {
+		return nil, fmt.Errorf("failed to send code to python session: %w", err)
+	}
+
+	type readResult struct {
+		resp *pySessionResponse
+		err  error
+	}
+	ch := make(chan readResult, 1)
+	go func(id int) {
+		resp, err := s.read(id)
+		ch <- readResult{resp, err}
+	}(s.seq)
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case r := <-ch:
+		return r.resp, r.err
+	case <-timer.C:
+		return nil, fmt.Errorf("execution timed out after %v, the session has been reset", timeout)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// read reads until the response of request id, output written around the protocol, e.g. by subprocesses, is kept in stdout
+func (s *pySession) read(id int) (*pySessionResponse, error) {
+	var stray strings.Builder
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("python session exited: %s", stray.String()+line)
+		}
+
+		if !strings.HasPrefix(line, s.marker) {
+			stray.WriteString(line)
+			continue
+		}
+
+		resp := &pySessionResponse{}
+		if err = json.Unmarshal([]byte(strings.TrimPrefix(line, s.marker)), resp); err != nil {
+			return nil, fmt.Errorf("failed to decode python session response: %w", err)
+		}
+		if resp.ID != id {
+			continue
+		}
+		resp.Stdout = stray.String() + resp.Stdout
+		return resp, nil
+	}
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commandline
+
+// pySessionScript is the interpreter side of the session protocol. It reads one JSON request per line from stdin,
+// and writes one JSON response per request to stdout, prefixed with the marker passed as the first argument.
+const pySessionScript = `import ast
+import contextlib
+import io
+import json
+import os
+import sys
+import time
+import traceback
+
+os.environ.setdefault("MPLBACKEND", "Agg")
+
+MARKER = sys.argv[1]
+IMAGE_EXTS = (".png", ".jpg", ".jpeg", ".gif", ".svg", ".webp")
+MAX_DEPTH = 2
+
+requests = sys.stdin
+out = sys.stdout
+namespace = {"__name__": "__main__"}
+
+
+def snapshot():
+    files = {}
+    root = os.getcwd()
+    for dirpath, dirnames, filenames in os.walk(root):
+        if dirpath[len(root):].count(os.sep) >= MAX_DEPTH:
+            dirnames[:] = []
+        dirnames[:] = [d for d in dirnames if not d.startswith(".")]
+        for name in filenames:
+            if name.lower().endswith(IMAGE_EXTS):
+                path = os.path.join(dirpath, name)
+                try:
+                    files[path] = os.stat(path).st_mtime_ns
+                except OSError:
+                    pass
+    return files
+
+
+def save_figures():
+    plt = sys.modules.get("matplotlib.pyplot")
+    if plt is None:
+        return
+    for num in plt.get_fignums():
+        plt.figure(num).savefig("figure_%d_%d.png" % (time.time_ns(), num))
+    plt.close("all")
+
+
+def run(code):
+    stdout, stderr = io.StringIO(), io.StringIO()
+    result = {"value": "", "error": ""}
+    before = snapshot()
+    sys.stdin = io.StringIO()
+    with contextlib.redirect_stdout(stdout), contextlib.redirect_stderr(stderr):
+        try:
+            tree = ast.parse(code, "<session>", "exec")
+            last = None
+            if tree.body and isinstance(tree.body[-1], ast.Expr):
+                last = ast.Expression(tree.body.pop().value)
+            exec(compile(tree, "<session>", "exec"), namespace)
+            if last is not None:
+                value = eval(compile(last, "<session>", "eval"), namespace)
+                if value is not None:
+                    namespace["_"] = value
+                    result["value"] = repr(value)
+            save_figures()
+        except SyntaxError as e:
+            result["error"] = "".join(traceback.format_exception_only(type(e), e))
+        except BaseException as e:
+            result["error"] = "".join(traceback.format_exception(type(e), e, e.__traceback__.tb_next))
+    sys.stdin = requests
+    after = snapshot()
+    result["stdout"] = stdout.getvalue()
+    result["stderr"] = stderr.getvalue()
+    result["images"] = sorted(os.path.relpath(p) for p, m in after.items() if before.get(p) != m)
+    return result
+
+
+for line in requests:
+    try:
+        request = json.loads(line)
+    except ValueError:
+        continue
+    response = run(request.get("code", ""))
+    response["id"] = request.get("id")
+    out.write(MARKER + json.dumps(response) + "\n")
+    out.flush()
+`
@@ -0,0 +1,216 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commandline
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// processOperator runs processes in a temporary directory
+type processOperator struct {
+	dir string
+}
+
+func (o *processOperator) path(p string) string {
+	if filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(o.dir, p)
+}
+
+func (o *processOperator) ReadFile(ctx context.Context, path string) (string, error) {
+	b, err := os.ReadFile(o.path(path))
+	return string(b), err
+}
+
+func (o *processOperator) WriteFile(ctx context.Context, path string, content string) error {
+	return os.WriteFile(o.path(path), []byte(content), 0644)
+}
+
+func (o *processOperator) IsDirectory(ctx context.Context, path string) (bool, error) {
+	panic("implement me")
+}
+
+func (o *processOperator) Exists(ctx context.Context, path string) (bool, error) {
+	panic("implement me")
+}
+
+func (o *processOperator) RunCommand(ctx context.Context, command string) (string, error) {
+	panic("implement me")
+}
+
+func (o *processOperator) StartProcess(ctx context.Context, command string) (Process, error) {
+	cmd := exec.Command("/bin/sh", "-c", command)
+	cmd.Dir = o.dir
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	pr, pw := io.Pipe()
+	cmd.Stdout, cmd.Stderr = pw, pw
+	if err = cmd.Start(); err != nil {
+		return nil, err
+	}
+	go func() { _ = pw.CloseWithError(cmd.Wait()) }()
+	return &testProcess{Reader: pr, WriteCloser: stdin, cmd: cmd}, nil
+}
+
+type testProcess struct {
+	io.Reader
+	io.WriteCloser
+	cmd *exec.Cmd
+}
+
+func (p *testProcess) Close() error {
+	_ = p.WriteCloser.Close()
+	return p.cmd.Process.Kill()
+}
+
+func newTestPySessionExecutor(t *testing.T, cfg *PySessionExecutorConfig) *PySessionExecutor {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not found")
+	}
+	cfg.Operator = &processOperator{dir: t.TempDir()}
+	executor, err := NewPySessionExecutor(context.Background(), cfg)
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = executor.Close() })
+	return executor
+}
+
+func TestNewPySessionExecutor(t *testing.T) {
+	ctx := context.Background()
+	_, err := NewPySessionExecutor(ctx, nil)
+	assert.Error(t, err)
+	_, err = NewPySessionExecutor(ctx, &PySessionExecutorConfig{Operator: &pyOperator{}})
+	assert.ErrorContains(t, err, "ProcessStarter")
+
+	executor, err := NewPySessionExecutor(ctx, &PySessionExecutorConfig{Operator: &processOperator{}})
+	assert.NoError(t, err)
+	assert.Equal(t, defaultPythonCommand, executor.command)
+	assert.Equal(t, defaultPySessionIdleTimeout, executor.idleTimeout)
+	assert.Equal(t, defaultPySessionExecTimeout, executor.execTimeout)
+}
+
+func TestPySessionExecutor(t *testing.T) {
+	ctx := context.Background()
+	executor := newTestPySessionExecutor(t, &PySessionExecutorConfig{})
+
+	result, err := executor.Execute(ctx, &PySessionInput{Code: "import sys\nx = 40\nprint('hi')\nprint('oops', file=sys.stderr)"})
+	assert.NoError(t, err)
+	assert.Equal(t, &PySessionResult{Stdout: "hi\n", Stderr: "oops\n"}, result)
+
+	result, err = executor.Execute(ctx, &PySessionInput{Code: "x += 2\n{'x': x}"})
+	assert.NoError(t, err)
+	assert.Equal(t, "{'x': 42}", result.Value)
+
+	result, err = executor.Execute(ctx, &PySessionInput{Code: "x = None\nx"})
+	assert.NoError(t, err)
+	assert.Empty(t, result.Value)
+
+	result, err = executor.Execute(ctx, &PySessionInput{Code: "print('before')\n1 / 0"})
+	assert.NoError(t, err)
+	assert.Equal(t, "before\n", result.Stdout)
+	assert.Contains(t, result.Error, "ZeroDivisionError")
+	assert.NotContains(t, result.Error, pySessionScriptName)
+
+	result, err = executor.Execute(ctx, &PySessionInput{Code: "def f(:"})
+	assert.NoError(t, err)
+	assert.Contains(t, result.Error, "SyntaxError")
+
+	// sessions don't share state
+	result, err = executor.Execute(ctx, &PySessionInput{Code: "x", SessionID: "other"})
+	assert.NoError(t, err)
+	assert.Contains(t, result.Error, "NameError")
+
+	// subprocess output bypasses the redirection, but is still returned
+	result, err = executor.Execute(ctx, &PySessionInput{Code: "import os\nos.system('echo from shell')"})
+	assert.NoError(t, err)
+	assert.Equal(t, "from shell\n", result.Stdout)
+	assert.Equal(t, "0", result.Value)
+}
+
+func TestPySessionExecutor_Images(t *testing.T) {
+	ctx := context.Background()
+	executor := newTestPySessionExecutor(t, &PySessionExecutorConfig{})
+
+	result, err := executor.Execute(ctx, &PySessionInput{Code: "import os\nos.makedirs('out', exist_ok=True)\n" +
+		"open('out/plot.png', 'wb').write(b'\\x89PNG')\nopen('data.txt', 'w').write('x')"})
+	assert.NoError(t, err)
+	if assert.Len(t, result.Images, 1) {
+		assert.Equal(t, "data:image/png;base64,iVBORw==", result.Images[0].ImageURL.URL)
+		assert.Equal(t, "image/png", result.Images[0].ImageURL.MIMEType)
+		assert.Equal(t, filepath.Join("out", "plot.png"), result.Images[0].ImageURL.Extra["path"])
+	}
+
+	// unchanged images are not returned again
+	result, err = executor.Execute(ctx, &PySessionInput{Code: "1"})
+	assert.NoError(t, err)
+	assert.Empty(t, result.Images)
+}
+
+func TestPySessionExecutor_Reset(t *testing.T) {
+	ctx := context.Background()
+	executor := newTestPySessionExecutor(t, &PySessionExecutorConfig{IdleTimeout: 200 * time.Millisecond, ExecTimeout: time.Second})
+
+	out, err := executor.InvokableRun(ctx, `{"code": "x = 1"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, `{}`, out)
+
+	out, err = executor.InvokableRun(ctx, `{"reset": true}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "session has been reset", out)
+
+	out, err = executor.InvokableRun(ctx, `{"code": "x"}`)
+	assert.NoError(t, err)
+	result := &PySessionResult{}
+	assert.NoError(t, json.Unmarshal([]byte(out), result))
+	assert.Contains(t, result.Error, "NameError")
+
+	// expires when idle
+	_, err = executor.Execute(ctx, &PySessionInput{Code: "y = 1"})
+	assert.NoError(t, err)
+	time.Sleep(500 * time.Millisecond)
+	executor.mu.Lock()
+	assert.Empty(t, executor.sessions)
+	executor.mu.Unlock()
+	result, err = executor.Execute(ctx, &PySessionInput{Code: "y"})
+	assert.NoError(t, err)
+	assert.Contains(t, result.Error, "NameError")
+
+	// times out, and starts over
+	_, err = executor.Execute(ctx, &PySessionInput{Code: "z = 1\nimport time\ntime.sleep(10)"})
+	assert.ErrorContains(t, err, "timed out")
+	result, err = executor.Execute(ctx, &PySessionInput{Code: "'z' in globals()"})
+	assert.NoError(t, err)
+	assert.Equal(t, "False", result.Value)
+
+	// reset together with code
+	_, err = executor.Execute(ctx, &PySessionInput{Code: "w = 1"})
+	assert.NoError(t, err)
+	result, err = executor.Execute(ctx, &PySessionInput{Code: "'w' in globals()", Reset: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "False", result.Value)
+}
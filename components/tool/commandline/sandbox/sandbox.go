@@ -32,6 +32,10 @@ import (
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/google/uuid"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/tool/commandline"
 )
 
 // Config configures the sandbox environment
@@ -45,6 +49,7 @@ type Config struct {
 	CPULimit       float64 // CPU limit in cores
 	NetworkEnabled bool
 	Timeout        time.Duration // Command execution timeout in seconds
+	MaxOutputBytes int           // Limit of stdout and stderr of each command, the rest is dropped
 }
 
 // DockerSandbox provides a containerized execution environment
@@ -61,6 +66,8 @@ const (
 	defaultCPULimit    = 1.0
 	defaultTimeout     = time.Second * 30
 	defaultHostName    = "sandbox"
+	defaultMaxOutput   = 1 << 20 // 1M
+	streamBufferSize   = 64
 )
 
 // NewDockerSandbox creates a new Docker sandbox with the given configuration
@@ -87,6 +94,9 @@ func NewDockerSandbox(ctx context.Context, config *Config) (*DockerSandbox, erro
 	if config.CPULimit == 0 {
 		config.CPULimit = defaultCPULimit
 	}
+	if config.MaxOutputBytes == 0 {
+		config.MaxOutputBytes = defaultMaxOutput
+	}
 	if config.VolumeBindings == nil {
 		config.VolumeBindings = make(map[string]string)
 	}
@@ -165,14 +175,70 @@ func (s *DockerSandbox) Create(ctx context.Context) error {
 
 // RunCommand executes a command in the sandbox
 func (s *DockerSandbox) RunCommand(ctx context.Context, cmd string) (string, error) {
+	result, err := s.ExecCommand(ctx, cmd)
+	if err != nil {
+		return "", err
+	}
+
+	if result.TimedOut {
+		return "", fmt.Errorf("command execution timedout after %v", s.config.Timeout)
+	}
+	if result.ExitCode != 0 {
+		return "", fmt.Errorf("command execution failed with exit code %d: %s",
+			result.ExitCode, result.Stderr)
+	}
+
+	if result.Truncated && len(result.Stdout) >= s.config.MaxOutputBytes {
+		return result.Stdout + commandline.OutputTruncatedMessage, nil
+	}
+	return result.Stdout, nil
+}
+
+// ExecCommand executes a command in the sandbox, and returns its exit code, output and duration.
+// Unlike RunCommand, a non-zero exit code or a timeout is reported in the result instead of an error.
+func (s *DockerSandbox) ExecCommand(ctx context.Context, cmd string) (*commandline.CommandResult, error) {
+	return s.exec(ctx, cmd, nil)
+}
+
+// StreamCommand executes a command in the sandbox, and yields its output line by line as it arrives.
+// The last chunk carries the result, closing the stream early stops waiting for the command.
+func (s *DockerSandbox) StreamCommand(ctx context.Context, cmd string) (*schema.StreamReader[*commandline.CommandOutput], error) {
 	if s.containerID == "" {
-		return "", fmt.Errorf("sandbox not initialized")
+		return nil, fmt.Errorf("sandbox not initialized")
+	}
+
+	sr, sw := schema.Pipe[*commandline.CommandOutput](streamBufferSize)
+
+	go func() {
+		defer sw.Close()
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		result, err := s.exec(ctx, cmd, func(stream commandline.OutputStream, line string) {
+			if closed := sw.Send(&commandline.CommandOutput{Stream: stream, Line: line}, nil); closed {
+				cancel()
+			}
+		})
+		if err != nil {
+			sw.Send(nil, err)
+			return
+		}
+		sw.Send(&commandline.CommandOutput{Result: result}, nil)
+	}()
+
+	return sr, nil
+}
+
+func (s *DockerSandbox) exec(ctx context.Context, cmd string, onLine func(commandline.OutputStream, string)) (*commandline.CommandResult, error) {
+	if s.containerID == "" {
+		return nil, fmt.Errorf("sandbox not initialized")
 	}
 
 	timeout := s.config.Timeout
 
 	// Create execution context
-	ctx, cancel := context.WithTimeout(ctx, timeout)
+	execCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	// Create execution config
@@ -183,53 +249,74 @@ func (s *DockerSandbox) RunCommand(ctx context.Context, cmd string) (string, err
 		WorkingDir:   s.config.WorkDir,
 	}
 
+	start := time.Now()
+
 	// Create execution instance
-	execID, err := s.client.ContainerExecCreate(ctx, s.containerID, execConfig)
+	execID, err := s.client.ContainerExecCreate(execCtx, s.containerID, execConfig)
 	if err != nil {
-		return "", fmt.Errorf("failed to create exec instance: %w", err)
+		return nil, fmt.Errorf("failed to create exec instance: %w", err)
 	}
 
 	// Attach to execution instance
-	resp, err := s.client.ContainerExecAttach(ctx, execID.ID, container.ExecStartOptions{
+	resp, err := s.client.ContainerExecAttach(execCtx, execID.ID, container.ExecStartOptions{
 		Detach:      false,
 		Tty:         false,
 		ConsoleSize: nil,
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to attach to exec instance: %w", err)
+		return nil, fmt.Errorf("failed to attach to exec instance: %w", err)
 	}
 	defer resp.Close()
 
 	// Read output
-	var outBuf, errBuf bytes.Buffer
-	outputDone := make(chan error)
+	stdout := commandline.NewOutputWriter(commandline.StdoutStream, s.config.MaxOutputBytes, onLine)
+	stderr := commandline.NewOutputWriter(commandline.StderrStream, s.config.MaxOutputBytes, onLine)
+	outputDone := make(chan error, 1)
 
 	go func() {
-		_, err := stdcopy.StdCopy(&outBuf, &errBuf, resp.Reader)
+		_, err := stdcopy.StdCopy(stdout, stderr, resp.Reader)
 		outputDone <- err
 	}()
 
 	select {
 	case err := <-outputDone:
 		if err != nil {
-			return "", fmt.Errorf("failed to read command output: %w", err)
+			return nil, fmt.Errorf("failed to read command output: %w", err)
 		}
-	case <-ctx.Done():
-		return "", fmt.Errorf("command execution timedout after %v", timeout)
-	}
+	case <-execCtx.Done():
+		// Stop reading, and wait for the reader to release the buffers
+		resp.Close()
+		<-outputDone
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		stdout.Flush()
+		stderr.Flush()
+		return &commandline.CommandResult{
+			ExitCode:  -1,
+			Stdout:    stdout.String(),
+			Stderr:    stderr.String(),
+			Duration:  time.Since(start),
+			Truncated: stdout.Truncated() || stderr.Truncated(),
+			TimedOut:  true,
+		}, nil
+	}
+	stdout.Flush()
+	stderr.Flush()
 
 	// Check execution status
-	inspectResp, err := s.client.ContainerExecInspect(ctx, execID.ID)
+	inspectResp, err := s.client.ContainerExecInspect(execCtx, execID.ID)
 	if err != nil {
-		return "", fmt.Errorf("failed to inspect exec status: %w", err)
+		return nil, fmt.Errorf("failed to inspect exec status: %w", err)
 	}
 
-	if inspectResp.ExitCode != 0 {
-		return "", fmt.Errorf("command execution failed with exit code %d: %s",
-			inspectResp.ExitCode, errBuf.String())
-	}
-
-	return outBuf.String(), nil
+	return &commandline.CommandResult{
+		ExitCode:  inspectResp.ExitCode,
+		Stdout:    stdout.String(),
+		Stderr:    stderr.String(),
+		Duration:  time.Since(start),
+		Truncated: stdout.Truncated() || stderr.Truncated(),
+	}, nil
 }
 
 // ReadFile reads a file from the container
@@ -321,6 +408,25 @@ func (s *DockerSandbox) WriteFile(ctx context.Context, path string, content stri
 	return nil
 }
 
+// RemoveFile removes a file in the container
+func (s *DockerSandbox) RemoveFile(ctx context.Context, path string) error {
+	if s.containerID == "" {
+		return fmt.Errorf("sandbox not initialized")
+	}
+
+	// Resolve path
+	resolvedPath, err := s.safeResolvePath(path)
+	if err != nil {
+		return err
+	}
+
+	if _, err = s.RunCommand(ctx, fmt.Sprintf("rm -f %s", resolvedPath)); err != nil {
+		return fmt.Errorf("failed to remove file: %w", err)
+	}
+
+	return nil
+}
+
 // IsDirectory checks if a path in the container is a directory
 func (s *DockerSandbox) IsDirectory(ctx context.Context, path string) (bool, error) {
 	if s.containerID == "" {
@@ -34,6 +34,8 @@ import (
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/components/tool/commandline"
 )
 
 func TestNewDockerSandbox(t *testing.T) {
@@ -48,6 +50,7 @@ func TestNewDockerSandbox(t *testing.T) {
 	assert.Equal(t, 1.0, sandbox.config.CPULimit)
 	assert.False(t, sandbox.config.NetworkEnabled)
 	assert.Equal(t, time.Second*30, sandbox.config.Timeout)
+	assert.Equal(t, 1024*1024, sandbox.config.MaxOutputBytes)
 
 	// 测试自定义配置
 	customConfig := &Config{
@@ -133,6 +136,93 @@ func TestDockerSandbox_RunCommand(t *testing.T) {
 	assert.Equal(t, "success", output)
 }
 
+func TestDockerSandbox_ExecCommand(t *testing.T) {
+	ctx := context.Background()
+
+	defer mockey.Mock((*client.Client).ContainerExecCreate).Return(container.ExecCreateResponse{ID: "test_exec_id"}, nil).Build().UnPatch()
+	buf := &bytes.Buffer{}
+	stdcopy.NewStdWriter(buf, stdcopy.Stdout).Write([]byte("hello world"))
+	stdcopy.NewStdWriter(buf, stdcopy.Stderr).Write([]byte("not found\n"))
+	defer mockey.Mock((*client.Client).ContainerExecAttach).To(func(ctx context.Context, execID string, config container.ExecAttachOptions) (types.HijackedResponse, error) {
+		return types.HijackedResponse{
+			Conn:   &myConn{},
+			Reader: bufio.NewReader(buf),
+		}, nil
+	}).Build().UnPatch()
+	defer mockey.Mock((*client.Client).ContainerExecInspect).Return(container.ExecInspect{ExitCode: 2}, nil).Build().UnPatch()
+
+	sandbox := &DockerSandbox{
+		config: Config{
+			Timeout:        30 * time.Second,
+			MaxOutputBytes: 5,
+		},
+		client:      &client.Client{},
+		containerID: "test_container_id",
+	}
+
+	result, err := sandbox.ExecCommand(ctx, "cat missing")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.ExitCode)
+	assert.Equal(t, "hello", result.Stdout)
+	assert.Equal(t, "not f", result.Stderr)
+	assert.True(t, result.Truncated)
+	assert.False(t, result.TimedOut)
+}
+
+func TestDockerSandbox_StreamCommand(t *testing.T) {
+	ctx := context.Background()
+
+	defer mockey.Mock((*client.Client).ContainerExecCreate).Return(container.ExecCreateResponse{ID: "test_exec_id"}, nil).Build().UnPatch()
+	buf := &bytes.Buffer{}
+	stdcopy.NewStdWriter(buf, stdcopy.Stdout).Write([]byte("step 1\nstep"))
+	stdcopy.NewStdWriter(buf, stdcopy.Stderr).Write([]byte("warning\n"))
+	stdcopy.NewStdWriter(buf, stdcopy.Stdout).Write([]byte(" 2\ndone"))
+	defer mockey.Mock((*client.Client).ContainerExecAttach).To(func(ctx context.Context, execID string, config container.ExecAttachOptions) (types.HijackedResponse, error) {
+		return types.HijackedResponse{
+			Conn:   &myConn{},
+			Reader: bufio.NewReader(buf),
+		}, nil
+	}).Build().UnPatch()
+	defer mockey.Mock((*client.Client).ContainerExecInspect).Return(container.ExecInspect{ExitCode: 0}, nil).Build().UnPatch()
+
+	sandbox := &DockerSandbox{
+		config: Config{
+			Timeout: 30 * time.Second,
+		},
+		client:      &client.Client{},
+		containerID: "test_container_id",
+	}
+
+	sr, err := sandbox.StreamCommand(ctx, "make")
+	assert.NoError(t, err)
+	defer sr.Close()
+
+	var lines []string
+	var result *commandline.CommandResult
+	for {
+		out, err := sr.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		if out.Result != nil {
+			result = out.Result
+			continue
+		}
+		lines = append(lines, string(out.Stream)+": "+out.Line)
+	}
+	assert.Equal(t, []string{"stdout: step 1", "stderr: warning", "stdout: step 2", "stdout: done"}, lines)
+	if assert.NotNil(t, result) {
+		assert.Equal(t, 0, result.ExitCode)
+		assert.Equal(t, "step 1\nstep 2\ndone", result.Stdout)
+		assert.Equal(t, "warning\n", result.Stderr)
+	}
+
+	// not initialized
+	_, err = (&DockerSandbox{}).StreamCommand(ctx, "make")
+	assert.Error(t, err)
+}
+
 func TestDockerSandbox_IsDirectory(t *testing.T) {
 	ctx := context.Background()
 
@@ -0,0 +1,151 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commandline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+type ShellExecutorConfig struct {
+	// Operator runs the commands, it must implement CommandExecutor.
+	Operator Operator
+}
+
+// NewShellExecutor creates a tool that runs shell commands. It can be invoked for a structured result,
+// or streamed to show output lines as they arrive.
+func NewShellExecutor(_ context.Context, cfg *ShellExecutorConfig) (*ShellExecutor, error) {
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	if cfg.Operator == nil {
+		return nil, errors.New("operator is required")
+	}
+	executor, ok := cfg.Operator.(CommandExecutor)
+	if !ok {
+		return nil, errors.New("operator must implement CommandExecutor")
+	}
+
+	return &ShellExecutor{
+		info: &schema.ToolInfo{
+			Name: "shell_execute",
+			Desc: "Executes a shell command and returns its exit code, stdout and stderr. Commands that don't finish within the timeout are killed.",
+			ParamsOneOf: schema.NewParamsOneOfByOpenAPIV3(&openapi3.Schema{
+				Type: openapi3.TypeObject,
+				Properties: map[string]*openapi3.SchemaRef{
+					"command": {
+						Value: &openapi3.Schema{
+							Type:        openapi3.TypeString,
+							Description: "The shell command to execute.",
+						},
+					},
+				},
+				Required: []string{"command"},
+			}),
+		},
+		executor: executor,
+	}, nil
+}
+
+type ShellExecutor struct {
+	info     *schema.ToolInfo
+	executor CommandExecutor
+}
+
+type ShellInput struct {
+	Command string `json:"command"`
+}
+
+func (s *ShellExecutor) Info(_ context.Context) (*schema.ToolInfo, error) {
+	return s.info, nil
+}
+
+// InvokableRun returns the CommandResult in JSON
+func (s *ShellExecutor) InvokableRun(ctx context.Context, argumentsInJSON string, _ ...tool.Option) (string, error) {
+	args, err := s.parseInput(argumentsInJSON)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := s.executor.ExecCommand(ctx, args.Command)
+	if err != nil {
+		return "", fmt.Errorf("execute error: %w", err)
+	}
+
+	b, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("marshal result fail: %w", err)
+	}
+	return string(b), nil
+}
+
+// StreamableRun yields one chunk per line of output, stderr lines are prefixed with "[stderr] ",
+// and the last chunk reports the exit code.
+func (s *ShellExecutor) StreamableRun(ctx context.Context, argumentsInJSON string, _ ...tool.Option) (*schema.StreamReader[string], error) {
+	args, err := s.parseInput(argumentsInJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	sr, err := s.executor.StreamCommand(ctx, args.Command)
+	if err != nil {
+		return nil, fmt.Errorf("execute error: %w", err)
+	}
+
+	return schema.StreamReaderWithConvert(sr, func(out *CommandOutput) (string, error) {
+		if out.Result != nil {
+			return formatResultLine(out.Result), nil
+		}
+		if out.Stream == StderrStream {
+			return "[stderr] " + out.Line + "\n", nil
+		}
+		return out.Line + "\n", nil
+	}), nil
+}
+
+// Execute runs the command and waits for the result
+func (s *ShellExecutor) Execute(ctx context.Context, args *ShellInput) (*CommandResult, error) {
+	return s.executor.ExecCommand(ctx, args.Command)
+}
+
+func (s *ShellExecutor) parseInput(argumentsInJSON string) (*ShellInput, error) {
+	args := &ShellInput{}
+	if err := json.Unmarshal([]byte(argumentsInJSON), args); err != nil {
+		return nil, fmt.Errorf("extract argument fail: %w", err)
+	}
+	if len(args.Command) == 0 {
+		return nil, errors.New("parameter `command` is required")
+	}
+	return args, nil
+}
+
+func formatResultLine(result *CommandResult) string {
+	switch {
+	case result.TimedOut:
+		return fmt.Sprintf("[timed out after %v]\n", result.Duration)
+	case result.Truncated:
+		return fmt.Sprintf("[exit code %d, output truncated]\n", result.ExitCode)
+	default:
+		return fmt.Sprintf("[exit code %d]\n", result.ExitCode)
+	}
+}
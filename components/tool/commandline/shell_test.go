@@ -0,0 +1,96 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commandline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type shellOperator struct {
+	pyOperator
+	outputs []*CommandOutput
+	err     error
+}
+
+func (s *shellOperator) ExecCommand(ctx context.Context, command string) (*CommandResult, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.outputs[len(s.outputs)-1].Result, nil
+}
+
+func (s *shellOperator) StreamCommand(ctx context.Context, command string) (*schema.StreamReader[*CommandOutput], error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return schema.StreamReaderFromArray(s.outputs), nil
+}
+
+func TestShellExecutor(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewShellExecutor(ctx, &ShellExecutorConfig{Operator: &pyOperator{}})
+	assert.ErrorContains(t, err, "CommandExecutor")
+
+	op := &shellOperator{outputs: []*CommandOutput{
+		{Stream: StdoutStream, Line: "compiling"},
+		{Stream: StderrStream, Line: "warning: unused"},
+		{Result: &CommandResult{ExitCode: 1, Stdout: "compiling\n", Stderr: "warning: unused\n", Duration: time.Second}},
+	}}
+	shell, err := NewShellExecutor(ctx, &ShellExecutorConfig{Operator: op})
+	assert.NoError(t, err)
+
+	out, err := shell.InvokableRun(ctx, `{"command": "make"}`)
+	assert.NoError(t, err)
+	result := &CommandResult{}
+	assert.NoError(t, json.Unmarshal([]byte(out), result))
+	assert.Equal(t, op.outputs[2].Result, result)
+
+	sr, err := shell.StreamableRun(ctx, `{"command": "make"}`)
+	assert.NoError(t, err)
+	var chunks []string
+	for {
+		chunk, err := sr.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		chunks = append(chunks, chunk)
+	}
+	assert.Equal(t, []string{"compiling\n", "[stderr] warning: unused\n", "[exit code 1]\n"}, chunks)
+
+	op.outputs = []*CommandOutput{{Result: &CommandResult{ExitCode: -1, TimedOut: true, Duration: time.Minute}}}
+	sr, err = shell.StreamableRun(ctx, `{"command": "sleep 100"}`)
+	assert.NoError(t, err)
+	chunk, err := sr.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, "[timed out after 1m0s]\n", chunk)
+
+	_, err = shell.InvokableRun(ctx, `{}`)
+	assert.Error(t, err)
+	op.err = errors.New("sandbox not initialized")
+	_, err = shell.StreamableRun(ctx, `{"command": "make"}`)
+	assert.ErrorContains(t, err, "sandbox not initialized")
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+
+	mcpp "github.com/cloudwego/eino-ext/components/tool/mcp"
+)
+
+func main() {
+	ctx := context.Background()
+
+	conf := &mcpp.ServerConfig{
+		Name:    "eino-tools",
+		Version: "1.0.0",
+		Tools:   []tool.BaseTool{&upperTool{}},
+	}
+
+	// serve over stdio, so that the binary can be configured as a command in any MCP client
+	if err := mcpp.ServeStdio(ctx, conf); err != nil {
+		log.Fatal(err)
+	}
+
+	// or serve over streamable HTTP:
+	//   svr, _ := mcpp.NewStreamableHTTPServer(ctx, conf)
+	//   svr.Start("localhost:12345") // endpoint: http://localhost:12345/mcp
+	// or over SSE:
+	//   svr, _ := mcpp.NewSSEServer(ctx, conf, server.WithBaseURL("http://localhost:12345"))
+	//   svr.Start("localhost:12345") // endpoint: http://localhost:12345/sse
+}
+
+type upperTool struct{}
+
+func (u *upperTool) Info(ctx context.Context) (*schema.ToolInfo, error) {
+	return &schema.ToolInfo{
+		Name: "to_upper",
+		Desc: "Convert text to upper case",
+		ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+			"text": {Type: schema.String, Desc: "the text to convert", Required: true},
+		}),
+	}, nil
+}
+
+func (u *upperTool) InvokableRun(ctx context.Context, argumentsInJSON string, opts ...tool.Option) (string, error) {
+	input := struct {
+		Text string `json:"text"`
+	}{}
+	if err := json.Unmarshal([]byte(argumentsInJSON), &input); err != nil {
+		return "", err
+	}
+	return strings.ToUpper(input.Text), nil
+}
@@ -0,0 +1,464 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const defaultNameSeparator = "__"
+
+// ClientConfig configures one of the MCP servers managed by a Manager.
+type ClientConfig struct {
+	// Name identifies the server and namespaces its tools, e.g. server "github" exposes tool "create_issue" as "github__create_issue"
+	// Required, must be unique within the Manager
+	Name string
+	// Cli is the MCP client of the server
+	// Notice: should Initialize with server before use
+	// Optional if Connect is set, in which case the first client is created by Connect
+	Cli client.MCPClient
+	// Connect creates, starts and initializes a new client of the server,
+	// it's used to reconnect after the transport is dropped
+	// Optional. Default: dropped clients are not reconnected
+	Connect func(ctx context.Context) (client.MCPClient, error)
+	// AllowList specifies which tools of the server to expose, by their names on the server
+	// Optional. Default: all tools
+	AllowList []string
+	// DenyList specifies which tools of the server to hide, by their names on the server, it's applied after AllowList
+	// Optional.
+	DenyList []string
+	// CallTimeout limits the duration of each tool call to the server
+	// Optional. Default: no limit besides the context of the call
+	CallTimeout time.Duration
+	// MaxConcurrency limits the number of concurrent tool calls to the server, further calls wait for a free slot
+	// Optional. Default: no limit
+	MaxConcurrency int
+	// ToolCallResultHandler processes the result of each tool call to the server, see Config.ToolCallResultHandler
+	// The name passed to it is the tool name on the server, without the server name
+	// Optional.
+	ToolCallResultHandler func(ctx context.Context, name string, result *mcp.CallToolResult) (*mcp.CallToolResult, error)
+}
+
+// ManagerConfig is the config of Manager.
+type ManagerConfig struct {
+	// Clients are the MCP servers to aggregate
+	// Required
+	Clients []*ClientConfig
+	// NameSeparator joins the server name and the tool name
+	// Optional. Default: "__"
+	NameSeparator string
+	// HealthCheckInterval is the interval at which each server is pinged, a server failing the ping is reconnected with ClientConfig.Connect
+	// Optional. Default: 0, no periodic health check, servers are still checked after a failed call
+	HealthCheckInterval time.Duration
+	// HealthCheckTimeout limits each ping
+	// Optional. Default: 10s
+	HealthCheckTimeout time.Duration
+	// ProgressHandler receives the progress notifications of tool calls, see Config.ProgressHandler
+	// Optional.
+	ProgressHandler ProgressHandler
+}
+
+// Manager aggregates the tools of several MCP servers, namespacing their names with the server name.
+type Manager struct {
+	conf    *ManagerConfig
+	servers []*managedServer
+
+	// ctx is cancelled by Close, which waits for the background goroutines tracked by wg
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewManager connects the servers which have no client yet, and starts the periodic health check if configured.
+func NewManager(ctx context.Context, conf *ManagerConfig) (*Manager, error) {
+	if conf == nil || len(conf.Clients) == 0 {
+		return nil, errors.New("at least one mcp client is required")
+	}
+
+	c := *conf
+	if c.NameSeparator == "" {
+		c.NameSeparator = defaultNameSeparator
+	}
+	if c.HealthCheckTimeout <= 0 {
+		c.HealthCheckTimeout = 10 * time.Second
+	}
+
+	m := &Manager{conf: &c}
+	m.ctx, m.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	names := make(map[string]struct{}, len(c.Clients))
+	for _, cc := range c.Clients {
+		if cc == nil || cc.Name == "" {
+			return nil, errors.New("mcp client name is required")
+		}
+		// keeps the namespaced names unambiguous, as the first separator always ends the server name
+		if strings.Contains(cc.Name, c.NameSeparator) {
+			return nil, fmt.Errorf("mcp client name %s must not contain the name separator %q", cc.Name, c.NameSeparator)
+		}
+		if _, ok := names[cc.Name]; ok {
+			return nil, fmt.Errorf("duplicate mcp client name: %s", cc.Name)
+		}
+		names[cc.Name] = struct{}{}
+
+		if cc.Cli == nil && cc.Connect == nil {
+			return nil, fmt.Errorf("mcp client %s has neither Cli nor Connect", cc.Name)
+		}
+		m.servers = append(m.servers, newManagedServer(cc, m))
+	}
+
+	for _, s := range m.servers {
+		if s.cli != nil {
+			continue
+		}
+		if err := s.reconnect(ctx, nil); err != nil {
+			_ = m.Close()
+			return nil, err
+		}
+	}
+
+	if c.HealthCheckInterval > 0 {
+		m.goBackground(m.healthCheckLoop)
+	}
+
+	return m, nil
+}
+
+// Tools lists the tools of all servers, named "<server><separator><tool>".
+// Server names can't contain the separator, so tools of different servers never collide.
+// A server whose listing fails is reconnected and listed once more. If it still fails, the tools of the other
+// servers are returned anyway, along with an error joining the failures of each server, so that one dead server
+// doesn't take the tools of the others away. Callers requiring every server should treat a non-nil error as fatal.
+func (m *Manager) Tools(ctx context.Context) ([]tool.BaseTool, error) {
+	var (
+		ret  []tool.BaseTool
+		errs []error
+	)
+	for _, s := range m.servers {
+		tools, err := s.tools(ctx)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, t := range tools {
+			ret = append(ret, t)
+		}
+	}
+	return ret, errors.Join(errs...)
+}
+
+// Close stops the health checks and closes the clients of all servers.
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	m.closed = true
+	m.mu.Unlock()
+	m.cancel()
+	m.wg.Wait()
+
+	var errs []error
+	for _, s := range m.servers {
+		if err := s.close(); err != nil {
+			errs = append(errs, fmt.Errorf("close mcp client %s fail: %w", s.conf.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// goBackground runs f in a goroutine, with a context cancelled by Close which waits for f to return.
+// f is not run once the manager is closed.
+func (m *Manager) goBackground(f func(ctx context.Context)) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return false
+	}
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		f(m.ctx)
+	}()
+	return true
+}
+
+func (m *Manager) healthCheckLoop(ctx context.Context) {
+	ticker := time.NewTicker(m.conf.HealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, s := range m.servers {
+				s.checkHealth(ctx)
+			}
+		}
+	}
+}
+
+type managedServer struct {
+	conf    *ClientConfig
+	manager *Manager
+	allow   map[string]struct{}
+	deny    map[string]struct{}
+	sem     chan struct{}
+
+	mu     sync.Mutex
+	cli    client.MCPClient
+	hub    *notificationHub
+	closed bool
+
+	// serializes reconnects, so that concurrent failures reconnect once
+	connectMu sync.Mutex
+	// set while a check started by a failed call is running
+	checkPending atomic.Bool
+}
+
+func newManagedServer(conf *ClientConfig, manager *Manager) *managedServer {
+	s := &managedServer{
+		conf:    conf,
+		manager: manager,
+		allow:   toSet(conf.AllowList),
+		deny:    toSet(conf.DenyList),
+	}
+	if conf.MaxConcurrency > 0 {
+		s.sem = make(chan struct{}, conf.MaxConcurrency)
+	}
+	if conf.Cli != nil {
+		s.cli = conf.Cli
+		s.hub = newNotificationHub(conf.Cli)
+	}
+	return s
+}
+
+func toSet(names []string) map[string]struct{} {
+	if len(names) == 0 {
+		return nil
+	}
+	set := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		set[name] = struct{}{}
+	}
+	return set
+}
+
+func (s *managedServer) current() (client.MCPClient, *notificationHub) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cli, s.hub
+}
+
+func (s *managedServer) tools(ctx context.Context) ([]*managedTool, error) {
+	cli, hub := s.current()
+	tools, err := getTools(ctx, &Config{Cli: cli}, hub)
+	if err != nil && s.conf.Connect != nil {
+		if reconnErr := s.reconnect(ctx, cli); reconnErr != nil {
+			return nil, fmt.Errorf("list tools of mcp server %s fail: %w", s.conf.Name, errors.Join(err, reconnErr))
+		}
+		cli, hub = s.current()
+		tools, err = getTools(ctx, &Config{Cli: cli}, hub)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list tools of mcp server %s fail: %w", s.conf.Name, err)
+	}
+
+	ret := make([]*managedTool, 0, len(tools))
+	for _, t := range tools {
+		info := t.(*toolHelper).info
+		if s.allow != nil {
+			if _, ok := s.allow[info.Name]; !ok {
+				continue
+			}
+		}
+		if _, ok := s.deny[info.Name]; ok {
+			continue
+		}
+
+		namespaced := *info
+		namespaced.Name = s.conf.Name + s.manager.conf.NameSeparator + info.Name
+		ret = append(ret, &managedTool{
+			server: s,
+			name:   info.Name,
+			info:   &namespaced,
+		})
+	}
+	return ret, nil
+}
+
+// checkHealth pings the server and reconnects it if the ping fails.
+func (s *managedServer) checkHealth(ctx context.Context) {
+	cli, _ := s.current()
+	if cli == nil {
+		_ = s.reconnect(ctx, nil)
+		return
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, s.manager.conf.HealthCheckTimeout)
+	err := cli.Ping(pingCtx)
+	cancel()
+	if err != nil {
+		_ = s.reconnect(ctx, cli)
+	}
+}
+
+// checkHealthAsync checks the health of the server in background, unless such a check is already pending,
+// so that a burst of failed calls pings the server once.
+func (s *managedServer) checkHealthAsync() {
+	if !s.checkPending.CompareAndSwap(false, true) {
+		return
+	}
+	started := s.manager.goBackground(func(ctx context.Context) {
+		defer s.checkPending.Store(false)
+		s.checkHealth(ctx)
+	})
+	if !started {
+		s.checkPending.Store(false)
+	}
+}
+
+// reconnect replaces the client with a new one from Connect, unless the client has already been replaced since broken was got.
+func (s *managedServer) reconnect(ctx context.Context, broken client.MCPClient) error {
+	if s.conf.Connect == nil {
+		return fmt.Errorf("mcp server %s can't reconnect without Connect", s.conf.Name)
+	}
+
+	s.connectMu.Lock()
+	defer s.connectMu.Unlock()
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return fmt.Errorf("mcp server %s is closed", s.conf.Name)
+	}
+	if s.cli != broken {
+		// reconnected by someone else
+		s.mu.Unlock()
+		return nil
+	}
+	s.mu.Unlock()
+
+	cli, err := s.conf.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("connect mcp server %s fail: %w", s.conf.Name, err)
+	}
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		_ = cli.Close()
+		return fmt.Errorf("mcp server %s is closed", s.conf.Name)
+	}
+	s.cli = cli
+	s.hub = newNotificationHub(cli)
+	s.mu.Unlock()
+
+	if broken != nil {
+		_ = broken.Close()
+	}
+	return nil
+}
+
+func (s *managedServer) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	if s.cli == nil {
+		return nil
+	}
+	return s.cli.Close()
+}
+
+type managedTool struct {
+	server *managedServer
+	// name is the tool name on the server
+	name string
+	// info carries the namespaced name
+	info *schema.ToolInfo
+}
+
+func (t *managedTool) Info(ctx context.Context) (*schema.ToolInfo, error) {
+	return t.info, nil
+}
+
+func (t *managedTool) InvokableRun(ctx context.Context, argumentsInJSON string, opts ...tool.Option) (string, error) {
+	result, err := t.run(ctx, func(ctx context.Context, h *toolHelper) (any, error) {
+		return h.InvokableRun(ctx, argumentsInJSON, opts...)
+	})
+	if err != nil {
+		return "", err
+	}
+	return result.(string), nil
+}
+
+func (t *managedTool) MultimodalRun(ctx context.Context, argumentsInJSON string, opts ...tool.Option) ([]schema.ChatMessagePart, error) {
+	result, err := t.run(ctx, func(ctx context.Context, h *toolHelper) (any, error) {
+		return h.MultimodalRun(ctx, argumentsInJSON, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]schema.ChatMessagePart), nil
+}
+
+func (t *managedTool) run(ctx context.Context, call func(ctx context.Context, h *toolHelper) (any, error)) (any, error) {
+	s := t.server
+	if s.sem != nil {
+		select {
+		case s.sem <- struct{}{}:
+			defer func() { <-s.sem }()
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wait for mcp server %s concurrency slot: %w", s.conf.Name, ctx.Err())
+		}
+	}
+	if s.conf.CallTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.conf.CallTimeout)
+		defer cancel()
+	}
+
+	cli, hub := s.current()
+	if cli == nil {
+		return nil, fmt.Errorf("mcp server %s is not connected", s.conf.Name)
+	}
+	result, err := call(ctx, &toolHelper{
+		cli:                   cli,
+		info:                  &schema.ToolInfo{Name: t.name},
+		toolCallResultHandler: s.conf.ToolCallResultHandler,
+		progressHandler:       s.manager.conf.ProgressHandler,
+		hub:                   hub,
+	})
+	if err != nil {
+		// the transport may be dropped, check in background so that the next call gets a working client,
+		// unless the call only failed because of its own timeout or cancellation
+		if !errors.Is(err, errToolResult) && s.conf.Connect != nil && ctx.Err() == nil {
+			s.checkHealthAsync()
+		}
+		return nil, fmt.Errorf("mcp server %s: %w", s.conf.Name, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,376 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager(t *testing.T) {
+	ctx := context.Background()
+
+	github := newTextServer("create_issue", "delete_repo", "search")
+	slack := newTextServer("send_message", "search")
+
+	m, err := NewManager(ctx, &ManagerConfig{
+		Clients: []*ClientConfig{
+			{Name: "github", Cli: newInProcessClient(t, github), DenyList: []string{"delete_repo"}},
+			{
+				Name:      "slack",
+				Cli:       newInProcessClient(t, slack),
+				AllowList: []string{"search"},
+				ToolCallResultHandler: func(ctx context.Context, name string, result *mcp.CallToolResult) (*mcp.CallToolResult, error) {
+					return mcp.NewToolResultText(name + " handled"), nil
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer m.Close()
+
+	tools, err := m.Tools(ctx)
+	require.NoError(t, err)
+	var names []string
+	for _, tl := range tools {
+		info, err := tl.Info(ctx)
+		require.NoError(t, err)
+		names = append(names, info.Name)
+	}
+	assert.Equal(t, []string{"github__create_issue", "github__search", "slack__search"}, names)
+
+	result, err := tools[1].(tool.InvokableTool).InvokableRun(ctx, `{}`)
+	require.NoError(t, err)
+	assert.Equal(t, "search", result)
+	parts, err := tools[2].(MultimodalTool).MultimodalRun(ctx, `{}`)
+	require.NoError(t, err)
+	assert.Equal(t, "search handled", parts[0].Text)
+}
+
+func TestManagerConfigError(t *testing.T) {
+	ctx := context.Background()
+	cli := &mockMCPClient{}
+
+	_, err := NewManager(ctx, &ManagerConfig{})
+	assert.Error(t, err)
+	_, err = NewManager(ctx, &ManagerConfig{Clients: []*ClientConfig{{Name: "a", Cli: cli}, {Name: "a", Cli: cli}}})
+	assert.ErrorContains(t, err, "duplicate mcp client name: a")
+	_, err = NewManager(ctx, &ManagerConfig{Clients: []*ClientConfig{{Name: "a__b", Cli: cli}}})
+	assert.ErrorContains(t, err, "must not contain the name separator")
+	_, err = NewManager(ctx, &ManagerConfig{Clients: []*ClientConfig{{Name: "a"}}})
+	assert.ErrorContains(t, err, "neither Cli nor Connect")
+
+	// tool names may contain the separator, server names can't
+	m, err := NewManager(ctx, &ManagerConfig{
+		NameSeparator: ".",
+		Clients: []*ClientConfig{
+			{Name: "a", Cli: newInProcessClient(t, newTextServer("b.c"))},
+			{Name: "a_b", Cli: newInProcessClient(t, newTextServer("c"))},
+		},
+	})
+	require.NoError(t, err)
+	defer m.Close()
+	tools, err := m.Tools(ctx)
+	require.NoError(t, err)
+	require.Len(t, tools, 2)
+	info, err := tools[0].Info(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "a.b.c", info.Name)
+	info, err = tools[1].Info(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "a_b.c", info.Name)
+}
+
+func TestManagerLimits(t *testing.T) {
+	ctx := context.Background()
+
+	var running, maxRunning atomic.Int32
+	svr := server.NewMCPServer("test", "1.0.0")
+	svr.AddTool(mcp.NewTool("sleep"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := maxRunning.Load()
+			if n <= old || maxRunning.CompareAndSwap(old, n) {
+				break
+			}
+		}
+		d, _ := time.ParseDuration(request.GetString("duration", "0s"))
+		select {
+		case <-time.After(d):
+			return mcp.NewToolResultText("slept"), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	})
+
+	m, err := NewManager(ctx, &ManagerConfig{
+		Clients: []*ClientConfig{
+			{Name: "s", Cli: newInProcessClient(t, svr), CallTimeout: 200 * time.Millisecond, MaxConcurrency: 2},
+		},
+	})
+	require.NoError(t, err)
+	defer m.Close()
+	tools, err := m.Tools(ctx)
+	require.NoError(t, err)
+	sleep := tools[0].(tool.InvokableTool)
+
+	_, err = sleep.InvokableRun(ctx, `{"duration":"5s"}`)
+	assert.ErrorContains(t, err, context.DeadlineExceeded.Error())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := sleep.InvokableRun(ctx, `{"duration":"20ms"}`)
+			assert.NoError(t, err)
+			assert.Equal(t, "slept", result)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(2), maxRunning.Load())
+}
+
+func TestManagerReconnect(t *testing.T) {
+	ctx := context.Background()
+	svr := newTextServer("echo")
+
+	var (
+		mu      sync.Mutex
+		clients []*flakyClient
+	)
+	connect := func(ctx context.Context) (client.MCPClient, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		cli := &flakyClient{MCPClient: newInProcessClient(t, svr)}
+		clients = append(clients, cli)
+		return cli, nil
+	}
+	latest := func() *flakyClient {
+		mu.Lock()
+		defer mu.Unlock()
+		return clients[len(clients)-1]
+	}
+	count := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(clients)
+	}
+
+	t.Run("after failed call", func(t *testing.T) {
+		m, err := NewManager(ctx, &ManagerConfig{Clients: []*ClientConfig{{Name: "s", Connect: connect}}})
+		require.NoError(t, err)
+		defer m.Close()
+		require.Equal(t, 1, count())
+
+		tools, err := m.Tools(ctx)
+		require.NoError(t, err)
+		echo := tools[0].(tool.InvokableTool)
+
+		first := latest()
+		first.broken.Store(true)
+		_, err = echo.InvokableRun(ctx, `{}`)
+		assert.ErrorContains(t, err, "transport dropped")
+
+		assert.Eventually(t, func() bool { return count() == 2 }, 5*time.Second, 10*time.Millisecond)
+		assert.True(t, first.closed.Load())
+
+		result, err := echo.InvokableRun(ctx, `{}`)
+		require.NoError(t, err)
+		assert.Equal(t, "echo", result)
+	})
+
+	t.Run("merged checks", func(t *testing.T) {
+		m, err := NewManager(ctx, &ManagerConfig{Clients: []*ClientConfig{{Name: "s", Connect: connect}}})
+		require.NoError(t, err)
+		defer m.Close()
+
+		tools, err := m.Tools(ctx)
+		require.NoError(t, err)
+		echo := tools[0].(tool.InvokableTool)
+
+		// the failed calls share the check pinging the server, which waits for the gate
+		before := count()
+		first := latest()
+		gate := make(chan struct{})
+		first.pingGate = gate
+		first.broken.Store(true)
+		for i := 0; i < 5; i++ {
+			_, err = echo.InvokableRun(ctx, `{}`)
+			assert.Error(t, err)
+		}
+		assert.Eventually(t, func() bool { return first.pings.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+		close(gate)
+		assert.Eventually(t, func() bool { return count() == before+1 }, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, int32(1), first.pings.Load())
+	})
+
+	t.Run("call timeout", func(t *testing.T) {
+		slow := server.NewMCPServer("test", "1.0.0")
+		slow.AddTool(mcp.NewTool("slow"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(time.Second):
+				return mcp.NewToolResultText("slow"), nil
+			}
+		})
+		cli := &flakyClient{MCPClient: newInProcessClient(t, slow)}
+		m, err := NewManager(ctx, &ManagerConfig{Clients: []*ClientConfig{{
+			Name:        "s",
+			Cli:         cli,
+			Connect:     connect,
+			CallTimeout: 20 * time.Millisecond,
+		}}})
+		require.NoError(t, err)
+
+		tools, err := m.Tools(ctx)
+		require.NoError(t, err)
+		_, err = tools[0].(tool.InvokableTool).InvokableRun(ctx, `{}`)
+		assert.Error(t, err)
+
+		// a call failing for its own timeout doesn't check the server
+		require.NoError(t, m.Close())
+		assert.Equal(t, int32(0), cli.pings.Load())
+	})
+
+	t.Run("health check", func(t *testing.T) {
+		before := count()
+		m, err := NewManager(ctx, &ManagerConfig{
+			Clients:             []*ClientConfig{{Name: "s", Connect: connect}},
+			HealthCheckInterval: 20 * time.Millisecond,
+		})
+		require.NoError(t, err)
+		require.Equal(t, before+1, count())
+
+		latest().broken.Store(true)
+		assert.Eventually(t, func() bool { return count() == before+2 }, 5*time.Second, 10*time.Millisecond)
+
+		require.NoError(t, m.Close())
+		assert.True(t, latest().closed.Load())
+	})
+
+	t.Run("listing", func(t *testing.T) {
+		m, err := NewManager(ctx, &ManagerConfig{Clients: []*ClientConfig{{Name: "s", Connect: connect}}})
+		require.NoError(t, err)
+		defer m.Close()
+
+		before := count()
+		latest().broken.Store(true)
+		tools, err := m.Tools(ctx)
+		require.NoError(t, err)
+		assert.Len(t, tools, 1)
+		assert.Equal(t, before+1, count())
+	})
+
+	t.Run("without connect", func(t *testing.T) {
+		cli := &flakyClient{MCPClient: newInProcessClient(t, svr)}
+		m, err := NewManager(ctx, &ManagerConfig{Clients: []*ClientConfig{
+			{Name: "s", Cli: cli},
+			{Name: "healthy", Cli: newInProcessClient(t, newTextServer("search"))},
+		}})
+		require.NoError(t, err)
+		defer m.Close()
+
+		// the tools of the healthy server are still listed
+		cli.broken.Store(true)
+		tools, err := m.Tools(ctx)
+		assert.ErrorContains(t, err, "list tools of mcp server s fail")
+		assert.NotContains(t, err.Error(), "healthy")
+		if assert.Len(t, tools, 1) {
+			info, err := tools[0].Info(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, "healthy__search", info.Name)
+		}
+	})
+}
+
+// newTextServer creates a server whose tools return their own names.
+func newTextServer(names ...string) *server.MCPServer {
+	svr := server.NewMCPServer("test", "1.0.0")
+	for _, name := range names {
+		svr.AddTool(mcp.NewTool(name), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(name), nil
+		})
+	}
+	return svr
+}
+
+func newInProcessClient(t *testing.T, svr *server.MCPServer) *client.Client {
+	cli, err := client.NewInProcessClient(svr)
+	require.NoError(t, err)
+	initClient(t, context.Background(), cli)
+	return cli
+}
+
+// flakyClient fails every request once broken, as if its transport were dropped.
+type flakyClient struct {
+	client.MCPClient
+	broken atomic.Bool
+	closed atomic.Bool
+	pings  atomic.Int32
+	// pingGate, if set, holds the pings until it's closed
+	pingGate chan struct{}
+}
+
+var errDropped = errors.New("transport dropped")
+
+func (f *flakyClient) Ping(ctx context.Context) error {
+	f.pings.Add(1)
+	if f.pingGate != nil {
+		select {
+		case <-f.pingGate:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if f.broken.Load() {
+		return errDropped
+	}
+	return f.MCPClient.Ping(ctx)
+}
+
+func (f *flakyClient) ListTools(ctx context.Context, request mcp.ListToolsRequest) (*mcp.ListToolsResult, error) {
+	if f.broken.Load() {
+		return nil, errDropped
+	}
+	return f.MCPClient.ListTools(ctx, request)
+}
+
+func (f *flakyClient) CallTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if f.broken.Load() {
+		return nil, errDropped
+	}
+	return f.MCPClient.CallTool(ctx, request)
+}
+
+func (f *flakyClient) Close() error {
+	f.closed.Store(true)
+	return f.MCPClient.Close()
+}
@@ -19,6 +19,7 @@ package mcp
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/bytedance/sonic"
@@ -29,6 +30,9 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// errToolResult is returned when the tool reports an error in its result, as opposed to a failed call
+var errToolResult = errors.New("failed to call mcp tool, mcp server return error")
+
 type Config struct {
 	// Cli is the MCP (Model Control Protocol) client, ref: https://github.com/mark3labs/mcp-go?tab=readme-ov-file#tools
 	// Notice: should Initialize with server before use
@@ -40,9 +44,23 @@ type Config struct {
 	// It can be used for custom processing of tool call results
 	// If nil, no additional processing will be performed
 	ToolCallResultHandler func(ctx context.Context, name string, result *mcp.CallToolResult) (*mcp.CallToolResult, error)
+	// ProgressHandler receives the progress notifications sent by the MCP server during a tool call
+	// It can be overridden per call with WithProgressHandler
+	// Optional. Default: no progress is requested
+	ProgressHandler ProgressHandler
+	// ToolsChangedHandler is called with the refreshed tools after the MCP server sends notifications/tools/list_changed
+	// Only used by NewToolSet
+	// Optional.
+	ToolsChangedHandler func(ctx context.Context, tools []tool.BaseTool)
 }
 
+// GetTools fetches the tools of the MCP server once.
+// Use NewToolSet instead if the tool set of the server may change at runtime.
 func GetTools(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
+	return getTools(ctx, conf, nil)
+}
+
+func getTools(ctx context.Context, conf *Config, hub *notificationHub) ([]tool.BaseTool, error) {
 	listResults, err := conf.Cli.ListTools(ctx, mcp.ListToolsRequest{})
 	if err != nil {
 		return nil, fmt.Errorf("list mcp tools fail: %w", err)
@@ -79,6 +97,8 @@ func GetTools(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
 				ParamsOneOf: schema.NewParamsOneOfByOpenAPIV3(inputSchema),
 			},
 			toolCallResultHandler: conf.ToolCallResultHandler,
+			progressHandler:       conf.ProgressHandler,
+			hub:                   hub,
 		})
 	}
 
@@ -89,13 +109,48 @@ type toolHelper struct {
 	cli                   client.MCPClient
 	info                  *schema.ToolInfo
 	toolCallResultHandler func(ctx context.Context, name string, result *mcp.CallToolResult) (*mcp.CallToolResult, error)
+	progressHandler       ProgressHandler
+	// hub dispatches the progress notifications of the calls, the hub shared by the tools of cli if nil
+	hub *notificationHub
 }
 
 func (m *toolHelper) Info(ctx context.Context) (*schema.ToolInfo, error) {
 	return m.info, nil
 }
 
+// InvokableRun calls the MCP tool and renders the result content as text, see MultimodalRun for non-text content.
 func (m *toolHelper) InvokableRun(ctx context.Context, argumentsInJSON string, opts ...tool.Option) (string, error) {
+	result, err := m.callTool(ctx, argumentsInJSON, opts...)
+	if err != nil {
+		return "", err
+	}
+	return resultToText(result), nil
+}
+
+// MultimodalRun calls the MCP tool and converts the result content to message parts,
+// images, audios and binary resources are kept as data urls.
+func (m *toolHelper) MultimodalRun(ctx context.Context, argumentsInJSON string, opts ...tool.Option) ([]schema.ChatMessagePart, error) {
+	result, err := m.callTool(ctx, argumentsInJSON, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resultToParts(result), nil
+}
+
+func (m *toolHelper) callTool(ctx context.Context, argumentsInJSON string, opts ...tool.Option) (*mcp.CallToolResult, error) {
+	options := tool.GetImplSpecificOptions(&options{progressHandler: m.progressHandler}, opts...)
+
+	var meta *mcp.Meta
+	if options.progressHandler != nil {
+		hub := m.hub
+		if hub == nil {
+			hub = sharedNotificationHub(m.cli)
+		}
+		token, unregister := hub.registerProgress(ctx, m.info.Name, options.progressHandler)
+		defer unregister()
+		meta = &mcp.Meta{ProgressToken: token}
+	}
+
 	result, err := m.cli.CallTool(ctx, mcp.CallToolRequest{
 		Request: mcp.Request{
 			Method: "tools/call",
@@ -107,25 +162,22 @@ func (m *toolHelper) InvokableRun(ctx context.Context, argumentsInJSON string, o
 		}{
 			Name:      m.info.Name,
 			Arguments: json.RawMessage(argumentsInJSON),
+			Meta:      meta,
 		},
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to call mcp tool: %w", err)
+		return nil, fmt.Errorf("failed to call mcp tool: %w", err)
 	}
 
 	if m.toolCallResultHandler != nil {
 		result, err = m.toolCallResultHandler(ctx, m.info.Name, result)
 		if err != nil {
-			return "", fmt.Errorf("failed to execute mcp tool call result handler: %w", err)
+			return nil, fmt.Errorf("failed to execute mcp tool call result handler: %w", err)
 		}
 	}
 
-	marshaledResult, err := sonic.MarshalString(result)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal mcp tool result: %w", err)
-	}
 	if result.IsError {
-		return "", fmt.Errorf("failed to call mcp tool, mcp server return error: %s", marshaledResult)
+		return nil, fmt.Errorf("%w: %s", errToolResult, resultToText(result))
 	}
-	return marshaledResult, nil
+	return result, nil
 }
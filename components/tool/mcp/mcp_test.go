@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 )
@@ -39,10 +40,38 @@ func TestTool(t *testing.T) {
 
 	result, err := tools[0].(tool.InvokableTool).InvokableRun(ctx, "{\"input\": \"123\"}")
 	assert.NoError(t, err)
-	assert.Equal(t, "{\"content\":[{\"type\":\"text\",\"text\":\"hello\"}]}", result)
+	assert.Equal(t, "hello", result)
+
+	parts, err := tools[0].(MultimodalTool).MultimodalRun(ctx, "{\"input\": \"123\"}")
+	assert.NoError(t, err)
+	assert.Equal(t, []schema.ChatMessagePart{{Type: schema.ChatMessagePartTypeText, Text: "hello"}}, parts)
+}
+
+func TestGetToolsNotificationHandler(t *testing.T) {
+	cli := &mockMCPClient{}
+
+	ctx := context.Background()
+
+	// no handler is added to the client until a call requests progress
+	tools, err := GetTools(ctx, &Config{Cli: cli, ToolNameList: []string{"name"}})
+	assert.NoError(t, err)
+	_, err = tools[0].(tool.InvokableTool).InvokableRun(ctx, "{\"input\": \"123\"}")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, cli.notificationHandlers)
+
+	var progressHandler ProgressHandler = func(ctx context.Context, progress *Progress) {}
+	for i := 0; i < 2; i++ {
+		tools, err = GetTools(ctx, &Config{Cli: cli, ToolNameList: []string{"name"}, ProgressHandler: progressHandler})
+		assert.NoError(t, err)
+		_, err = tools[0].(tool.InvokableTool).InvokableRun(ctx, "{\"input\": \"123\"}")
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, cli.notificationHandlers)
 }
 
-type mockMCPClient struct{}
+type mockMCPClient struct {
+	notificationHandlers int
+}
 
 func (m *mockMCPClient) ListResourcesByPage(ctx context.Context, request mcp.ListResourcesRequest) (*mcp.ListResourcesResult, error) {
 	//TODO implement me
@@ -147,5 +176,5 @@ func (m *mockMCPClient) Close() error {
 }
 
 func (m *mockMCPClient) OnNotification(handler func(notification mcp.JSONRPCNotification)) {
-	panic("implement me")
+	m.notificationHandlers++
 }
@@ -0,0 +1,116 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+var progressTokenSeq atomic.Int64
+
+type progressEntry struct {
+	ctx      context.Context
+	toolName string
+	handler  ProgressHandler
+}
+
+// notificationHub dispatches the notifications of one MCP client to the tools created from it.
+type notificationHub struct {
+	mu                  sync.Mutex
+	progress            map[string]*progressEntry
+	toolsChangedHandler func()
+}
+
+// sharedHubs: client.MCPClient vs the *notificationHub shared by the tools of GetTools.
+// A handler can not be removed from a client once added, so each client gets one hub only.
+var sharedHubs sync.Map
+
+func newNotificationHub(cli client.MCPClient) *notificationHub {
+	h := &notificationHub{progress: make(map[string]*progressEntry)}
+	cli.OnNotification(h.handle)
+	return h
+}
+
+// sharedNotificationHub returns the hub shared by the tools of the client, registering it on first use.
+func sharedNotificationHub(cli client.MCPClient) *notificationHub {
+	if h, ok := sharedHubs.Load(cli); ok {
+		return h.(*notificationHub)
+	}
+
+	h := &notificationHub{progress: make(map[string]*progressEntry)}
+	actual, loaded := sharedHubs.LoadOrStore(cli, h)
+	if !loaded {
+		cli.OnNotification(h.handle)
+	}
+	return actual.(*notificationHub)
+}
+
+func (h *notificationHub) registerProgress(ctx context.Context, toolName string, handler ProgressHandler) (token string, unregister func()) {
+	token = fmt.Sprintf("eino-%d", progressTokenSeq.Add(1))
+
+	h.mu.Lock()
+	h.progress[token] = &progressEntry{ctx: ctx, toolName: toolName, handler: handler}
+	h.mu.Unlock()
+
+	return token, func() {
+		h.mu.Lock()
+		delete(h.progress, token)
+		h.mu.Unlock()
+	}
+}
+
+func (h *notificationHub) onToolsChanged(handler func()) {
+	h.mu.Lock()
+	h.toolsChangedHandler = handler
+	h.mu.Unlock()
+}
+
+func (h *notificationHub) handle(notification mcp.JSONRPCNotification) {
+	switch notification.Method {
+	case "notifications/progress":
+		h.handleProgress(notification.Params.AdditionalFields)
+	case mcp.MethodNotificationToolsListChanged:
+		h.mu.Lock()
+		handler := h.toolsChangedHandler
+		h.mu.Unlock()
+		if handler != nil {
+			handler()
+		}
+	}
+}
+
+func (h *notificationHub) handleProgress(fields map[string]any) {
+	token := fmt.Sprint(fields["progressToken"])
+
+	h.mu.Lock()
+	entry, ok := h.progress[token]
+	h.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	progress := &Progress{ToolName: entry.toolName}
+	progress.Progress, _ = fields["progress"].(float64)
+	progress.Total, _ = fields["total"].(float64)
+	progress.Message, _ = fields["message"].(string)
+	entry.handler(entry.ctx, progress)
+}
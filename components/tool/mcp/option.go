@@ -0,0 +1,49 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mcp
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/components/tool"
+)
+
+// Progress is a progress notification sent by the MCP server during a tool call.
+type Progress struct {
+	// ToolName is the name of the tool being called
+	ToolName string
+	// Progress increases every time progress is made, even if the total is unknown
+	Progress float64
+	// Total is the total amount of work, zero if unknown
+	Total float64
+	// Message is an optional human readable description of the progress
+	Message string
+}
+
+// ProgressHandler handles the progress of a tool call, ctx is the context of the call.
+// It's invoked on the goroutine that receives messages from the MCP server, so it should return quickly.
+type ProgressHandler func(ctx context.Context, progress *Progress)
+
+type options struct {
+	progressHandler ProgressHandler
+}
+
+// WithProgressHandler sets the handler receiving the progress notifications of this tool call, it overrides Config.ProgressHandler.
+func WithProgressHandler(handler ProgressHandler) tool.Option {
+	return tool.WrapImplSpecificOptFn(func(o *options) {
+		o.progressHandler = handler
+	})
+}
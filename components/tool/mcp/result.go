@@ -0,0 +1,139 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/bytedance/sonic"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// MultimodalTool is implemented by the tools returned by GetTools and ToolSet.
+// Callers whose chat model accepts multimodal tool messages can use MultimodalRun to keep image, audio and resource content.
+type MultimodalTool interface {
+	tool.InvokableTool
+	MultimodalRun(ctx context.Context, argumentsInJSON string, opts ...tool.Option) ([]schema.ChatMessagePart, error)
+}
+
+// resultToText renders the content of a tool result as text, one line per content:
+// text is kept as is, text resources are inlined, and other content is replaced by a short placeholder.
+func resultToText(result *mcp.CallToolResult) string {
+	lines := make([]string, 0, len(result.Content))
+	for _, content := range result.Content {
+		switch c := content.(type) {
+		case mcp.TextContent:
+			lines = append(lines, c.Text)
+		case mcp.ImageContent:
+			lines = append(lines, fmt.Sprintf("[image: %s]", c.MIMEType))
+		case mcp.AudioContent:
+			lines = append(lines, fmt.Sprintf("[audio: %s]", c.MIMEType))
+		case mcp.EmbeddedResource:
+			switch r := c.Resource.(type) {
+			case mcp.TextResourceContents:
+				lines = append(lines, r.Text)
+			case mcp.BlobResourceContents:
+				lines = append(lines, fmt.Sprintf("[resource: %s]", describeResource(r.URI, r.MIMEType)))
+			default:
+				lines = append(lines, marshalContent(c))
+			}
+		default:
+			lines = append(lines, marshalContent(content))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// resultToParts converts the content of a tool result to message parts, binary content is embedded as data urls.
+func resultToParts(result *mcp.CallToolResult) []schema.ChatMessagePart {
+	parts := make([]schema.ChatMessagePart, 0, len(result.Content))
+	for _, content := range result.Content {
+		switch c := content.(type) {
+		case mcp.TextContent:
+			parts = append(parts, textPart(c.Text))
+		case mcp.ImageContent:
+			parts = append(parts, schema.ChatMessagePart{
+				Type: schema.ChatMessagePartTypeImageURL,
+				ImageURL: &schema.ChatMessageImageURL{
+					URL:      dataURL(c.MIMEType, c.Data),
+					MIMEType: c.MIMEType,
+				},
+			})
+		case mcp.AudioContent:
+			parts = append(parts, schema.ChatMessagePart{
+				Type: schema.ChatMessagePartTypeAudioURL,
+				AudioURL: &schema.ChatMessageAudioURL{
+					URL:      dataURL(c.MIMEType, c.Data),
+					MIMEType: c.MIMEType,
+				},
+			})
+		case mcp.EmbeddedResource:
+			switch r := c.Resource.(type) {
+			case mcp.TextResourceContents:
+				parts = append(parts, textPart(r.Text))
+			case mcp.BlobResourceContents:
+				parts = append(parts, schema.ChatMessagePart{
+					Type: schema.ChatMessagePartTypeFileURL,
+					FileURL: &schema.ChatMessageFileURL{
+						URL:      dataURL(r.MIMEType, r.Blob),
+						URI:      r.URI,
+						MIMEType: r.MIMEType,
+						Name:     path.Base(r.URI),
+					},
+				})
+			default:
+				parts = append(parts, textPart(marshalContent(c)))
+			}
+		default:
+			parts = append(parts, textPart(marshalContent(content)))
+		}
+	}
+	return parts
+}
+
+func textPart(text string) schema.ChatMessagePart {
+	return schema.ChatMessagePart{
+		Type: schema.ChatMessagePartTypeText,
+		Text: text,
+	}
+}
+
+func dataURL(mimeType, base64Data string) string {
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+	return "data:" + mimeType + ";base64," + base64Data
+}
+
+func describeResource(uri, mimeType string) string {
+	if mimeType == "" {
+		return uri
+	}
+	return fmt.Sprintf("%s (%s)", uri, mimeType)
+}
+
+func marshalContent(content any) string {
+	marshaled, err := sonic.MarshalString(content)
+	if err != nil {
+		return fmt.Sprintf("%v", content)
+	}
+	return marshaled
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mcp
+
+import (
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultConversion(t *testing.T) {
+	result := &mcp.CallToolResult{
+		Content: []mcp.Content{
+			mcp.NewTextContent("first"),
+			mcp.NewImageContent("aW1n", "image/png"),
+			mcp.AudioContent{Type: "audio", Data: "YXVkaW8=", MIMEType: "audio/wav"},
+			mcp.NewEmbeddedResource(mcp.TextResourceContents{URI: "file:///notes.txt", MIMEType: "text/plain", Text: "notes"}),
+			mcp.NewEmbeddedResource(mcp.BlobResourceContents{URI: "file:///report.pdf", MIMEType: "application/pdf", Blob: "cGRm"}),
+		},
+	}
+
+	assert.Equal(t, "first\n[image: image/png]\n[audio: audio/wav]\nnotes\n[resource: file:///report.pdf (application/pdf)]", resultToText(result))
+
+	assert.Equal(t, []schema.ChatMessagePart{
+		{Type: schema.ChatMessagePartTypeText, Text: "first"},
+		{Type: schema.ChatMessagePartTypeImageURL, ImageURL: &schema.ChatMessageImageURL{URL: "data:image/png;base64,aW1n", MIMEType: "image/png"}},
+		{Type: schema.ChatMessagePartTypeAudioURL, AudioURL: &schema.ChatMessageAudioURL{URL: "data:audio/wav;base64,YXVkaW8=", MIMEType: "audio/wav"}},
+		{Type: schema.ChatMessagePartTypeText, Text: "notes"},
+		{Type: schema.ChatMessagePartTypeFileURL, FileURL: &schema.ChatMessageFileURL{
+			URL: "data:application/pdf;base64,cGRm", URI: "file:///report.pdf", MIMEType: "application/pdf", Name: "report.pdf",
+		}},
+	}, resultToParts(result))
+}
@@ -0,0 +1,237 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/bytedance/sonic"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	defaultServerName    = "eino-mcp-server"
+	defaultServerVersion = "1.0.0"
+)
+
+type ServerConfig struct {
+	// Name is the server name reported to MCP clients during initialization
+	// Optional. Default: "eino-mcp-server"
+	Name string
+	// Version is the server version reported to MCP clients during initialization
+	// Optional. Default: "1.0.0"
+	Version string
+	// Tools are the eino tools to expose, each must implement tool.InvokableTool or tool.StreamableTool
+	// Tool names must be unique
+	Tools []tool.BaseTool
+	// ServerOptions are passed through to server.NewMCPServer, e.g. server.WithLogging()
+	// Optional.
+	ServerOptions []server.ServerOption
+}
+
+// NewServer creates an MCP server which serves the configured eino tools.
+// The returned server can be served by any mcp-go transport, see ServeStdio, NewSSEServer and NewStreamableHTTPServer.
+func NewServer(ctx context.Context, conf *ServerConfig) (*server.MCPServer, error) {
+	if conf == nil {
+		return nil, errors.New("server config is required")
+	}
+
+	serverTools, err := toServerTools(ctx, conf.Tools)
+	if err != nil {
+		return nil, err
+	}
+
+	name := conf.Name
+	if name == "" {
+		name = defaultServerName
+	}
+	version := conf.Version
+	if version == "" {
+		version = defaultServerVersion
+	}
+
+	opts := append([]server.ServerOption{server.WithToolCapabilities(false)}, conf.ServerOptions...)
+	svr := server.NewMCPServer(name, version, opts...)
+	svr.AddTools(serverTools...)
+
+	return svr, nil
+}
+
+// ServeStdio serves the configured eino tools over stdin/stdout until ctx is done or stdin is closed.
+func ServeStdio(ctx context.Context, conf *ServerConfig, opts ...server.StdioOption) error {
+	return serveStdio(ctx, conf, os.Stdin, os.Stdout, opts...)
+}
+
+func serveStdio(ctx context.Context, conf *ServerConfig, stdin io.Reader, stdout io.Writer, opts ...server.StdioOption) error {
+	svr, err := NewServer(ctx, conf)
+	if err != nil {
+		return err
+	}
+
+	stdioServer := server.NewStdioServer(svr)
+	for _, opt := range opts {
+		opt(stdioServer)
+	}
+	err = stdioServer.Listen(ctx, stdin, stdout)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
+
+// NewSSEServer creates an SSE transport server which serves the configured eino tools.
+// Call Start to listen on an address, or mount it as an http.Handler.
+func NewSSEServer(ctx context.Context, conf *ServerConfig, opts ...server.SSEOption) (*server.SSEServer, error) {
+	svr, err := NewServer(ctx, conf)
+	if err != nil {
+		return nil, err
+	}
+	return server.NewSSEServer(svr, opts...), nil
+}
+
+// NewStreamableHTTPServer creates a streamable HTTP transport server which serves the configured eino tools.
+// Call Start to listen on an address, or mount it as an http.Handler.
+func NewStreamableHTTPServer(ctx context.Context, conf *ServerConfig, opts ...server.StreamableHTTPOption) (*server.StreamableHTTPServer, error) {
+	svr, err := NewServer(ctx, conf)
+	if err != nil {
+		return nil, err
+	}
+	return server.NewStreamableHTTPServer(svr, opts...), nil
+}
+
+func toServerTools(ctx context.Context, tools []tool.BaseTool) ([]server.ServerTool, error) {
+	names := make(map[string]struct{}, len(tools))
+	ret := make([]server.ServerTool, 0, len(tools))
+	for _, t := range tools {
+		if t == nil {
+			return nil, errors.New("tool is nil")
+		}
+
+		mcpTool, err := toMCPTool(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := names[mcpTool.Name]; ok {
+			return nil, fmt.Errorf("duplicate tool name: %s", mcpTool.Name)
+		}
+		names[mcpTool.Name] = struct{}{}
+
+		handler, err := toolHandler(mcpTool.Name, t)
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, server.ServerTool{
+			Tool:    mcpTool,
+			Handler: handler,
+		})
+	}
+	return ret, nil
+}
+
+func toMCPTool(ctx context.Context, t tool.BaseTool) (mcp.Tool, error) {
+	info, err := t.Info(ctx)
+	if err != nil {
+		return mcp.Tool{}, fmt.Errorf("get tool info fail: %w", err)
+	}
+	if info == nil || info.Name == "" {
+		return mcp.Tool{}, errors.New("tool info or tool name is empty")
+	}
+
+	inputSchema, err := info.ParamsOneOf.ToOpenAPIV3()
+	if err != nil {
+		return mcp.Tool{}, fmt.Errorf("conv tool params to json schema fail: %w, tool name: %s", err, info.Name)
+	}
+	if inputSchema == nil {
+		inputSchema = &openapi3.Schema{}
+	}
+	if inputSchema.Type == "" {
+		// MCP requires the input schema of a tool to be an object schema
+		sc := *inputSchema
+		sc.Type = openapi3.TypeObject
+		inputSchema = &sc
+	}
+
+	marshaledInputSchema, err := sonic.Marshal(inputSchema)
+	if err != nil {
+		return mcp.Tool{}, fmt.Errorf("conv tool params to json schema fail(marshal): %w, tool name: %s", err, info.Name)
+	}
+
+	return mcp.Tool{
+		Name:           info.Name,
+		Description:    info.Desc,
+		RawInputSchema: marshaledInputSchema,
+	}, nil
+}
+
+func toolHandler(name string, t tool.BaseTool) (server.ToolHandlerFunc, error) {
+	var run func(ctx context.Context, argumentsInJSON string) (string, error)
+	switch tt := t.(type) {
+	case tool.InvokableTool:
+		run = func(ctx context.Context, argumentsInJSON string) (string, error) {
+			return tt.InvokableRun(ctx, argumentsInJSON)
+		}
+	case tool.StreamableTool:
+		run = func(ctx context.Context, argumentsInJSON string) (string, error) {
+			sr, err := tt.StreamableRun(ctx, argumentsInJSON)
+			if err != nil {
+				return "", err
+			}
+			defer sr.Close()
+
+			var sb strings.Builder
+			for {
+				chunk, err := sr.Recv()
+				if errors.Is(err, io.EOF) {
+					return sb.String(), nil
+				}
+				if err != nil {
+					return "", err
+				}
+				sb.WriteString(chunk)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("tool %s implements neither tool.InvokableTool nor tool.StreamableTool", name)
+	}
+
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		argumentsInJSON := "{}"
+		if args := request.GetRawArguments(); args != nil {
+			marshaled, err := sonic.MarshalString(args)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal tool arguments: %w", err)
+			}
+			argumentsInJSON = marshaled
+		}
+
+		// errors returned by the tool are reported inside the result, so that the calling model can see them
+		output, err := run(ctx, argumentsInJSON)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText(output), nil
+	}, nil
+}
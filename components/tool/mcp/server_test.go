@@ -0,0 +1,241 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+	svr, err := NewServer(ctx, &ServerConfig{
+		Tools: []tool.BaseTool{&echoTool{}, &streamTool{}, &failTool{}},
+	})
+	require.NoError(t, err)
+
+	cli, err := client.NewInProcessClient(svr)
+	require.NoError(t, err)
+	defer cli.Close()
+	initClient(t, ctx, cli)
+
+	listResult, err := cli.ListTools(ctx, mcp.ListToolsRequest{})
+	require.NoError(t, err)
+	require.Len(t, listResult.Tools, 3)
+
+	// tools are listed in name order
+	assert.Equal(t, "echo", listResult.Tools[0].Name)
+	assert.Equal(t, "echo the input", listResult.Tools[0].Description)
+	marshaled, err := json.Marshal(listResult.Tools[0].InputSchema)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "object",
+		"properties": {"input": {"type": "string", "description": "text to echo"}},
+		"required": ["input"]
+	}`, string(marshaled))
+	// tools without params still get an object schema
+	assert.Equal(t, "stream", listResult.Tools[2].Name)
+	assert.Equal(t, "object", listResult.Tools[2].InputSchema.Type)
+
+	// round trip through the client side wrapper
+	tools, err := GetTools(ctx, &Config{Cli: cli})
+	require.NoError(t, err)
+	require.Len(t, tools, 3)
+
+	info, err := tools[0].Info(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "echo", info.Name)
+	assert.Equal(t, "echo the input", info.Desc)
+
+	result, err := tools[0].(tool.InvokableTool).InvokableRun(ctx, `{"input":"hello"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", result)
+
+	_, err = tools[1].(tool.InvokableTool).InvokableRun(ctx, `{}`)
+	assert.ErrorContains(t, err, "something went wrong")
+
+	result, err = tools[2].(tool.InvokableTool).InvokableRun(ctx, `{}`)
+	require.NoError(t, err)
+	assert.Equal(t, "a b c", result)
+}
+
+func TestServerConfigError(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewServer(ctx, nil)
+	assert.Error(t, err)
+
+	_, err = NewServer(ctx, &ServerConfig{Tools: []tool.BaseTool{&echoTool{}, &echoTool{}}})
+	assert.ErrorContains(t, err, "duplicate tool name: echo")
+
+	_, err = NewServer(ctx, &ServerConfig{Tools: []tool.BaseTool{&baseOnlyTool{}}})
+	assert.ErrorContains(t, err, "implements neither")
+}
+
+func TestServeStdio(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stdinReader, stdinWriter := io.Pipe()
+	stdoutReader, stdoutWriter := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		done <- serveStdio(ctx, &ServerConfig{Tools: []tool.BaseTool{&echoTool{}}}, stdinReader, stdoutWriter)
+	}()
+
+	reader := bufio.NewReader(stdoutReader)
+	call := func(req string) map[string]any {
+		_, err := stdinWriter.Write([]byte(req + "\n"))
+		require.NoError(t, err)
+		line, err := reader.ReadBytes('\n')
+		require.NoError(t, err)
+		resp := map[string]any{}
+		require.NoError(t, json.Unmarshal(line, &resp))
+		return resp
+	}
+
+	resp := call(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","clientInfo":{"name":"test","version":"1.0.0"},"capabilities":{}}}`)
+	assert.Equal(t, "eino-mcp-server", resp["result"].(map[string]any)["serverInfo"].(map[string]any)["name"])
+
+	resp = call(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"echo","arguments":{"input":"hi"}}}`)
+	content := resp["result"].(map[string]any)["content"].([]any)
+	assert.Equal(t, "hi", content[0].(map[string]any)["text"])
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("stdio server did not stop after cancel")
+	}
+}
+
+func TestHTTPTransports(t *testing.T) {
+	ctx := context.Background()
+	conf := &ServerConfig{Name: "test", Version: "0.1.0", Tools: []tool.BaseTool{&echoTool{}}}
+
+	t.Run("streamable http", func(t *testing.T) {
+		svr, err := NewStreamableHTTPServer(ctx, conf)
+		require.NoError(t, err)
+		ts := httptest.NewServer(svr)
+		defer ts.Close()
+
+		cli, err := client.NewStreamableHttpClient(ts.URL + "/mcp")
+		require.NoError(t, err)
+		defer cli.Close()
+		callEcho(t, ctx, cli)
+	})
+
+	t.Run("sse", func(t *testing.T) {
+		ts := httptest.NewUnstartedServer(nil)
+		svr, err := NewSSEServer(ctx, conf, server.WithBaseURL("http://"+ts.Listener.Addr().String()))
+		require.NoError(t, err)
+		ts.Config.Handler = svr
+		ts.Start()
+		defer ts.Close()
+
+		cli, err := client.NewSSEMCPClient(ts.URL + "/sse")
+		require.NoError(t, err)
+		defer cli.Close()
+		callEcho(t, ctx, cli)
+	})
+}
+
+func callEcho(t *testing.T, ctx context.Context, cli *client.Client) {
+	require.NoError(t, cli.Start(ctx))
+	initResult := initClient(t, ctx, cli)
+	assert.Equal(t, "test", initResult.ServerInfo.Name)
+	assert.Equal(t, "0.1.0", initResult.ServerInfo.Version)
+
+	tools, err := GetTools(ctx, &Config{Cli: cli})
+	require.NoError(t, err)
+	require.Len(t, tools, 1)
+	result, err := tools[0].(tool.InvokableTool).InvokableRun(ctx, `{"input":"over http"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "over http", result)
+}
+
+func initClient(t *testing.T, ctx context.Context, cli *client.Client) *mcp.InitializeResult {
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{Name: "test-client", Version: "1.0.0"}
+	result, err := cli.Initialize(ctx, initRequest)
+	require.NoError(t, err)
+	return result
+}
+
+type echoTool struct{}
+
+func (e *echoTool) Info(ctx context.Context) (*schema.ToolInfo, error) {
+	return &schema.ToolInfo{
+		Name: "echo",
+		Desc: "echo the input",
+		ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+			"input": {Type: schema.String, Desc: "text to echo", Required: true},
+		}),
+	}, nil
+}
+
+func (e *echoTool) InvokableRun(ctx context.Context, argumentsInJSON string, opts ...tool.Option) (string, error) {
+	args := struct {
+		Input string `json:"input"`
+	}{}
+	if err := json.Unmarshal([]byte(argumentsInJSON), &args); err != nil {
+		return "", err
+	}
+	return args.Input, nil
+}
+
+type streamTool struct{}
+
+func (s *streamTool) Info(ctx context.Context) (*schema.ToolInfo, error) {
+	return &schema.ToolInfo{Name: "stream", Desc: "stream some words"}, nil
+}
+
+func (s *streamTool) StreamableRun(ctx context.Context, argumentsInJSON string, opts ...tool.Option) (*schema.StreamReader[string], error) {
+	return schema.StreamReaderFromArray([]string{"a", " b", " c"}), nil
+}
+
+type failTool struct{}
+
+func (f *failTool) Info(ctx context.Context) (*schema.ToolInfo, error) {
+	return &schema.ToolInfo{Name: "fail", Desc: "always fails"}, nil
+}
+
+func (f *failTool) InvokableRun(ctx context.Context, argumentsInJSON string, opts ...tool.Option) (string, error) {
+	return "", errors.New("something went wrong")
+}
+
+type baseOnlyTool struct{}
+
+func (b *baseOnlyTool) Info(ctx context.Context) (*schema.ToolInfo, error) {
+	return &schema.ToolInfo{Name: "base"}, nil
+}
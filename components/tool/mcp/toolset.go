@@ -0,0 +1,108 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mcp
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cloudwego/eino/components/tool"
+)
+
+// ToolSet holds the tools of an MCP server and keeps them up to date:
+// the tools are fetched again after the server sends notifications/tools/list_changed.
+type ToolSet struct {
+	conf *Config
+	hub  *notificationHub
+
+	mu    sync.Mutex
+	tools []tool.BaseTool
+	stale bool
+}
+
+// NewToolSet fetches the tools of the MCP server and subscribes to tool list changes.
+func NewToolSet(ctx context.Context, conf *Config) (*ToolSet, error) {
+	s := &ToolSet{
+		conf: conf,
+		hub:  newNotificationHub(conf.Cli),
+	}
+	if err := s.Refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	s.hub.onToolsChanged(func() {
+		s.mu.Lock()
+		s.stale = true
+		s.mu.Unlock()
+
+		if conf.ToolsChangedHandler == nil {
+			return
+		}
+		// the notification is delivered on the goroutine reading server messages,
+		// listing tools there would wait for a response that can never be read
+		go func() {
+			ctx := context.WithoutCancel(ctx)
+			tools, err := s.Tools(ctx)
+			if err != nil {
+				// stays stale, the next call to Tools retries
+				return
+			}
+			conf.ToolsChangedHandler(ctx, tools)
+		}()
+	})
+
+	return s, nil
+}
+
+// Tools returns the current tools, fetching them again first if the server reported a change.
+func (s *ToolSet) Tools(ctx context.Context) ([]tool.BaseTool, error) {
+	s.mu.Lock()
+	stale := s.stale
+	tools := s.tools
+	s.mu.Unlock()
+
+	if !stale {
+		return tools, nil
+	}
+	if err := s.Refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tools, nil
+}
+
+// Refresh fetches the tools from the MCP server.
+func (s *ToolSet) Refresh(ctx context.Context) error {
+	s.mu.Lock()
+	// clear the flag before listing, so that a change reported meanwhile isn't lost
+	s.stale = false
+	s.mu.Unlock()
+
+	tools, err := getTools(ctx, s.conf, s.hub)
+	if err != nil {
+		s.mu.Lock()
+		s.stale = true
+		s.mu.Unlock()
+		return err
+	}
+
+	s.mu.Lock()
+	s.tools = tools
+	s.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mcp
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolSet(t *testing.T) {
+	ctx := context.Background()
+	svr := server.NewMCPServer("test", "1.0.0", server.WithToolCapabilities(true))
+	svr.AddTool(mcp.NewTool("slow", mcp.WithDescription("reports progress")), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if request.Params.Meta != nil && request.Params.Meta.ProgressToken != nil {
+			for i := 1; i <= 2; i++ {
+				err := server.ServerFromContext(ctx).SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+					"progressToken": request.Params.Meta.ProgressToken,
+					"progress":      i,
+					"total":         2,
+					"message":       "working",
+				})
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		return mcp.NewToolResultText("done"), nil
+	})
+
+	ts := httptest.NewUnstartedServer(nil)
+	ts.Config.Handler = server.NewSSEServer(svr, server.WithBaseURL("http://"+ts.Listener.Addr().String()))
+	ts.Start()
+	defer ts.Close()
+
+	cli, err := client.NewSSEMCPClient(ts.URL + "/sse")
+	require.NoError(t, err)
+	defer cli.Close()
+	require.NoError(t, cli.Start(ctx))
+	initClient(t, ctx, cli)
+
+	changed := make(chan []tool.BaseTool, 1)
+	toolSet, err := NewToolSet(ctx, &Config{
+		Cli: cli,
+		ToolsChangedHandler: func(ctx context.Context, tools []tool.BaseTool) {
+			changed <- tools
+		},
+	})
+	require.NoError(t, err)
+
+	tools, err := toolSet.Tools(ctx)
+	require.NoError(t, err)
+	require.Len(t, tools, 1)
+
+	t.Run("progress", func(t *testing.T) {
+		var (
+			mu       sync.Mutex
+			progress []Progress
+		)
+		result, err := tools[0].(tool.InvokableTool).InvokableRun(ctx, `{}`, WithProgressHandler(func(ctx context.Context, p *Progress) {
+			mu.Lock()
+			defer mu.Unlock()
+			progress = append(progress, *p)
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "done", result)
+
+		// notifications and the response travel separately, so the last notification may arrive after the result
+		assert.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(progress) == 2
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, Progress{ToolName: "slow", Progress: 1, Total: 2, Message: "working"}, progress[0])
+		assert.Equal(t, Progress{ToolName: "slow", Progress: 2, Total: 2, Message: "working"}, progress[1])
+	})
+
+	t.Run("list changed", func(t *testing.T) {
+		svr.AddTool(mcp.NewTool("added"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("added"), nil
+		})
+
+		select {
+		case tools := <-changed:
+			require.Len(t, tools, 2)
+			info, err := tools[0].Info(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, "added", info.Name)
+		case <-time.After(5 * time.Second):
+			t.Fatal("tools changed handler not called")
+		}
+
+		tools, err := toolSet.Tools(ctx)
+		require.NoError(t, err)
+		assert.Len(t, tools, 2)
+	})
+}
@@ -18,27 +18,16 @@ package devops
 
 import (
 	"context"
-	"time"
 
 	"github.com/cloudwego/eino-ext/devops/internal/apihandler"
 	"github.com/cloudwego/eino-ext/devops/internal/model"
-	"github.com/cloudwego/eino-ext/devops/internal/utils/safego"
 )
 
-// Init start eino devops server
-func Init(ctx context.Context, opts ...model.DevOption) error {
+// Init start eino devops server, and returns once it listens.
+// shutdown stops the server: the SSE streams of the running debug runs are drained until ctx is done, then cancelled.
+func Init(ctx context.Context, opts ...model.DevOption) (shutdown func(ctx context.Context) error, err error) {
 	opt := model.NewDevOpt(opts)
 	apihandler.InitDebug(opt)
 
-	errCh := make(chan error)
-	safego.Go(ctx, func() {
-		errCh <- apihandler.StartHTTPServer(ctx, opt.DevServerPort)
-	})
-
-	select {
-	case err := <-errCh:
-		return err
-	case <-time.After(2 * time.Second):
-		return nil
-	}
+	return apihandler.StartHTTPServer(ctx, opt)
 }
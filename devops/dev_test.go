@@ -27,7 +27,8 @@ import (
 func Test_Debug(t *testing.T) {
 	ctx := context.Background()
 	PatchConvey("Test success", t, func() {
-		actualErr := Init(ctx)
+		shutdown, actualErr := Init(ctx)
 		assert.Nil(t, actualErr)
+		assert.Nil(t, shutdown(ctx))
 	})
 }
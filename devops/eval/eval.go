@@ -0,0 +1,134 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package eval scores the outputs of a graph on a dataset of inputs, see devops.WithEvaluators to register
+// evaluators with the dev server.
+package eval
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Case is a case of a dataset, a line of its JSONL file, e.g.
+//
+//	{"id": "greeting", "input": {"query": "hi"}, "expected": "hello"}
+type Case struct {
+	// ID: the id of the case, its line number in the dataset if empty.
+	ID string `json:"id,omitempty"`
+	// Input: the input of the graph, json value.
+	Input json.RawMessage `json:"input"`
+	// Expected: the expected output of the graph, json value, optional.
+	Expected json.RawMessage `json:"expected,omitempty"`
+}
+
+// ParseDataset parses a JSONL dataset, skipping the blank lines.
+func ParseDataset(r io.Reader) ([]*Case, error) {
+	cases := make([]*Case, 0)
+	ids := make(map[string]bool)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		b := bytes.TrimSpace(scanner.Bytes())
+		if len(b) == 0 {
+			continue
+		}
+
+		c := &Case{}
+		if err := json.Unmarshal(b, c); err != nil {
+			return nil, fmt.Errorf("invalid case at line %d, err=%w", line, err)
+		}
+		if len(c.Input) == 0 {
+			return nil, fmt.Errorf("input of case at line %d is empty", line)
+		}
+		if c.ID == "" {
+			c.ID = strconv.Itoa(line)
+		}
+		if ids[c.ID] {
+			return nil, fmt.Errorf("duplicated case id=%s at line %d", c.ID, line)
+		}
+		ids[c.ID] = true
+
+		cases = append(cases, c)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read dataset failed, err=%w", err)
+	}
+	if len(cases) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	return cases, nil
+}
+
+// Sample is what an evaluator scores: a case, and the output of the graph for it.
+type Sample struct {
+	// Input: the input of the graph, json marshal string.
+	Input string
+	// Expected: the expected output, json marshal string, empty if the case has none.
+	Expected string
+	// Output: the output of the graph, json marshal string.
+	Output string
+}
+
+// Score is the result of an evaluator on a sample.
+type Score struct {
+	Evaluator string `json:"evaluator"`
+	Pass      bool   `json:"pass"`
+	// Score: between 0 and 1.
+	Score  float64 `json:"score"`
+	Reason string  `json:"reason,omitempty"`
+	// Error: set when the evaluator failed, the sample not passing.
+	Error string `json:"error,omitempty"`
+}
+
+// Evaluator scores the output of a graph.
+type Evaluator interface {
+	// Name identifies the evaluator in the reports, and in the evaluation requests for registered evaluators.
+	Name() string
+	Evaluate(ctx context.Context, sample *Sample) (*Score, error)
+}
+
+// Evaluate runs the evaluator on the sample, an error being reported in the score.
+func Evaluate(ctx context.Context, e Evaluator, sample *Sample) *Score {
+	score, err := e.Evaluate(ctx, sample)
+	if err != nil {
+		return &Score{Evaluator: e.Name(), Error: err.Error()}
+	}
+	if score == nil {
+		return &Score{Evaluator: e.Name(), Error: "no score"}
+	}
+	score.Evaluator = e.Name()
+
+	return score
+}
+
+// passScore returns the score of a pass or fail evaluation.
+func passScore(pass bool, reason string) *Score {
+	s := &Score{Pass: pass, Reason: reason}
+	if pass {
+		s.Score = 1
+	}
+	return s
+}
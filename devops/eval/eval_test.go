@@ -0,0 +1,177 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eval
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDataset(t *testing.T) {
+	cases, err := ParseDataset(strings.NewReader(`{"id": "a", "input": "hi", "expected": "hello"}
+
+{"input": {"query": "x"}}
+`))
+	assert.NoError(t, err)
+	if assert.Len(t, cases, 2) {
+		assert.Equal(t, "a", cases[0].ID)
+		assert.Equal(t, `"hello"`, string(cases[0].Expected))
+		assert.Equal(t, "3", cases[1].ID)
+		assert.Empty(t, cases[1].Expected)
+	}
+
+	_, err = ParseDataset(strings.NewReader(`{"input": 1`))
+	assert.Error(t, err)
+	_, err = ParseDataset(strings.NewReader(`{"id": "a"}`))
+	assert.Error(t, err)
+	_, err = ParseDataset(strings.NewReader("{\"id\": \"a\", \"input\": 1}\n{\"id\": \"a\", \"input\": 2}"))
+	assert.Error(t, err)
+	_, err = ParseDataset(strings.NewReader(" \n"))
+	assert.Error(t, err)
+}
+
+func TestExactMatch(t *testing.T) {
+	ctx := context.Background()
+	e := ExactMatch()
+
+	s := Evaluate(ctx, e, &Sample{Output: `{"a": 1, "b": [1, 2]}`, Expected: `{"b":[1,2],"a":1}`})
+	assert.True(t, s.Pass)
+	assert.Equal(t, 1.0, s.Score)
+	assert.Equal(t, ExactMatchName, s.Evaluator)
+
+	s = Evaluate(ctx, e, &Sample{Output: `"a"`, Expected: `"b"`})
+	assert.False(t, s.Pass)
+	assert.Empty(t, s.Error)
+
+	s = Evaluate(ctx, e, &Sample{Output: `"a"`})
+	assert.False(t, s.Pass)
+	assert.NotEmpty(t, s.Error)
+}
+
+func TestJSONPathEqual(t *testing.T) {
+	ctx := context.Background()
+	e, err := JSONPathEqual("$.choices[0].content")
+	assert.NoError(t, err)
+	assert.Equal(t, "json_path:$.choices[0].content", e.Name())
+
+	s := Evaluate(ctx, e, &Sample{
+		Output:   `{"choices": [{"content": "hi", "id": 1}]}`,
+		Expected: `{"choices": [{"content": "hi", "id": 2}]}`,
+	})
+	assert.True(t, s.Pass)
+
+	s = Evaluate(ctx, e, &Sample{
+		Output:   `{"choices": []}`,
+		Expected: `{"choices": [{"content": "hi"}]}`,
+	})
+	assert.False(t, s.Pass)
+	assert.Empty(t, s.Error)
+
+	s = Evaluate(ctx, e, &Sample{Output: `{}`, Expected: `{}`})
+	assert.NotEmpty(t, s.Error)
+
+	e, err = JSONPathEqual("[1][0]")
+	assert.NoError(t, err)
+	s = Evaluate(ctx, e, &Sample{Output: `[0, [5]]`, Expected: `[1, [5]]`})
+	assert.True(t, s.Pass)
+
+	_, err = JSONPathEqual("a[x]")
+	assert.Error(t, err)
+	_, err = JSONPathEqual("$")
+	assert.Error(t, err)
+}
+
+func TestRegexMatch(t *testing.T) {
+	ctx := context.Background()
+	e, err := RegexMatch(`^hello`)
+	assert.NoError(t, err)
+
+	assert.True(t, Evaluate(ctx, e, &Sample{Output: `"hello world"`}).Pass)
+	assert.False(t, Evaluate(ctx, e, &Sample{Output: `{"hello": 1}`}).Pass)
+
+	_, err = RegexMatch(`(`)
+	assert.Error(t, err)
+}
+
+type judgeModel struct {
+	reply string
+	err   error
+	input []*schema.Message
+}
+
+func (j *judgeModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	j.input = input
+	if j.err != nil {
+		return nil, j.err
+	}
+	return schema.AssistantMessage(j.reply, nil), nil
+}
+
+func (j *judgeModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func TestLLMJudge(t *testing.T) {
+	ctx := context.Background()
+	cm := &judgeModel{reply: "```json\n{\"score\": 0.8, \"reason\": \"close enough\"}\n```"}
+	e := LLMJudge(cm, WithJudgeName("judge"), WithJudgeCriteria("be polite"), WithJudgePassScore(0.9))
+	assert.Equal(t, "judge", e.Name())
+
+	s := Evaluate(ctx, e, &Sample{Input: `"hi"`, Expected: `"hello"`, Output: `"hey"`})
+	assert.Empty(t, s.Error)
+	assert.False(t, s.Pass)
+	assert.Equal(t, 0.8, s.Score)
+	assert.Equal(t, "close enough", s.Reason)
+	if assert.Len(t, cm.input, 2) {
+		assert.Contains(t, cm.input[0].Content, "be polite")
+		assert.Contains(t, cm.input[1].Content, `"hello"`)
+	}
+
+	cm.reply = "no idea"
+	assert.NotEmpty(t, Evaluate(ctx, e, &Sample{Output: `"hey"`}).Error)
+	cm.reply = `{"score": 2}`
+	assert.NotEmpty(t, Evaluate(ctx, e, &Sample{Output: `"hey"`}).Error)
+	cm.err = fmt.Errorf("rate limited")
+	assert.NotEmpty(t, Evaluate(ctx, e, &Sample{Output: `"hey"`}).Error)
+}
+
+func TestReport_Summarize(t *testing.T) {
+	r := &Report{
+		Total: 4,
+		Cases: []*CaseResult{
+			{CaseID: "1", Pass: true, Scores: []*Score{{Evaluator: "a", Pass: true, Score: 1}, {Evaluator: "b", Pass: true, Score: 0.5}}},
+			{CaseID: "2", Scores: []*Score{{Evaluator: "a", Score: 0}, {Evaluator: "b", Error: "failed"}}},
+			{CaseID: "3", Error: "graph failed"},
+		},
+	}
+	r.Summarize()
+
+	assert.Equal(t, 3, r.Finished)
+	assert.Equal(t, 1, r.Passed)
+	assert.Equal(t, 1, r.Errors)
+	assert.InDelta(t, 1.0/3, r.PassRate, 1e-9)
+	assert.Equal(t, []*EvaluatorSummary{
+		{Evaluator: "a", Passed: 1, Failed: 1, AvgScore: 0.5},
+		{Evaluator: "b", Passed: 1, Errors: 1, AvgScore: 0.25},
+	}, r.Evaluators)
+}
@@ -0,0 +1,219 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	ExactMatchName    = "exact_match"
+	JSONPathEqualName = "json_path"
+	RegexMatchName    = "regex"
+)
+
+type exactMatch struct{}
+
+// ExactMatch passes when the output equals the expected value, both compared as json values so that
+// the formatting and the order of the keys do not matter.
+func ExactMatch() Evaluator {
+	return exactMatch{}
+}
+
+func (exactMatch) Name() string {
+	return ExactMatchName
+}
+
+func (exactMatch) Evaluate(_ context.Context, sample *Sample) (*Score, error) {
+	if sample.Expected == "" {
+		return nil, fmt.Errorf("no expected output")
+	}
+
+	output, err := decodeJSON(sample.Output)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := decodeJSON(sample.Expected)
+	if err != nil {
+		return nil, err
+	}
+
+	if reflect.DeepEqual(output, expected) {
+		return passScore(true, ""), nil
+	}
+	return passScore(false, fmt.Sprintf("output %s != expected %s", sample.Output, sample.Expected)), nil
+}
+
+type jsonPathEqual struct {
+	path  string
+	steps []pathStep
+}
+
+// JSONPathEqual passes when the value at the path is the same in the output and the expected value.
+// The path is a dotted path with array indexes, e.g. $.choices[0].content, the leading $ being optional.
+func JSONPathEqual(path string) (Evaluator, error) {
+	steps, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonPathEqual{path: path, steps: steps}, nil
+}
+
+func (j *jsonPathEqual) Name() string {
+	return JSONPathEqualName + ":" + j.path
+}
+
+func (j *jsonPathEqual) Evaluate(_ context.Context, sample *Sample) (*Score, error) {
+	if sample.Expected == "" {
+		return nil, fmt.Errorf("no expected output")
+	}
+
+	output, err := decodeJSON(sample.Output)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := decodeJSON(sample.Expected)
+	if err != nil {
+		return nil, err
+	}
+
+	expectedValue, ok := lookupPath(expected, j.steps)
+	if !ok {
+		return nil, fmt.Errorf("path %s not found in expected output", j.path)
+	}
+	outputValue, ok := lookupPath(output, j.steps)
+	if !ok {
+		return passScore(false, fmt.Sprintf("path %s not found in output", j.path)), nil
+	}
+
+	if reflect.DeepEqual(outputValue, expectedValue) {
+		return passScore(true, ""), nil
+	}
+	return passScore(false, fmt.Sprintf("%v != expected %v at %s", outputValue, expectedValue, j.path)), nil
+}
+
+type regexMatch struct {
+	re *regexp.Regexp
+}
+
+// RegexMatch passes when the output matches the pattern, a string output being matched without its quotes.
+func RegexMatch(pattern string) (Evaluator, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern=%s, err=%w", pattern, err)
+	}
+	return &regexMatch{re: re}, nil
+}
+
+func (r *regexMatch) Name() string {
+	return RegexMatchName + ":" + r.re.String()
+}
+
+func (r *regexMatch) Evaluate(_ context.Context, sample *Sample) (*Score, error) {
+	text := outputText(sample.Output)
+	if r.re.MatchString(text) {
+		return passScore(true, ""), nil
+	}
+	return passScore(false, fmt.Sprintf("output does not match %s", r.re.String())), nil
+}
+
+// outputText unquotes a json string, other values being kept as json.
+func outputText(output string) string {
+	var s string
+	if err := json.Unmarshal([]byte(output), &s); err == nil {
+		return s
+	}
+	return output
+}
+
+func decodeJSON(s string) (any, error) {
+	var v any
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return nil, fmt.Errorf("invalid json=%s, err=%w", s, err)
+	}
+	return v, nil
+}
+
+// pathStep is a key of an object, or an index of an array if key is empty.
+type pathStep struct {
+	key   string
+	index int
+}
+
+func parsePath(path string) ([]pathStep, error) {
+	p := strings.TrimPrefix(strings.TrimSpace(path), "$")
+	p = strings.TrimPrefix(p, ".")
+
+	steps := make([]pathStep, 0)
+	for _, part := range strings.Split(p, ".") {
+		key := part
+		if i := strings.Index(part, "["); i >= 0 {
+			key = part[:i]
+		}
+		if key != "" {
+			steps = append(steps, pathStep{key: key})
+		}
+
+		rest := part[len(key):]
+		for rest != "" {
+			end := strings.Index(rest, "]")
+			if rest[0] != '[' || end < 0 {
+				return nil, fmt.Errorf("invalid json path=%s", path)
+			}
+			index, err := strconv.Atoi(rest[1:end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid index in json path=%s", path)
+			}
+			steps = append(steps, pathStep{index: index})
+			rest = rest[end+1:]
+		}
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("empty json path")
+	}
+
+	return steps, nil
+}
+
+func lookupPath(v any, steps []pathStep) (any, bool) {
+	for _, step := range steps {
+		if step.key != "" {
+			m, ok := v.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			if v, ok = m[step.key]; !ok {
+				return nil, false
+			}
+			continue
+		}
+
+		arr, ok := v.([]any)
+		if !ok || step.index >= len(arr) {
+			return nil, false
+		}
+		v = arr[step.index]
+	}
+
+	return v, true
+}
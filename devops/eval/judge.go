@@ -0,0 +1,138 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+const LLMJudgeName = "llm_judge"
+
+const defaultJudgeCriteria = "The output answers the input correctly and completely. " +
+	"If an expected output is given, the output must convey the same meaning, the wording may differ."
+
+const judgePrompt = `You are a strict evaluator of the outputs of an AI application.
+Evaluate the output against the criteria below, and reply with a json object only, without markdown:
+{"score": <number between 0 and 1>, "reason": "<one sentence>"}
+
+Criteria:
+%s`
+
+type llmJudge struct {
+	cm        model.BaseChatModel
+	name      string
+	criteria  string
+	passScore float64
+}
+
+type JudgeOption func(*llmJudge)
+
+// WithJudgeName sets the name of the judge, default to llm_judge, to register several judges.
+func WithJudgeName(name string) JudgeOption {
+	return func(j *llmJudge) {
+		j.name = name
+	}
+}
+
+// WithJudgeCriteria sets what the judge checks, in natural language.
+func WithJudgeCriteria(criteria string) JudgeOption {
+	return func(j *llmJudge) {
+		j.criteria = criteria
+	}
+}
+
+// WithJudgePassScore sets the minimum score to pass, default to 0.5.
+func WithJudgePassScore(score float64) JudgeOption {
+	return func(j *llmJudge) {
+		j.passScore = score
+	}
+}
+
+// LLMJudge asks the chat model to score the output against criteria, see WithJudgeCriteria.
+func LLMJudge(cm model.BaseChatModel, opts ...JudgeOption) Evaluator {
+	j := &llmJudge{
+		cm:        cm,
+		name:      LLMJudgeName,
+		criteria:  defaultJudgeCriteria,
+		passScore: 0.5,
+	}
+	for _, opt := range opts {
+		opt(j)
+	}
+	return j
+}
+
+func (j *llmJudge) Name() string {
+	return j.name
+}
+
+func (j *llmJudge) Evaluate(ctx context.Context, sample *Sample) (*Score, error) {
+	sb := &strings.Builder{}
+	_, _ = fmt.Fprintf(sb, "Input:\n%s\n\n", sample.Input)
+	if sample.Expected != "" {
+		_, _ = fmt.Fprintf(sb, "Expected output:\n%s\n\n", sample.Expected)
+	}
+	_, _ = fmt.Fprintf(sb, "Output:\n%s", sample.Output)
+
+	msg, err := j.cm.Generate(ctx, []*schema.Message{
+		schema.SystemMessage(fmt.Sprintf(judgePrompt, j.criteria)),
+		schema.UserMessage(sb.String()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("judge generate failed, err=%w", err)
+	}
+
+	verdict, err := parseVerdict(msg.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Score{
+		Pass:   verdict.Score >= j.passScore,
+		Score:  verdict.Score,
+		Reason: verdict.Reason,
+	}, nil
+}
+
+type verdict struct {
+	Score  float64 `json:"score"`
+	Reason string  `json:"reason"`
+}
+
+// parseVerdict takes the json object of the reply, the models wrapping it in markdown or text at times.
+func parseVerdict(content string) (*verdict, error) {
+	start, end := strings.Index(content, "{"), strings.LastIndex(content, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("no verdict in judge reply=%s", content)
+	}
+
+	v := &verdict{}
+	if err := json.Unmarshal([]byte(content[start:end+1]), v); err != nil {
+		return nil, fmt.Errorf("invalid verdict in judge reply=%s, err=%w", content, err)
+	}
+	if v.Score < 0 || v.Score > 1 {
+		return nil, fmt.Errorf("verdict score=%v out of [0, 1]", v.Score)
+	}
+
+	return v, nil
+}
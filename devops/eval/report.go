@@ -0,0 +1,120 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eval
+
+type ReportStatus string
+
+const (
+	ReportStatusRunning ReportStatus = "running"
+	ReportStatusDone    ReportStatus = "done"
+)
+
+// Report is the result of the evaluation of a graph on a dataset.
+type Report struct {
+	ID          string       `json:"id"`
+	GraphID     string       `json:"graph_id"`
+	GraphName   string       `json:"graph_name"`
+	DatasetID   string       `json:"dataset_id"`
+	DatasetName string       `json:"dataset_name"`
+	Status      ReportStatus `json:"status"`
+
+	// Total: the number of cases, Finished being the ones run so far.
+	Total    int `json:"total"`
+	Finished int `json:"finished"`
+	// Passed: the cases that ran without error and passed all the evaluators.
+	Passed int `json:"passed"`
+	// Errors: the cases the graph failed on.
+	Errors   int     `json:"errors"`
+	PassRate float64 `json:"pass_rate"`
+
+	Evaluators []*EvaluatorSummary `json:"evaluators,omitempty"`
+	Cases      []*CaseResult       `json:"cases,omitempty"`
+
+	// File: where the report is written once done.
+	File string `json:"file,omitempty"`
+
+	StartTimeMS int64 `json:"start_time_ms"`
+	EndTimeMS   int64 `json:"end_time_ms,omitempty"`
+}
+
+type EvaluatorSummary struct {
+	Evaluator string `json:"evaluator"`
+	Passed    int    `json:"passed"`
+	Failed    int    `json:"failed"`
+	// Errors: the samples the evaluator failed on.
+	Errors   int     `json:"errors"`
+	AvgScore float64 `json:"avg_score"`
+}
+
+type CaseResult struct {
+	CaseID string `json:"case_id"`
+	// Input, Expected and Output: json marshal strings.
+	Input    string `json:"input"`
+	Expected string `json:"expected,omitempty"`
+	Output   string `json:"output,omitempty"`
+	// Error: the error of the graph, no evaluator running then.
+	Error     string   `json:"error,omitempty"`
+	Scores    []*Score `json:"scores,omitempty"`
+	Pass      bool     `json:"pass"`
+	LatencyMS int64    `json:"latency_ms"`
+}
+
+// Summarize computes the counters of the report from its cases.
+func (r *Report) Summarize() {
+	r.Finished, r.Passed, r.Errors = len(r.Cases), 0, 0
+
+	summaries := make(map[string]*EvaluatorSummary)
+	r.Evaluators = make([]*EvaluatorSummary, 0)
+	for _, c := range r.Cases {
+		if c.Pass {
+			r.Passed++
+		}
+		if c.Error != "" {
+			r.Errors++
+		}
+
+		for _, s := range c.Scores {
+			summary, ok := summaries[s.Evaluator]
+			if !ok {
+				summary = &EvaluatorSummary{Evaluator: s.Evaluator}
+				summaries[s.Evaluator] = summary
+				r.Evaluators = append(r.Evaluators, summary)
+			}
+			switch {
+			case s.Error != "":
+				summary.Errors++
+			case s.Pass:
+				summary.Passed++
+			default:
+				summary.Failed++
+			}
+			// AvgScore holds the sum until all the cases are counted
+			summary.AvgScore += s.Score
+		}
+	}
+
+	for _, summary := range r.Evaluators {
+		if n := summary.Passed + summary.Failed + summary.Errors; n > 0 {
+			summary.AvgScore /= float64(n)
+		}
+	}
+
+	r.PassRate = 0
+	if r.Finished > 0 {
+		r.PassRate = float64(r.Passed) / float64(r.Finished)
+	}
+}
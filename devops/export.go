@@ -0,0 +1,154 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devops
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/devops/export"
+	"github.com/cloudwego/eino-ext/devops/internal/apihandler"
+	"github.com/cloudwego/eino-ext/devops/internal/model"
+	"github.com/cloudwego/eino-ext/devops/internal/service"
+	devmodel "github.com/cloudwego/eino-ext/devops/model"
+)
+
+// ExportGraphs writes the canvas of the graphs compiled since Init or ExportCommand to dir, in a file per graph
+// and format named after the graph, e.g. my_graph.mmd. All the graphs are written if graphNames is empty.
+func ExportGraphs(dir string, formats []export.Format, graphNames ...string) (files []string, err error) {
+	graphs := service.ContainerSVC.ListGraphs()
+
+	names := graphNames
+	if len(names) == 0 {
+		names = make([]string, 0, len(graphs))
+		for name := range graphs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create dir=%s failed, err=%w", dir, err)
+	}
+
+	for _, name := range names {
+		graphID, ok := graphs[name]
+		if !ok {
+			return files, fmt.Errorf("graph=%s not found", name)
+		}
+
+		canvas, err := service.ContainerSVC.CreateCanvas(graphID)
+		if err != nil {
+			return files, fmt.Errorf("create canvas of graph=%s failed, err=%w", name, err)
+		}
+
+		for _, format := range formats {
+			file := filepath.Join(dir, exportFileName(name)+format.Ext())
+			if err = exportFile(file, &canvas, format); err != nil {
+				return files, fmt.Errorf("export graph=%s to %s failed, err=%w", name, file, err)
+			}
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
+
+func exportFile(file string, canvas *devmodel.CanvasInfo, format export.Format) (err error) {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cErr := f.Close(); err == nil {
+			err = cErr
+		}
+	}()
+
+	return export.Write(f, canvas, format)
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// exportFileName turns a graph name into a file name, e.g. the generated file.func:line names.
+func exportFileName(graphName string) string {
+	name := strings.Trim(unsafeFileChars.ReplaceAllString(graphName, "_"), "_.")
+	if name == "" {
+		return "graph"
+	}
+	return name
+}
+
+// ExportCommand is a headless command writing graphs to files, without starting the dev server.
+// The graphs compiled by compile are registered, then written by ExportGraphs according to args:
+//
+//	-out dir      the directory of the files, default to the working directory
+//	-format list  comma separated formats among mermaid, dot and json, default to all of them
+//	-graph list   comma separated names of the graphs to write, default to all of them
+//
+// Example, in cmd/graphs/main.go:
+//
+//	func main() {
+//		err := devops.ExportCommand(context.Background(), os.Args[1:], func(ctx context.Context) error {
+//			_, err := mygraph.Build(ctx) // compiles the graph
+//			return err
+//		})
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+//
+// then: go run ./cmd/graphs -out docs/graphs -format mermaid
+func ExportCommand(ctx context.Context, args []string, compile func(ctx context.Context) error, opts ...model.DevOption) error {
+	fs := flag.NewFlagSet("devops", flag.ContinueOnError)
+	out := fs.String("out", ".", "the directory of the files")
+	formatList := fs.String("format", "mermaid,dot,json", "comma separated formats among mermaid, dot and json")
+	graphList := fs.String("graph", "", "comma separated names of the graphs to write, all of them if empty")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	formats, err := export.ParseFormats(*formatList)
+	if err != nil {
+		return err
+	}
+
+	var graphNames []string
+	for _, name := range strings.Split(*graphList, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			graphNames = append(graphNames, name)
+		}
+	}
+
+	apihandler.InitDebug(model.NewDevOpt(opts))
+	if err = compile(ctx); err != nil {
+		return fmt.Errorf("compile graphs failed, err=%w", err)
+	}
+
+	files, err := ExportGraphs(*out, formats, graphNames...)
+	for _, file := range files {
+		_, _ = fmt.Fprintln(fs.Output(), file)
+	}
+
+	return err
+}
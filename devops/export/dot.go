@@ -0,0 +1,101 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cloudwego/eino/compose"
+
+	"github.com/cloudwego/eino-ext/devops/model"
+)
+
+// DOT writes the graph as a Graphviz digraph. The subgraphs are clusters, the edges to and from them
+// being drawn from their start and end nodes.
+func DOT(w io.Writer, gs *model.GraphSchema) error {
+	if gs == nil {
+		return fmt.Errorf("graph schema is empty")
+	}
+
+	bw := bufio.NewWriter(w)
+	_, _ = fmt.Fprintf(bw, "digraph %s {\n", dotID(gs.Name))
+	_, _ = fmt.Fprintln(bw, "    compound=true;")
+	_, _ = fmt.Fprintln(bw, "    node [shape=box];")
+	writeDOTGraph(bw, normalize(gs), "", 1)
+	_, _ = fmt.Fprintln(bw, "}")
+
+	return bw.Flush()
+}
+
+// writeDOTGraph writes the nodes and edges of the graph, the ids of its nodes being their keys after prefix.
+func writeDOTGraph(w io.Writer, gs *model.GraphSchema, prefix string, depth int) {
+	indent := strings.Repeat("    ", depth)
+
+	subGraphs := make(map[string]bool)
+	for _, node := range gs.Nodes {
+		id := prefix + node.Key
+		if node.GraphSchema != nil {
+			subGraphs[node.Key] = true
+			_, _ = fmt.Fprintf(w, "%ssubgraph %s {\n", indent, dotID("cluster_"+id))
+			_, _ = fmt.Fprintf(w, "%s    label=%s;\n", indent, dotID(nodeLabel(node)))
+			writeDOTGraph(w, node.GraphSchema, id+"/", depth+1)
+			_, _ = fmt.Fprintf(w, "%s}\n", indent)
+			continue
+		}
+
+		attrs := ""
+		switch node.Type {
+		case model.NodeTypeOfStart, model.NodeTypeOfEnd:
+			attrs = ", shape=oval"
+		case model.NodeTypeOfBranch:
+			attrs = ", shape=diamond"
+		case model.NodeTypeOfParallel:
+			attrs = ", shape=circle"
+		}
+		_, _ = fmt.Fprintf(w, "%s%s [label=%s%s];\n", indent, dotID(id), dotID(nodeLabel(node)), attrs)
+	}
+
+	for _, edge := range gs.Edges {
+		source, target := prefix+edge.SourceNodeKey, prefix+edge.TargetNodeKey
+
+		var attrs []string
+		if subGraphs[edge.SourceNodeKey] {
+			attrs = append(attrs, "ltail="+dotID("cluster_"+source))
+			source += "/" + compose.END
+		}
+		if subGraphs[edge.TargetNodeKey] {
+			attrs = append(attrs, "lhead="+dotID("cluster_"+target))
+			target += "/" + compose.START
+		}
+
+		if len(attrs) == 0 {
+			_, _ = fmt.Fprintf(w, "%s%s -> %s;\n", indent, dotID(source), dotID(target))
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "%s%s -> %s [%s];\n", indent, dotID(source), dotID(target), strings.Join(attrs, ", "))
+	}
+}
+
+var dotReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// dotID quotes the string, so that any key can be an id.
+func dotID(s string) string {
+	return `"` + dotReplacer.Replace(s) + `"`
+}
@@ -0,0 +1,191 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package export writes the canvas of a graph to files that can be read without the Eino Dev plugin,
+// e.g. to review graphs in pull requests and docs.
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino/compose"
+
+	"github.com/cloudwego/eino-ext/devops/model"
+)
+
+type Format string
+
+const (
+	// FormatMermaid is a Mermaid flowchart, rendered by GitHub in markdown files.
+	FormatMermaid Format = "mermaid"
+	// FormatDOT is a Graphviz digraph.
+	FormatDOT Format = "dot"
+	// FormatJSON is the canvas as served to the plugin, with stable ids and order so that it can be diffed.
+	FormatJSON Format = "json"
+)
+
+// Formats are all the supported formats.
+var Formats = []Format{FormatMermaid, FormatDOT, FormatJSON}
+
+// Ext returns the file extension of the format.
+func (f Format) Ext() string {
+	switch f {
+	case FormatMermaid:
+		return ".mmd"
+	case FormatDOT:
+		return ".dot"
+	case FormatJSON:
+		return ".json"
+	default:
+		return ""
+	}
+}
+
+// ParseFormats parses a comma separated list of formats, e.g. mermaid,dot.
+func ParseFormats(s string) ([]Format, error) {
+	formats := make([]Format, 0, len(Formats))
+	for _, f := range strings.Split(s, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		if Format(f).Ext() == "" {
+			return nil, fmt.Errorf("format=%s not supported", f)
+		}
+		formats = append(formats, Format(f))
+	}
+	if len(formats) == 0 {
+		return nil, fmt.Errorf("no format given")
+	}
+
+	return formats, nil
+}
+
+// Write writes the canvas in the format.
+func Write(w io.Writer, canvas *model.CanvasInfo, format Format) error {
+	if canvas == nil || canvas.GraphSchema == nil {
+		return fmt.Errorf("canvas is empty")
+	}
+
+	switch format {
+	case FormatMermaid:
+		return Mermaid(w, canvas.GraphSchema)
+	case FormatDOT:
+		return DOT(w, canvas.GraphSchema)
+	case FormatJSON:
+		return JSON(w, canvas)
+	default:
+		return fmt.Errorf("format=%s not supported", format)
+	}
+}
+
+// JSON writes the canvas as indented json. The random ids of the graphs and edges are replaced with their
+// names, and the nodes and edges are sorted, so that the same graph is always written the same way.
+func JSON(w io.Writer, canvas *model.CanvasInfo) error {
+	if canvas == nil || canvas.GraphSchema == nil {
+		return fmt.Errorf("canvas is empty")
+	}
+
+	stable := &model.CanvasInfo{
+		Version:     canvas.Version,
+		GraphSchema: normalize(canvas.GraphSchema),
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(stable)
+}
+
+// normalize returns a copy of the graph with stable ids and order, the nodes of the same key being merged.
+func normalize(gs *model.GraphSchema) *model.GraphSchema {
+	ngs := *gs
+	ngs.ID = gs.Name
+
+	seen := make(map[string]bool, len(gs.Nodes))
+	ngs.Nodes = make([]*model.Node, 0, len(gs.Nodes))
+	for _, node := range gs.Nodes {
+		if seen[node.Key] {
+			continue
+		}
+		seen[node.Key] = true
+
+		nn := *node
+		if node.GraphSchema != nil {
+			nn.GraphSchema = normalize(node.GraphSchema)
+		}
+		ngs.Nodes = append(ngs.Nodes, &nn)
+	}
+	sort.SliceStable(ngs.Nodes, func(i, j int) bool {
+		return nodeLess(ngs.Nodes[i], ngs.Nodes[j])
+	})
+
+	seen = make(map[string]bool, len(gs.Edges))
+	ngs.Edges = make([]*model.Edge, 0, len(gs.Edges))
+	for _, edge := range gs.Edges {
+		ne := *edge
+		ne.ID = edge.Name
+		if seen[ne.ID] {
+			continue
+		}
+		seen[ne.ID] = true
+		ngs.Edges = append(ngs.Edges, &ne)
+	}
+	sort.SliceStable(ngs.Edges, func(i, j int) bool {
+		a, b := ngs.Edges[i], ngs.Edges[j]
+		if a.SourceNodeKey != b.SourceNodeKey {
+			return a.SourceNodeKey < b.SourceNodeKey
+		}
+		return a.TargetNodeKey < b.TargetNodeKey
+	})
+
+	return &ngs
+}
+
+// nodeLess sorts the start node first, the end node last, and the others by key.
+func nodeLess(a, b *model.Node) bool {
+	rank := func(n *model.Node) int {
+		switch n.Key {
+		case compose.START:
+			return 0
+		case compose.END:
+			return 2
+		default:
+			return 1
+		}
+	}
+	if ra, rb := rank(a), rank(b); ra != rb {
+		return ra < rb
+	}
+	return a.Key < b.Key
+}
+
+// nodeLabel is the key of the node, followed by its component if any.
+func nodeLabel(node *model.Node) string {
+	switch node.Type {
+	case model.NodeTypeOfStart, model.NodeTypeOfEnd, model.NodeTypeOfBranch, model.NodeTypeOfParallel:
+		return node.Name
+	}
+
+	if node.ComponentSchema == nil || node.ComponentSchema.Name == "" {
+		return node.Key
+	}
+	return node.Key + "\n" + node.ComponentSchema.Name
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/compose"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/devops/model"
+)
+
+func testCanvas() *model.CanvasInfo {
+	sub := &model.GraphSchema{
+		ID:   "sub_id",
+		Name: "sub",
+		Nodes: []*model.Node{
+			{Key: compose.END, Name: compose.END, Type: model.NodeTypeOfEnd},
+			{Key: compose.START, Name: compose.START, Type: model.NodeTypeOfStart},
+			{Key: "inner", Name: "inner", Type: "Lambda", ComponentSchema: &model.ComponentSchema{Name: "Lambda"}},
+		},
+		Edges: []*model.Edge{
+			{ID: "e1", Name: "start_to_inner", SourceNodeKey: compose.START, TargetNodeKey: "inner"},
+			{ID: "e2", Name: "inner_to_end", SourceNodeKey: "inner", TargetNodeKey: compose.END},
+		},
+	}
+
+	return &model.CanvasInfo{
+		Version: model.Version,
+		GraphSchema: &model.GraphSchema{
+			ID:   "random_id",
+			Name: "my graph",
+			Nodes: []*model.Node{
+				{Key: compose.START, Name: compose.START, Type: model.NodeTypeOfStart},
+				{Key: compose.END, Name: compose.END, Type: model.NodeTypeOfEnd},
+				{Key: "model", Name: "model", Type: "ChatModel", ComponentSchema: &model.ComponentSchema{Name: `Ark "v3"`}},
+				{Key: "sub", Name: "sub", Type: "Graph", GraphSchema: sub},
+				{Key: "from:model", Name: "branch", Type: model.NodeTypeOfBranch},
+				{Key: "from:model", Name: "branch", Type: model.NodeTypeOfBranch},
+			},
+			Edges: []*model.Edge{
+				{ID: "x1", Name: "start_to_model", SourceNodeKey: compose.START, TargetNodeKey: "model"},
+				{ID: "x2", Name: "model_to_from:model", SourceNodeKey: "model", TargetNodeKey: "from:model"},
+				{ID: "x3", Name: "from:model_to_sub", SourceNodeKey: "from:model", TargetNodeKey: "sub"},
+				{ID: "x4", Name: "from:model_to_end", SourceNodeKey: "from:model", TargetNodeKey: compose.END},
+				{ID: "x5", Name: "sub_to_end", SourceNodeKey: "sub", TargetNodeKey: compose.END},
+			},
+		},
+	}
+}
+
+func TestMermaid(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := Write(buf, testCanvas(), FormatMermaid)
+	assert.NoError(t, err)
+
+	expected := `---
+title: my graph
+---
+flowchart TD
+    n0(["start"])
+    n1{"branch"}
+    n2["model<br/>Ark #quot;v3#quot;"]
+    subgraph n3 ["sub"]
+        n3_0(["start"])
+        n3_1["inner<br/>Lambda"]
+        n3_2(["end"])
+        n3_1 --> n3_2
+        n3_0 --> n3_1
+    end
+    n4(["end"])
+    n1 --> n4
+    n1 --> n3
+    n2 --> n1
+    n0 --> n2
+    n3 --> n4
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestDOT(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := Write(buf, testCanvas(), FormatDOT)
+	assert.NoError(t, err)
+
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, "digraph \"my graph\" {\n"))
+	assert.Contains(t, out, `"model" [label="model\nArk \"v3\""];`)
+	assert.Contains(t, out, `"from:model" [label="branch", shape=diamond];`)
+	assert.Contains(t, out, `subgraph "cluster_sub" {`)
+	assert.Contains(t, out, `"sub/start" -> "sub/inner";`)
+	assert.Contains(t, out, `"from:model" -> "sub/start" [lhead="cluster_sub"];`)
+	assert.Contains(t, out, `"sub/end" -> "end" [ltail="cluster_sub"];`)
+	assert.Equal(t, 1, strings.Count(out, `"from:model" [label`))
+}
+
+func TestJSON(t *testing.T) {
+	canvas := testCanvas()
+
+	first := &bytes.Buffer{}
+	err := Write(first, canvas, FormatJSON)
+	assert.NoError(t, err)
+
+	// the order of the nodes and edges does not matter
+	gs := canvas.GraphSchema
+	gs.Nodes[0], gs.Nodes[2] = gs.Nodes[2], gs.Nodes[0]
+	gs.Edges[0], gs.Edges[4] = gs.Edges[4], gs.Edges[0]
+	second := &bytes.Buffer{}
+	err = Write(second, canvas, FormatJSON)
+	assert.NoError(t, err)
+	assert.Equal(t, first.String(), second.String())
+
+	got := &model.CanvasInfo{}
+	err = json.Unmarshal(first.Bytes(), got)
+	assert.NoError(t, err)
+	assert.Equal(t, "my graph", got.ID)
+	assert.Len(t, got.Nodes, 5)
+	assert.Equal(t, compose.START, got.Nodes[0].Key)
+	assert.Equal(t, compose.END, got.Nodes[4].Key)
+	assert.Equal(t, "from:model_to_end", got.Edges[0].ID)
+
+	// the canvas is not modified
+	assert.Equal(t, "random_id", canvas.ID)
+}
+
+func TestParseFormats(t *testing.T) {
+	formats, err := ParseFormats("mermaid, json")
+	assert.NoError(t, err)
+	assert.Equal(t, []Format{FormatMermaid, FormatJSON}, formats)
+
+	_, err = ParseFormats("svg")
+	assert.Error(t, err)
+	_, err = ParseFormats("")
+	assert.Error(t, err)
+}
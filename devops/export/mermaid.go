@@ -0,0 +1,91 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/devops/model"
+)
+
+// Mermaid writes the graph as a Mermaid flowchart, the subgraphs being nested.
+func Mermaid(w io.Writer, gs *model.GraphSchema) error {
+	if gs == nil {
+		return fmt.Errorf("graph schema is empty")
+	}
+
+	bw := bufio.NewWriter(w)
+	_, _ = fmt.Fprintf(bw, "---\ntitle: %s\n---\n", mermaidText(gs.Name))
+	_, _ = fmt.Fprintln(bw, "flowchart TD")
+	writeMermaidGraph(bw, normalize(gs), "n", 1)
+
+	return bw.Flush()
+}
+
+// writeMermaidGraph writes the nodes and edges of the graph, the ids of its nodes being prefix and their index,
+// as the keys can not be used as ids, e.g. end is reserved.
+func writeMermaidGraph(w io.Writer, gs *model.GraphSchema, prefix string, depth int) {
+	indent := strings.Repeat("    ", depth)
+
+	ids := make(map[string]string, len(gs.Nodes))
+	for i, node := range gs.Nodes {
+		ids[node.Key] = fmt.Sprintf("%s%d", prefix, i)
+	}
+
+	for _, node := range gs.Nodes {
+		id := ids[node.Key]
+		if node.GraphSchema != nil {
+			_, _ = fmt.Fprintf(w, "%ssubgraph %s [\"%s\"]\n", indent, id, mermaidText(nodeLabel(node)))
+			writeMermaidGraph(w, node.GraphSchema, id+"_", depth+1)
+			_, _ = fmt.Fprintf(w, "%send\n", indent)
+			continue
+		}
+
+		label := mermaidText(nodeLabel(node))
+		switch node.Type {
+		case model.NodeTypeOfStart, model.NodeTypeOfEnd:
+			_, _ = fmt.Fprintf(w, "%s%s([\"%s\"])\n", indent, id, label)
+		case model.NodeTypeOfBranch:
+			_, _ = fmt.Fprintf(w, "%s%s{\"%s\"}\n", indent, id, label)
+		case model.NodeTypeOfParallel:
+			_, _ = fmt.Fprintf(w, "%s%s((\"%s\"))\n", indent, id, label)
+		default:
+			_, _ = fmt.Fprintf(w, "%s%s[\"%s\"]\n", indent, id, label)
+		}
+	}
+
+	for _, edge := range gs.Edges {
+		source, ok := ids[edge.SourceNodeKey]
+		if !ok {
+			continue
+		}
+		target, ok := ids[edge.TargetNodeKey]
+		if !ok {
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "%s%s --> %s\n", indent, source, target)
+	}
+}
+
+var mermaidReplacer = strings.NewReplacer(`"`, "#quot;", "\n", "<br/>", "<", "#lt;", ">", "#gt;")
+
+func mermaidText(s string) string {
+	return mermaidReplacer.Replace(s)
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devops
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudwego/eino/compose"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExportCommand(t *testing.T) {
+	dir := t.TempDir()
+
+	err := ExportCommand(context.Background(), []string{"-out", dir, "-format", "mermaid,json", "-graph", "export graph"},
+		func(ctx context.Context) error {
+			g := compose.NewGraph[string, string]()
+			err := g.AddLambdaNode("echo", compose.InvokableLambda(func(ctx context.Context, input string) (string, error) {
+				return input, nil
+			}))
+			if err != nil {
+				return err
+			}
+			if err = g.AddEdge(compose.START, "echo"); err != nil {
+				return err
+			}
+			if err = g.AddEdge("echo", compose.END); err != nil {
+				return err
+			}
+			_, err = g.Compile(ctx, compose.WithGraphName("export graph"))
+			return err
+		})
+	assert.NoError(t, err)
+
+	mermaid, err := os.ReadFile(filepath.Join(dir, "export_graph.mmd"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(mermaid), `["echo<br/>Lambda"]`)
+
+	_, err = os.Stat(filepath.Join(dir, "export_graph.json"))
+	assert.NoError(t, err)
+	_, err = os.Stat(filepath.Join(dir, "export_graph.dot"))
+	assert.True(t, os.IsNotExist(err))
+
+	err = ExportCommand(context.Background(), []string{"-out", dir, "-graph", "unknown"},
+		func(ctx context.Context) error { return nil })
+	assert.Error(t, err)
+
+	err = ExportCommand(context.Background(), []string{"-format", "svg"},
+		func(ctx context.Context) error { return nil })
+	assert.Error(t, err)
+}
+
+func Test_exportFileName(t *testing.T) {
+	assert.Equal(t, "graph.Build_42", exportFileName("graph.Build:42"))
+	assert.Equal(t, "my_graph", exportFileName("my graph"))
+	assert.Equal(t, "graph", exportFileName("//"))
+}
@@ -57,11 +57,15 @@ func StreamLog(res http.ResponseWriter, req *http.Request) {
 	sseResponseChan := make(chan SSEResponse, 1000)
 	ctx := req.Context()
 	safego.Go(ctx, func() {
+		// the log stream never ends, unless the client disconnects or the server shuts down
+		defer close(sseResponseChan)
 		for {
 			select {
 			case <-ctx.Done():
 				log.Errorf("client disconnect")
 				return
+			case <-drainingOf(ctx):
+				return
 			case message, ok := <-logCh:
 				if !ok {
 					return
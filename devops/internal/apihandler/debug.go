@@ -38,6 +38,12 @@ func InitDebug(opt *model.DevOpt) {
 	for _, rt := range opt.GoTypes {
 		model.RegisterType(rt.Type)
 	}
+	if opt.DebugRunStore != nil {
+		service.DebugSVC = service.NewDebugService(opt.DebugRunStore)
+	}
+	if len(opt.Evaluators) > 0 || opt.EvalReportDir != "" {
+		service.EvalSVC = service.NewEvalService(opt.Evaluators, opt.EvalReportDir)
+	}
 }
 
 // GetCanvasInfo use graph name to  get canvas info
@@ -64,6 +70,16 @@ func GetCanvasInfo(res http.ResponseWriter, req *http.Request) {
 		}
 	}
 
+	// the canvas of a thread shows the nodes stubbed in its latest run
+	if threadID := getReqQuery(req, "thread_id"); len(threadID) > 0 {
+		stubs, err := service.DebugSVC.GetStubbedNodes(req.Context(), graphID, threadID)
+		if err != nil {
+			newHTTPResp(newBizError(http.StatusBadRequest, err), newBaseResp(http.StatusBadRequest, "")).doResp(res)
+			return
+		}
+		canvasInfo = withStubbedNodes(canvasInfo, stubs)
+	}
+
 	resp := &types.GetCanvasInfoResponse{
 		CanvasInfo: canvasInfo,
 	}
@@ -71,6 +87,28 @@ func GetCanvasInfo(res http.ResponseWriter, req *http.Request) {
 	newHTTPResp(resp).doResp(res)
 }
 
+// withStubbedNodes sets the stub kind of the stubbed nodes in a copy of the canvas, the canvas being cached
+// for all the threads of the graph.
+func withStubbedNodes(canvasInfo devmodel.CanvasInfo, stubs map[string]devmodel.StubKind) devmodel.CanvasInfo {
+	if len(stubs) == 0 || canvasInfo.GraphSchema == nil {
+		return canvasInfo
+	}
+
+	gs := *canvasInfo.GraphSchema
+	gs.Nodes = make([]*devmodel.Node, 0, len(canvasInfo.Nodes))
+	for _, node := range canvasInfo.Nodes {
+		if kind, ok := stubs[node.Key]; ok {
+			cp := *node
+			cp.Stub = kind
+			node = &cp
+		}
+		gs.Nodes = append(gs.Nodes, node)
+	}
+	canvasInfo.GraphSchema = &gs
+
+	return canvasInfo
+}
+
 // CreateDebugThread create thread_id.
 func CreateDebugThread(res http.ResponseWriter, req *http.Request) {
 	err := validateCreateDebugThreadRequest(req)
@@ -131,9 +169,12 @@ func StreamDebugRun(res http.ResponseWriter, req *http.Request) {
 	ctx = context.WithValue(ctx, "K_LOGID", rs.LogID)
 
 	m := &model.DebugRunMeta{
-		GraphID:  graphID,
-		ThreadID: threadID,
-		FromNode: rs.FromNode,
+		GraphID:     graphID,
+		ThreadID:    threadID,
+		FromNode:    rs.FromNode,
+		Mode:        model.RunMode(rs.Mode),
+		Breakpoints: toBreakpoints(rs.Breakpoints),
+		Stubs:       rs.Stubs,
 	}
 
 	debugID, stateCh, errCh, err := service.DebugSVC.DebugRun(ctx, m, rs.Input)
@@ -142,6 +183,183 @@ func StreamDebugRun(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	doDebugRunSSEResp(ctx, res, debugID, stateCh, errCh)
+}
+
+// ListDebugRuns list the recorded runs of a debug thread.
+func ListDebugRuns(res http.ResponseWriter, req *http.Request) {
+	graphID, threadID, err := validateDebugThreadPath(req)
+	if err != nil {
+		newHTTPResp(newBizError(http.StatusBadRequest, err), newBaseResp(http.StatusBadRequest, "")).doResp(res)
+		return
+	}
+
+	runs, err := service.DebugSVC.ListDebugRuns(req.Context(), graphID, threadID)
+	if err != nil {
+		newHTTPResp(newBizError(http.StatusInternalServerError, err)).doResp(res)
+		return
+	}
+
+	newHTTPResp(&types.ListDebugRunsResponse{Runs: runs}).doResp(res)
+}
+
+// GetDebugRun get a recorded run, with the state of each of its nodes.
+func GetDebugRun(res http.ResponseWriter, req *http.Request) {
+	run, bizErr := getDebugRun(req)
+	if bizErr != nil {
+		newHTTPResp(bizErr, newBaseResp(bizErr.BizCode, "")).doResp(res)
+		return
+	}
+
+	newHTTPResp(&types.GetDebugRunResponse{Run: run}).doResp(res)
+}
+
+// ReplayDebugRun run a recorded run again, with the same input or an edited one.
+func ReplayDebugRun(res http.ResponseWriter, req *http.Request) {
+	var err error
+	defer func() {
+		if err != nil {
+			sseResp := make(chan SSEResponse, 1)
+			evt := types.DebugRunErrEVT("", err.Error())
+			sseResp <- NewStreamResponse(string(evt.Type), string(evt.JsonBytes()))
+			close(sseResp)
+			doSSEResp(req.Context(), res, sseResp)
+		}
+	}()
+
+	run, bizErr := getDebugRun(req)
+	if bizErr != nil {
+		err = fmt.Errorf("%s", bizErr.BizMsg)
+		log.Errorf(err.Error())
+		return
+	}
+
+	rs, err := getReqFromBody[types.ReplayDebugRunRequest](req)
+	if err != nil {
+		log.Errorf(err.Error())
+		return
+	}
+	if err = validateBreakpoints(rs.Breakpoints); err != nil {
+		log.Errorf(err.Error())
+		return
+	}
+	stubs := run.Stubs
+	if rs.Stubs != nil {
+		stubs = rs.Stubs
+	}
+	if err = validateStubs(stubs); err != nil {
+		log.Errorf(err.Error())
+		return
+	}
+
+	input := run.Input
+	if rs.Input != nil {
+		input = *rs.Input
+	}
+
+	ctx := context.WithValue(req.Context(), "K_LOGID", rs.LogID)
+
+	m := &model.DebugRunMeta{
+		GraphID:     run.GraphID,
+		ThreadID:    run.ThreadID,
+		FromNode:    run.FromNode,
+		Mode:        model.RunMode(run.Mode),
+		ReplayOf:    run.ID,
+		Breakpoints: toBreakpoints(rs.Breakpoints),
+		Stubs:       stubs,
+	}
+
+	debugID, stateCh, errCh, err := service.DebugSVC.DebugRun(ctx, m, input)
+	if err != nil {
+		log.Errorf(err.Error())
+		return
+	}
+
+	doDebugRunSSEResp(ctx, res, debugID, stateCh, errCh)
+}
+
+// ResumeDebugRun resume a run paused at a breakpoint, possibly with the paused input or output edited.
+func ResumeDebugRun(res http.ResponseWriter, req *http.Request) {
+	graphID, threadID, err := validateDebugThreadPath(req)
+	if err != nil {
+		newHTTPResp(newBizError(http.StatusBadRequest, err), newBaseResp(http.StatusBadRequest, "")).doResp(res)
+		return
+	}
+
+	runID := getPathParam(req, "run_id")
+	if runID == "" {
+		newHTTPResp(newBizError(http.StatusBadRequest, fmt.Errorf("run_id is empty")), newBaseResp(http.StatusBadRequest, "")).doResp(res)
+		return
+	}
+
+	rs, err := getReqFromBody[types.ResumeDebugRunRequest](req)
+	if err != nil {
+		newHTTPResp(newBizError(http.StatusBadRequest, err), newBaseResp(http.StatusBadRequest, "")).doResp(res)
+		return
+	}
+
+	action := model.ResumeAction(rs.Action)
+	switch action {
+	case model.ResumeActionContinue, model.ResumeActionStep, model.ResumeActionAbort:
+	default:
+		newHTTPResp(newBizError(http.StatusBadRequest, fmt.Errorf("action=%s is not supported", rs.Action)),
+			newBaseResp(http.StatusBadRequest, "")).doResp(res)
+		return
+	}
+
+	cmd := &model.ResumeCommand{
+		NodeKey: rs.NodeKey,
+		Action:  action,
+		Input:   rs.Input,
+		Output:  rs.Output,
+	}
+	if err = service.DebugSVC.ResumeDebugRun(req.Context(), graphID, threadID, runID, cmd); err != nil {
+		newHTTPResp(newBizError(http.StatusBadRequest, err), newBaseResp(http.StatusBadRequest, "")).doResp(res)
+		return
+	}
+
+	newHTTPResp(&types.ResumeDebugRunResponse{}).doResp(res)
+}
+
+func getDebugRun(req *http.Request) (*devmodel.DebugRun, *BizError) {
+	graphID, threadID, err := validateDebugThreadPath(req)
+	if err != nil {
+		return nil, newBizError(http.StatusBadRequest, err)
+	}
+
+	runID := getPathParam(req, "run_id")
+	if runID == "" {
+		return nil, newBizError(http.StatusBadRequest, fmt.Errorf("run_id is empty"))
+	}
+
+	run, ok, err := service.DebugSVC.GetDebugRun(req.Context(), graphID, threadID, runID)
+	if err != nil {
+		return nil, newBizError(http.StatusInternalServerError, err)
+	}
+	if !ok {
+		return nil, newBizError(http.StatusNotFound, fmt.Errorf("run=%s not exist", runID))
+	}
+
+	return run, nil
+}
+
+func validateDebugThreadPath(req *http.Request) (graphID, threadID string, err error) {
+	graphID = getPathParam(req, "graph_id")
+	if graphID == "" {
+		return "", "", fmt.Errorf("graph_id is empty")
+	}
+
+	threadID = getPathParam(req, "thread_id")
+	if threadID == "" {
+		return "", "", fmt.Errorf("thread_id is empty")
+	}
+
+	return graphID, threadID, nil
+}
+
+// doDebugRunSSEResp sends the node states of a debug run as SSE events, then its errors.
+func doDebugRunSSEResp(ctx context.Context, res http.ResponseWriter, debugID string,
+	stateCh chan *model.NodeDebugState, errCh chan error) {
 	sseStreamResponseChan := make(chan SSEResponse, 50)
 	wg := sync.WaitGroup{}
 	wg.Add(1)
@@ -159,11 +377,6 @@ func StreamDebugRun(res http.ResponseWriter, req *http.Request) {
 				}
 
 				evt := types.DebugRunDataEVT(debugID, state)
-				if err != nil {
-					errEvt := types.DebugRunErrEVT(debugID, err.Error())
-					sseStreamResponseChan <- NewStreamResponse(string(errEvt.Type), string(evt.JsonBytes()))
-					return
-				}
 				sseStreamResponseChan <- NewStreamResponse(string(evt.Type), string(evt.JsonBytes()))
 			}
 		}
@@ -178,7 +391,7 @@ func StreamDebugRun(res http.ResponseWriter, req *http.Request) {
 		}
 	})
 
-	doSSEResp(req.Context(), res, sseStreamResponseChan)
+	doSSEResp(ctx, res, sseStreamResponseChan)
 }
 
 func validateDebugRunRequest(req *http.Request) (*types.DebugRunRequest, error) {
@@ -201,9 +414,78 @@ func validateDebugRunRequest(req *http.Request) (*types.DebugRunRequest, error)
 		return nil, fmt.Errorf("from_node is empty")
 	}
 
+	if !model.RunMode(r.Mode).IsValid() {
+		return nil, fmt.Errorf("mode=%s is not supported", r.Mode)
+	}
+
+	if err = validateBreakpoints(r.Breakpoints); err != nil {
+		return nil, err
+	}
+
+	if err = validateStubs(r.Stubs); err != nil {
+		return nil, err
+	}
+
 	return r, nil
 }
 
+func validateBreakpoints(bps []types.Breakpoint) error {
+	for _, bp := range bps {
+		if bp.NodeKey == "" {
+			return fmt.Errorf("node_key of breakpoint is empty")
+		}
+		switch model.BreakpointPosition(bp.Position) {
+		case model.BreakpointBefore, model.BreakpointAfter:
+		default:
+			return fmt.Errorf("position=%s of breakpoint is not supported", bp.Position)
+		}
+	}
+	return nil
+}
+
+func validateStubs(stubs []*devmodel.NodeStub) error {
+	stubbed := make(map[string]bool, len(stubs))
+	for _, stub := range stubs {
+		if stub == nil || stub.NodeKey == "" {
+			return fmt.Errorf("node_key of stub is empty")
+		}
+		if stubbed[stub.NodeKey] {
+			return fmt.Errorf("node=%s is stubbed more than once", stub.NodeKey)
+		}
+		stubbed[stub.NodeKey] = true
+
+		switch stub.Kind {
+		case devmodel.StubKindFixed:
+			if stub.Output == "" {
+				return fmt.Errorf("output of fixed stub of node=%s is empty", stub.NodeKey)
+			}
+		case devmodel.StubKindTemplate:
+			if stub.Template == "" {
+				return fmt.Errorf("template of stub of node=%s is empty", stub.NodeKey)
+			}
+		case devmodel.StubKindRecorded:
+		default:
+			return fmt.Errorf("stub kind=%s of node=%s is not supported", stub.Kind, stub.NodeKey)
+		}
+	}
+	return nil
+}
+
+func toBreakpoints(bps []types.Breakpoint) []model.Breakpoint {
+	if len(bps) == 0 {
+		return nil
+	}
+
+	breakpoints := make([]model.Breakpoint, 0, len(bps))
+	for _, bp := range bps {
+		breakpoints = append(breakpoints, model.Breakpoint{
+			NodeKey:  bp.NodeKey,
+			Position: model.BreakpointPosition(bp.Position),
+		})
+	}
+	return breakpoints
+}
+
 func ListInputTypes(res http.ResponseWriter, req *http.Request) {
 	resp := &types.ListInputTypesResponse{
 		Types: model.GetRegisteredTypeJsonSchema(),
@@ -106,6 +106,44 @@ func (d *debugTestSuite) Test_GetCanvasInfo() {
 	})
 }
 
+func (d *debugTestSuite) Test_GetCanvasInfo_StubbedNodes() {
+	mockey.PatchConvey("", d.t, func() {
+		mockGraphID := "mock_graph"
+		mockThreadID := "mock_thread_id"
+		mockey.Mock(getPathParam).Return(mockGraphID).Build()
+
+		canvas := devmodel.CanvasInfo{
+			GraphSchema: &devmodel.GraphSchema{
+				Name:  "mock_canvas",
+				Nodes: []*devmodel.Node{{Key: "node_1"}, {Key: "node_2"}},
+			},
+		}
+		d.mockContainerSVC.EXPECT().GetCanvas(mockGraphID).Return(canvas, true).Times(1)
+		d.mockDebugSVC.EXPECT().GetStubbedNodes(gomock.Any(), mockGraphID, mockThreadID).
+			Return(map[string]devmodel.StubKind{"node_2": devmodel.StubKindRecorded}, nil).Times(1)
+
+		req, err := http.NewRequest(http.MethodGet, "?thread_id="+mockThreadID, nil)
+		assert.Nil(d.t, err)
+		res := &mockResponseWriter{}
+		GetCanvasInfo(res, req)
+
+		resp := &HTTPResp{}
+		err = json.Unmarshal(res.body, &resp)
+		assert.Nil(d.t, err)
+		b, err := json.Marshal(resp.Data)
+		assert.Nil(d.t, err)
+		var data *types.GetCanvasInfoResponse
+		err = json.Unmarshal(b, &data)
+		assert.Nil(d.t, err)
+		if assert.Len(d.t, data.CanvasInfo.Nodes, 2) {
+			assert.Empty(d.t, data.CanvasInfo.Nodes[0].Stub)
+			assert.Equal(d.t, devmodel.StubKindRecorded, data.CanvasInfo.Nodes[1].Stub)
+		}
+		// the cached canvas is left as is
+		assert.Empty(d.t, canvas.Nodes[1].Stub)
+	})
+}
+
 func (d *debugTestSuite) Test_CreateDebugThread() {
 	mockey.PatchConvey("", d.t, func() {
 		mockGraphID := "mock_graph"
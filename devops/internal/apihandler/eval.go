@@ -0,0 +1,150 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apihandler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cloudwego/eino-ext/devops/internal/apihandler/types"
+	"github.com/cloudwego/eino-ext/devops/internal/model"
+	"github.com/cloudwego/eino-ext/devops/internal/service"
+)
+
+// AddDataset register a JSONL dataset against a graph, to evaluate it.
+func AddDataset(res http.ResponseWriter, req *http.Request) {
+	graphID := getPathParam(req, "graph_id")
+	if graphID == "" {
+		newHTTPResp(newBizError(http.StatusBadRequest, fmt.Errorf("graph_id is empty")), newBaseResp(http.StatusBadRequest, "")).doResp(res)
+		return
+	}
+
+	rs, err := getReqFromBody[types.AddDatasetRequest](req)
+	if err != nil {
+		newHTTPResp(newBizError(http.StatusBadRequest, err), newBaseResp(http.StatusBadRequest, "")).doResp(res)
+		return
+	}
+	if rs.Name == "" {
+		newHTTPResp(newBizError(http.StatusBadRequest, fmt.Errorf("name is empty")), newBaseResp(http.StatusBadRequest, "")).doResp(res)
+		return
+	}
+
+	ds, err := service.EvalSVC.AddDataset(req.Context(), graphID, rs.Name, rs.Content)
+	if err != nil {
+		newHTTPResp(newBizError(http.StatusBadRequest, err), newBaseResp(http.StatusBadRequest, "")).doResp(res)
+		return
+	}
+
+	newHTTPResp(&types.AddDatasetResponse{Dataset: types.ToDataset(ds)}).doResp(res)
+}
+
+// ListDatasets list the datasets of a graph, without their cases.
+func ListDatasets(res http.ResponseWriter, req *http.Request) {
+	graphID := getPathParam(req, "graph_id")
+	if graphID == "" {
+		newHTTPResp(newBizError(http.StatusBadRequest, fmt.Errorf("graph_id is empty")), newBaseResp(http.StatusBadRequest, "")).doResp(res)
+		return
+	}
+
+	datasets := service.EvalSVC.ListDatasets(req.Context(), graphID)
+	resp := &types.ListDatasetsResponse{
+		Datasets: make([]*types.Dataset, 0, len(datasets)),
+	}
+	for _, ds := range datasets {
+		resp.Datasets = append(resp.Datasets, types.ToDataset(ds))
+	}
+
+	newHTTPResp(resp).doResp(res)
+}
+
+// RunEvaluation start the evaluation of a graph on a dataset, the report of which is polled by GetEvaluation.
+func RunEvaluation(res http.ResponseWriter, req *http.Request) {
+	graphID := getPathParam(req, "graph_id")
+	datasetID := getPathParam(req, "dataset_id")
+	if graphID == "" || datasetID == "" {
+		newHTTPResp(newBizError(http.StatusBadRequest, fmt.Errorf("graph_id or dataset_id is empty")),
+			newBaseResp(http.StatusBadRequest, "")).doResp(res)
+		return
+	}
+
+	rs, err := getReqFromBody[types.RunEvaluationRequest](req)
+	if err != nil {
+		newHTTPResp(newBizError(http.StatusBadRequest, err), newBaseResp(http.StatusBadRequest, "")).doResp(res)
+		return
+	}
+
+	config := &model.EvalConfig{
+		Concurrency: rs.Concurrency,
+		Evaluators:  make([]*model.EvaluatorSpec, 0, len(rs.Evaluators)),
+	}
+	for _, spec := range rs.Evaluators {
+		if spec == nil || spec.Name == "" {
+			newHTTPResp(newBizError(http.StatusBadRequest, fmt.Errorf("name of evaluator is empty")),
+				newBaseResp(http.StatusBadRequest, "")).doResp(res)
+			return
+		}
+		config.Evaluators = append(config.Evaluators, &model.EvaluatorSpec{
+			Name:    spec.Name,
+			Path:    spec.Path,
+			Pattern: spec.Pattern,
+		})
+	}
+
+	reportID, err := service.EvalSVC.RunEvaluation(req.Context(), graphID, datasetID, config)
+	if err != nil {
+		newHTTPResp(newBizError(http.StatusBadRequest, err), newBaseResp(http.StatusBadRequest, "")).doResp(res)
+		return
+	}
+
+	newHTTPResp(&types.RunEvaluationResponse{ReportID: reportID}).doResp(res)
+}
+
+// ListEvaluations list the reports of the evaluations of a graph, without their cases.
+func ListEvaluations(res http.ResponseWriter, req *http.Request) {
+	graphID := getPathParam(req, "graph_id")
+	if graphID == "" {
+		newHTTPResp(newBizError(http.StatusBadRequest, fmt.Errorf("graph_id is empty")), newBaseResp(http.StatusBadRequest, "")).doResp(res)
+		return
+	}
+
+	newHTTPResp(&types.ListEvaluationsResponse{Reports: service.EvalSVC.ListReports(req.Context(), graphID)}).doResp(res)
+}
+
+// GetEvaluation get the report of an evaluation, running or done.
+func GetEvaluation(res http.ResponseWriter, req *http.Request) {
+	graphID := getPathParam(req, "graph_id")
+	reportID := getPathParam(req, "report_id")
+	if graphID == "" || reportID == "" {
+		newHTTPResp(newBizError(http.StatusBadRequest, fmt.Errorf("graph_id or report_id is empty")),
+			newBaseResp(http.StatusBadRequest, "")).doResp(res)
+		return
+	}
+
+	report, ok := service.EvalSVC.GetReport(req.Context(), graphID, reportID)
+	if !ok {
+		newHTTPResp(newBizError(http.StatusNotFound, fmt.Errorf("report=%s not found", reportID)),
+			newBaseResp(http.StatusNotFound, "")).doResp(res)
+		return
+	}
+
+	newHTTPResp(&types.GetEvaluationResponse{Report: report}).doResp(res)
+}
+
+// ListEvaluators list the names of the evaluators an evaluation can use.
+func ListEvaluators(res http.ResponseWriter, _ *http.Request) {
+	newHTTPResp(&types.ListEvaluatorsResponse{Evaluators: service.EvalSVC.ListEvaluators()}).doResp(res)
+}
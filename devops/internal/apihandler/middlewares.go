@@ -17,9 +17,13 @@
 package apihandler
 
 import (
+	"crypto/subtle"
 	"fmt"
 	"net/http"
 	"runtime/debug"
+	"strings"
+
+	"github.com/gorilla/mux"
 
 	"github.com/cloudwego/eino-ext/devops/internal/utils/log"
 )
@@ -37,20 +41,83 @@ func recoverMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-func corsMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		method := r.Method
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS,DELETE,PUT")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type,X-CSRF-Token, Authorization")
-		w.Header().Set("Access-Control-Expose-Headers", "Content-Length, Access-Control-Allow-Origin, Access-Control-Allow-Headers, Content-Type, New-Token, New-Expires-At")
-		w.Header().Set("Access-Control-Allow-Credentials", "true")
-
-		// 放行所有OPTIONS方法
-		if method == "OPTIONS" {
-			w.WriteHeader(http.StatusNoContent)
-			return
+// newCORSMiddleware allows the origins, any origin if empty or containing *.
+func newCORSMiddleware(allowedOrigins []string) mux.MiddlewareFunc {
+	allowAny := len(allowedOrigins) == 0
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		if origin == "*" {
+			allowAny = true
 		}
-		next.ServeHTTP(w, r)
-	})
+		allowed[strings.TrimSuffix(origin, "/")] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			method := r.Method
+			origin := r.Header.Get("Origin")
+			switch {
+			case allowAny:
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			case origin != "" && allowed[origin]:
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+				w.Header().Add("Vary", "Origin")
+			case origin != "":
+				// browsers send the Origin header, the other clients are not concerned by CORS
+				w.Header().Add("Vary", "Origin")
+				if method == http.MethodOptions {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+			}
+			w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS,DELETE,PUT")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type,X-CSRF-Token, Authorization")
+			w.Header().Set("Access-Control-Expose-Headers", "Content-Length, Access-Control-Allow-Origin, Access-Control-Allow-Headers, Content-Type, New-Token, New-Expires-At")
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+
+			// 放行所有OPTIONS方法
+			if method == http.MethodOptions {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// newAuthMiddleware requires the token as a bearer token, or an access_token query parameter. No auth if empty.
+func newAuthMiddleware(token string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		if token == "" {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// the CORS preflight requests carry no credentials
+			if r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			got := getReqQuery(r, "access_token")
+			if auth := r.Header.Get("Authorization"); auth != "" {
+				scheme, credentials, _ := strings.Cut(auth, " ")
+				if strings.EqualFold(scheme, "Bearer") {
+					got = strings.TrimSpace(credentials)
+				}
+			}
+
+			if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				log.Warnf("unauthorized request, path=%s", r.URL.Path)
+				w.Header().Set("WWW-Authenticate", `Bearer realm="eino-devops"`)
+				w.Header().Set("content-type", "application/json")
+				w.WriteHeader(http.StatusUnauthorized)
+				newHTTPResp(newBizError(http.StatusUnauthorized, fmt.Errorf("invalid or missing token")),
+					newBaseResp(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))).doResp(w)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
 }
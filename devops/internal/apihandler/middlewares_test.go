@@ -0,0 +1,154 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apihandler
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newAuthMiddleware(t *testing.T) {
+	h := newAuthMiddleware("secret")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	do := func(method, target, auth string) int {
+		req := httptest.NewRequest(method, target, nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		res := httptest.NewRecorder()
+		h.ServeHTTP(res, req)
+		return res.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/ping", ""))
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/ping", "Bearer wrong"))
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/ping", "Basic secret"))
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/ping", "Bearer secret"))
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/ping?access_token=secret", ""))
+	assert.Equal(t, http.StatusOK, do(http.MethodOptions, "/ping", ""))
+
+	noAuth := newAuthMiddleware("")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	res := httptest.NewRecorder()
+	noAuth.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/ping", nil))
+	assert.Equal(t, http.StatusOK, res.Code)
+}
+
+func Test_newCORSMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	do := func(h http.Handler, method, origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/ping", nil)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		res := httptest.NewRecorder()
+		h.ServeHTTP(res, req)
+		return res
+	}
+
+	anyOrigin := newCORSMiddleware(nil)(next)
+	res := do(anyOrigin, http.MethodGet, "http://a.com")
+	assert.Equal(t, "*", res.Header().Get("Access-Control-Allow-Origin"))
+
+	allowList := newCORSMiddleware([]string{"http://a.com/"})(next)
+	res = do(allowList, http.MethodGet, "http://a.com")
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "http://a.com", res.Header().Get("Access-Control-Allow-Origin"))
+
+	res = do(allowList, http.MethodGet, "http://b.com")
+	assert.Empty(t, res.Header().Get("Access-Control-Allow-Origin"))
+	res = do(allowList, http.MethodOptions, "http://b.com")
+	assert.Equal(t, http.StatusForbidden, res.Code)
+	res = do(allowList, http.MethodOptions, "http://a.com")
+	assert.Equal(t, http.StatusNoContent, res.Code)
+
+	// not a browser
+	res = do(allowList, http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, res.Code)
+}
+
+func Test_server_shutdown(t *testing.T) {
+	started := make(chan struct{}, 2)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/drain", func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-drainingOf(r.Context())
+		_, _ = w.Write([]byte("drained"))
+	})
+	mux.HandleFunc("/hang", func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-r.Context().Done()
+	})
+
+	serve := func() (*server, string) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		s := newServer(mux)
+		go func() { _ = s.srv.Serve(ln) }()
+		return s, "http://" + ln.Addr().String()
+	}
+
+	t.Run("drain", func(t *testing.T) {
+		s, addr := serve()
+		body := make(chan string, 1)
+		go func() {
+			resp, err := http.Get(addr + "/drain")
+			if !assert.NoError(t, err) {
+				body <- ""
+				return
+			}
+			defer resp.Body.Close()
+			b, _ := io.ReadAll(resp.Body)
+			body <- string(b)
+		}()
+		<-started
+
+		err := s.shutdown(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "drained", <-body)
+		assert.NoError(t, s.shutdown(context.Background()))
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		s, addr := serve()
+		go func() {
+			resp, err := http.Get(addr + "/hang")
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+		}()
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		err := s.shutdown(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -20,44 +20,123 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"sync"
 	"time"
 
 	"github.com/gorilla/mux"
 
+	"github.com/cloudwego/eino-ext/devops/internal/model"
 	"github.com/cloudwego/eino-ext/devops/internal/utils/log"
+	"github.com/cloudwego/eino-ext/devops/internal/utils/safego"
 )
 
 var (
-	logCh     = log.InitLogger()
-	startOnce sync.Once
+	logCh = log.InitLogger()
+
+	serverMu sync.Mutex
+	// running: the started server, until it is shut down.
+	running *server
 )
 
-// StartHTTPServer init http sever use the specified port.
-func StartHTTPServer(_ context.Context, port string) error {
-	log.Infof("start debug http server at port=%s", port)
-	var err error
-	startOnce.Do(func() {
-		r := mux.NewRouter()
-		registerRoutes(r)
-		err = http.ListenAndServe(":"+port, r)
-		if err != nil {
-			log.Errorf("start debug http server failed, err=%v", err)
+// StartHTTPServer starts the http server on the bind address and port of opt, and returns once it listens.
+// The server is started once, the later calls returning the shutdown of the running one.
+func StartHTTPServer(ctx context.Context, opt *model.DevOpt) (shutdown func(ctx context.Context) error, err error) {
+	serverMu.Lock()
+	defer serverMu.Unlock()
+
+	if running != nil {
+		return running.shutdown, nil
+	}
+
+	ln, err := net.Listen("tcp", net.JoinHostPort(opt.DevServerBindAddress, opt.DevServerPort))
+	if err != nil {
+		log.Errorf("start debug http server failed, err=%v", err)
+		return nil, err
+	}
+	log.Infof("start debug http server at addr=%s", ln.Addr())
+
+	r := mux.NewRouter()
+	registerRoutes(r, opt)
+
+	s := newServer(r)
+	safego.Go(ctx, func() {
+		if err := s.srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorf("debug http server stopped, err=%v", err)
 		}
 	})
-	return err
+	running = s
+
+	return s.shutdown, nil
+}
+
+type server struct {
+	srv *http.Server
+	// draining is closed when the server shuts down, to end the SSE streams that never end by themselves.
+	draining chan struct{}
+	// cancel cancels the context of all the requests.
+	cancel       context.CancelFunc
+	shutdownOnce sync.Once
+	shutdownErr  error
+}
+
+type drainingCtxKey struct{}
+
+func newServer(handler http.Handler) *server {
+	baseCtx, cancel := context.WithCancel(context.Background())
+	s := &server{
+		draining: make(chan struct{}),
+		cancel:   cancel,
+	}
+	baseCtx = context.WithValue(baseCtx, drainingCtxKey{}, (<-chan struct{})(s.draining))
+	s.srv = &http.Server{
+		Handler:     handler,
+		BaseContext: func(net.Listener) context.Context { return baseCtx },
+	}
+
+	return s
+}
+
+// shutdown stops accepting connections, and waits for the running requests to end, e.g. the SSE streams of
+// the debug runs. Those still running when ctx is done are cancelled.
+func (s *server) shutdown(ctx context.Context) error {
+	s.shutdownOnce.Do(func() {
+		close(s.draining)
+		if err := s.srv.Shutdown(ctx); err != nil {
+			s.shutdownErr = err
+			s.cancel()
+			_ = s.srv.Close()
+		}
+		s.cancel()
+
+		serverMu.Lock()
+		if running == s {
+			running = nil
+		}
+		serverMu.Unlock()
+		log.Infof("debug http server shut down")
+	})
+
+	return s.shutdownErr
+}
+
+// drainingOf returns the channel closed when the server of the request shuts down.
+func drainingOf(ctx context.Context) <-chan struct{} {
+	ch, _ := ctx.Value(drainingCtxKey{}).(<-chan struct{})
+	return ch
 }
 
-func registerRoutes(r *mux.Router) {
+func registerRoutes(r *mux.Router, opt *model.DevOpt) {
 	const (
 		root     = "/eino/devops"
 		debugBiz = "/debug/v1"
 	)
 
-	r.Use(recoverMiddleware, corsMiddleware)
+	r.Use(recoverMiddleware, newCORSMiddleware(opt.CORSAllowedOrigins), newAuthMiddleware(opt.AuthToken))
 
 	rootR := r.PathPrefix(root).Subrouter()
 	rootR.Path("/ping").HandlerFunc(Ping).Methods(http.MethodGet)
@@ -71,6 +150,18 @@ func registerRoutes(r *mux.Router) {
 	debugR.Path("/graphs/{graph_id}/canvas").HandlerFunc(GetCanvasInfo).Methods(http.MethodGet)
 	debugR.Path("/graphs/{graph_id}/threads").HandlerFunc(CreateDebugThread).Methods(http.MethodPost)
 	debugR.Path("/graphs/{graph_id}/threads/{thread_id}/stream").HandlerFunc(StreamDebugRun).Methods(http.MethodPost)
+	debugR.Path("/graphs/{graph_id}/threads/{thread_id}/runs").HandlerFunc(ListDebugRuns).Methods(http.MethodGet)
+	debugR.Path("/graphs/{graph_id}/threads/{thread_id}/runs/{run_id}").HandlerFunc(GetDebugRun).Methods(http.MethodGet)
+	debugR.Path("/graphs/{graph_id}/threads/{thread_id}/runs/{run_id}/replay").HandlerFunc(ReplayDebugRun).Methods(http.MethodPost)
+	debugR.Path("/graphs/{graph_id}/threads/{thread_id}/runs/{run_id}/resume").HandlerFunc(ResumeDebugRun).Methods(http.MethodPost)
+
+	// evaluation routes
+	debugR.Path("/evaluators").HandlerFunc(ListEvaluators).Methods(http.MethodGet)
+	debugR.Path("/graphs/{graph_id}/datasets").HandlerFunc(AddDataset).Methods(http.MethodPost)
+	debugR.Path("/graphs/{graph_id}/datasets").HandlerFunc(ListDatasets).Methods(http.MethodGet)
+	debugR.Path("/graphs/{graph_id}/datasets/{dataset_id}/evaluations").HandlerFunc(RunEvaluation).Methods(http.MethodPost)
+	debugR.Path("/graphs/{graph_id}/evaluations").HandlerFunc(ListEvaluations).Methods(http.MethodGet)
+	debugR.Path("/graphs/{graph_id}/evaluations/{report_id}").HandlerFunc(GetEvaluation).Methods(http.MethodGet)
 }
 
 type HTTPResp struct {
@@ -35,12 +35,60 @@ type DebugRunRequest struct {
 	FromNode string `json:"from_node"`
 	Input    string `json:"input"` // mock input data after json marshal
 	LogID    string `json:"log_id"`
+	// Mode: invoke, stream, collect or transform, invoke by default.
+	// In collect and transform mode, Input is a json array of the input chunks.
+	Mode string `json:"mode,omitempty"`
+	// Breakpoints: the run pauses at them until resumed, see ResumeDebugRunRequest.
+	Breakpoints []Breakpoint `json:"breakpoints,omitempty"`
+	// Stubs: the nodes replaced with canned outputs, e.g. chat models, so that they make no real calls.
+	Stubs []*devmodel.NodeStub `json:"stubs,omitempty"`
+}
+
+type Breakpoint struct {
+	NodeKey string `json:"node_key"`
+	// Position: before or after the node runs.
+	Position string `json:"position"`
+}
+
+type ResumeDebugRunRequest struct {
+	// NodeKey: the paused node, may be empty if only one node is paused.
+	NodeKey string `json:"node_key,omitempty"`
+	// Action: continue, step or abort.
+	Action string `json:"action"`
+	// Input: the edited input of a node paused before it runs, json marshal string.
+	Input *string `json:"input,omitempty"`
+	// Output: the edited output of a node paused after it runs, json marshal string.
+	Output *string `json:"output,omitempty"`
+}
+
+type ResumeDebugRunResponse struct{}
+
+type ListDebugRunsResponse struct {
+	Runs []*devmodel.DebugRun `json:"runs"`
+}
+
+type GetDebugRunResponse struct {
+	Run *devmodel.DebugRun `json:"run"`
+}
+
+type ReplayDebugRunRequest struct {
+	// Input: the edited input of the run, the recorded one is used if nil.
+	Input       *string      `json:"input,omitempty"`
+	LogID       string       `json:"log_id"`
+	Breakpoints []Breakpoint `json:"breakpoints,omitempty"`
+	// Stubs: the stubs of the replay, the recorded ones are used if nil.
+	Stubs []*devmodel.NodeStub `json:"stubs,omitempty"`
 }
 
 type DebugRunEventType string
 
 const (
-	debugRunEventOfData   DebugRunEventType = "data"
+	debugRunEventOfData DebugRunEventType = "data"
+	// debugRunEventOfChunk carries a chunk of a node streaming output, the data event with the whole output
+	// follows once the stream is closed.
+	debugRunEventOfChunk DebugRunEventType = "chunk"
+	// debugRunEventOfPaused tells the run is paused at a breakpoint, until resumed.
+	debugRunEventOfPaused DebugRunEventType = "paused"
 	debugRunEventOfFinish DebugRunEventType = "finish"
 	debugRunEventOfError  DebugRunEventType = "error"
 )
@@ -59,6 +107,10 @@ type NodeDebugState struct {
 	Output    string `json:"output,omitempty"`
 	Error     string `json:"error,omitempty"`
 	ErrorType string `json:"error_type,omitempty"`
+	// Paused: before or after, set in paused events only.
+	Paused string `json:"paused,omitempty"`
+	// Stub: the kind of stub the node was replaced with, if any.
+	Stub string `json:"stub,omitempty"`
 
 	Metrics NodeDebugMetrics `json:"metrics,omitempty"`
 }
@@ -72,8 +124,15 @@ type NodeDebugMetrics struct {
 }
 
 func DebugRunDataEVT(debugID string, state *model.NodeDebugState) (s DebugRunEventMsg) {
+	evtType := debugRunEventOfData
+	if state.Chunk {
+		evtType = debugRunEventOfChunk
+	} else if state.Paused != "" {
+		evtType = debugRunEventOfPaused
+	}
+
 	return DebugRunEventMsg{
-		Type:    debugRunEventOfData,
+		Type:    evtType,
 		DebugID: debugID,
 		Content: &NodeDebugState{
 			NodeKey:   state.NodeKey,
@@ -81,6 +140,8 @@ func DebugRunDataEVT(debugID string, state *model.NodeDebugState) (s DebugRunEve
 			Output:    state.Output,
 			Error:     state.Error,
 			ErrorType: string(state.ErrorType),
+			Paused:    string(state.Paused),
+			Stub:      string(state.Stub),
 			Metrics: NodeDebugMetrics{
 				PromptTokens:     state.Metrics.PromptTokens,
 				CompletionTokens: state.Metrics.CompletionTokens,
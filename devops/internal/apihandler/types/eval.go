@@ -0,0 +1,82 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	"github.com/cloudwego/eino-ext/devops/eval"
+	"github.com/cloudwego/eino-ext/devops/internal/model"
+)
+
+type AddDatasetRequest struct {
+	Name string `json:"name"`
+	// Content: the JSONL dataset, a case per line, e.g. {"id": "1", "input": "hi", "expected": "hello"}.
+	Content string `json:"content"`
+}
+
+type Dataset struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	CaseNum      int    `json:"case_num"`
+	CreateTimeMS int64  `json:"create_time_ms"`
+}
+
+type AddDatasetResponse struct {
+	Dataset *Dataset `json:"dataset"`
+}
+
+type ListDatasetsResponse struct {
+	Datasets []*Dataset `json:"datasets"`
+}
+
+type EvaluatorSpec struct {
+	// Name: exact_match, json_path, regex, or the name of an evaluator registered with the dev server.
+	Name    string `json:"name"`
+	Path    string `json:"path,omitempty"`
+	Pattern string `json:"pattern,omitempty"`
+}
+
+type RunEvaluationRequest struct {
+	// Evaluators: exact_match if empty.
+	Evaluators []*EvaluatorSpec `json:"evaluators,omitempty"`
+	// Concurrency: the number of cases run at the same time, 4 by default.
+	Concurrency int `json:"concurrency,omitempty"`
+}
+
+type RunEvaluationResponse struct {
+	ReportID string `json:"report_id"`
+}
+
+type ListEvaluationsResponse struct {
+	Reports []*eval.Report `json:"reports"`
+}
+
+type GetEvaluationResponse struct {
+	Report *eval.Report `json:"report"`
+}
+
+type ListEvaluatorsResponse struct {
+	Evaluators []string `json:"evaluators"`
+}
+
+func ToDataset(ds *model.Dataset) *Dataset {
+	return &Dataset{
+		ID:           ds.ID,
+		Name:         ds.Name,
+		CaseNum:      len(ds.Cases),
+		CreateTimeMS: ds.CreateTimeMS,
+	}
+}
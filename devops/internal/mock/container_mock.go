@@ -70,6 +70,26 @@ func (mr *MockContainerServiceMockRecorder) AddGraphInfo(graphName, graphInfo an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGraphInfo", reflect.TypeOf((*MockContainerService)(nil).AddGraphInfo), graphName, graphInfo)
 }
 
+// BuildDevGraph mocks base method.
+func (m *MockContainerService) BuildDevGraph(graphID, fromNode string, opts ...model.DevGraphOption) (*model.Graph, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{graphID, fromNode}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BuildDevGraph", varargs...)
+	ret0, _ := ret[0].(*model.Graph)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BuildDevGraph indicates an expected call of BuildDevGraph.
+func (mr *MockContainerServiceMockRecorder) BuildDevGraph(graphID, fromNode any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{graphID, fromNode}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildDevGraph", reflect.TypeOf((*MockContainerService)(nil).BuildDevGraph), varargs...)
+}
+
 // CreateCanvas mocks base method.
 func (m *MockContainerService) CreateCanvas(graphID string) (model0.CanvasInfo, error) {
 	m.ctrl.T.Helper()
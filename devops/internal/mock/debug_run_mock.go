@@ -30,6 +30,7 @@ import (
 	reflect "reflect"
 
 	model "github.com/cloudwego/eino-ext/devops/internal/model"
+	model0 "github.com/cloudwego/eino-ext/devops/model"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -87,3 +88,63 @@ func (mr *MockDebugServiceMockRecorder) DebugRun(ctx, m, userInput any) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DebugRun", reflect.TypeOf((*MockDebugService)(nil).DebugRun), ctx, m, userInput)
 }
+
+// GetDebugRun mocks base method.
+func (m *MockDebugService) GetDebugRun(ctx context.Context, graphID, threadID, runID string) (*model0.DebugRun, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDebugRun", ctx, graphID, threadID, runID)
+	ret0, _ := ret[0].(*model0.DebugRun)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDebugRun indicates an expected call of GetDebugRun.
+func (mr *MockDebugServiceMockRecorder) GetDebugRun(ctx, graphID, threadID, runID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDebugRun", reflect.TypeOf((*MockDebugService)(nil).GetDebugRun), ctx, graphID, threadID, runID)
+}
+
+// GetStubbedNodes mocks base method.
+func (m *MockDebugService) GetStubbedNodes(ctx context.Context, graphID, threadID string) (map[string]model0.StubKind, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStubbedNodes", ctx, graphID, threadID)
+	ret0, _ := ret[0].(map[string]model0.StubKind)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStubbedNodes indicates an expected call of GetStubbedNodes.
+func (mr *MockDebugServiceMockRecorder) GetStubbedNodes(ctx, graphID, threadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStubbedNodes", reflect.TypeOf((*MockDebugService)(nil).GetStubbedNodes), ctx, graphID, threadID)
+}
+
+// ListDebugRuns mocks base method.
+func (m *MockDebugService) ListDebugRuns(ctx context.Context, graphID, threadID string) ([]*model0.DebugRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDebugRuns", ctx, graphID, threadID)
+	ret0, _ := ret[0].([]*model0.DebugRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDebugRuns indicates an expected call of ListDebugRuns.
+func (mr *MockDebugServiceMockRecorder) ListDebugRuns(ctx, graphID, threadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDebugRuns", reflect.TypeOf((*MockDebugService)(nil).ListDebugRuns), ctx, graphID, threadID)
+}
+
+// ResumeDebugRun mocks base method.
+func (m *MockDebugService) ResumeDebugRun(ctx context.Context, graphID, threadID, debugID string, cmd *model.ResumeCommand) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeDebugRun", ctx, graphID, threadID, debugID, cmd)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResumeDebugRun indicates an expected call of ResumeDebugRun.
+func (mr *MockDebugServiceMockRecorder) ResumeDebugRun(ctx, graphID, threadID, debugID, cmd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeDebugRun", reflect.TypeOf((*MockDebugService)(nil).ResumeDebugRun), ctx, graphID, threadID, debugID, cmd)
+}
@@ -76,11 +76,16 @@ func initGraphInfo(gi *GraphInfo) *GraphInfo {
 	}
 }
 
-func BuildDevGraph(gi *GraphInfo, fromNode string) (g *Graph, err error) {
+func BuildDevGraph(gi *GraphInfo, fromNode string, opts ...DevGraphOption) (g *Graph, err error) {
 	if fromNode == compose.END {
 		return nil, fmt.Errorf("can not start from end node")
 	}
 
+	o := &devGraphOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	g = &Graph{Graph: compose.NewGraph[any, any](gi.NewGraphOptions...)}
 
 	var (
@@ -98,7 +103,7 @@ func BuildDevGraph(gi *GraphInfo, fromNode string) (g *Graph, err error) {
 
 		if fn != compose.START && !addNodes[fn] {
 			node := gi.Nodes[fn]
-			if err = g.addNode(fn, node); err != nil {
+			if err = g.addDevNode(fn, node, o); err != nil {
 				return nil, err
 			}
 			newGI.Nodes[fn] = node
@@ -108,7 +113,7 @@ func BuildDevGraph(gi *GraphInfo, fromNode string) (g *Graph, err error) {
 		for _, tn := range gi.Edges[fn] {
 			if !addNodes[tn] && tn != compose.END {
 				node := gi.Nodes[tn]
-				if err = g.addNode(tn, node); err != nil {
+				if err = g.addDevNode(tn, node, o); err != nil {
 					return nil, err
 				}
 				newGI.Nodes[tn] = node
@@ -126,7 +131,7 @@ func BuildDevGraph(gi *GraphInfo, fromNode string) (g *Graph, err error) {
 			for tn := range bt.GetEndNode() {
 				if !addNodes[tn] && tn != compose.END {
 					node := gi.Nodes[tn]
-					if err = g.addNode(tn, node); err != nil {
+					if err = g.addDevNode(tn, node, o); err != nil {
 						return nil, err
 					}
 					newGI.Nodes[tn] = node
@@ -366,6 +371,10 @@ func (gi GraphInfo) buildSubGraphSchema() (subGraphSchema map[string]*devmodel.G
 type Graph struct {
 	*compose.Graph[any, any]
 	GraphInfo *GraphInfo
+	// InterceptedNodes the nodes run through the NodeInterceptor, see WithNodeInterceptor.
+	InterceptedNodes map[string]bool
+	// StubbedNodes the nodes replaced with stubs, and the kind of their stub, see WithNodeStub.
+	StubbedNodes map[string]devmodel.StubKind
 }
 
 func (g *Graph) Compile() (Runnable, error) {
@@ -16,6 +16,10 @@
 
 package model
 
+import (
+	devmodel "github.com/cloudwego/eino-ext/devops/model"
+)
+
 type DebugGraph struct {
 	DT []*DebugThread
 }
@@ -32,6 +36,8 @@ func (d DebugGraph) GetDebugThread(threadID string) (thread DebugThread, exist b
 type DebugThread struct {
 	// ID: unique id of each debug thread, from IDE Client.
 	ID string
+	// StubbedNodes: the nodes replaced with stubs in the latest run of the thread, and the kind of their stub.
+	StubbedNodes map[string]devmodel.StubKind
 }
 
 type NodeDebugState struct {
@@ -48,6 +54,17 @@ type NodeDebugState struct {
 	ErrorType ErrorType
 
 	Metrics NodeDebugMetrics
+
+	// Chunk: whether the state is an incremental chunk of a streaming output, Output holding the chunk only.
+	// The state with the aggregated output follows once the stream is closed.
+	Chunk bool
+
+	// Paused: set when the run is paused at a breakpoint before or after the node, Input or Output holding
+	// the value that can be edited before resuming, see ResumeCommand.
+	Paused BreakpointPosition
+
+	// Stub: set when the node is replaced with a stub, the output being the canned one.
+	Stub devmodel.StubKind
 }
 
 type NodeDebugMetrics struct {
@@ -62,6 +79,77 @@ type DebugRunMeta struct {
 	GraphID  string
 	ThreadID string
 	FromNode string
+	// Mode: how the graph is run, RunModeInvoke by default.
+	Mode RunMode
+	// ReplayOf: the id of the recorded run replayed by this one, if any.
+	ReplayOf string
+	// Breakpoints: where the run pauses, until resumed by a ResumeCommand.
+	Breakpoints []Breakpoint
+	// Stubs: the nodes replaced with canned outputs.
+	Stubs []*devmodel.NodeStub
+}
+
+type BreakpointPosition string
+
+const (
+	BreakpointBefore BreakpointPosition = "before"
+	BreakpointAfter  BreakpointPosition = "after"
+)
+
+type Breakpoint struct {
+	NodeKey  string
+	Position BreakpointPosition
+}
+
+type ResumeAction string
+
+const (
+	// ResumeActionContinue: run until the next breakpoint.
+	ResumeActionContinue ResumeAction = "continue"
+	// ResumeActionStep: run until the next node starts or ends, whether it has a breakpoint or not.
+	ResumeActionStep ResumeAction = "step"
+	// ResumeActionAbort: stop the run with an error.
+	ResumeActionAbort ResumeAction = "abort"
+)
+
+// ResumeCommand resumes a run paused at a breakpoint.
+type ResumeCommand struct {
+	// NodeKey: the paused node to resume, may be empty if only one node is paused.
+	NodeKey string
+	Action  ResumeAction
+	// Input: the edited input of the node paused before it runs, json marshal string.
+	Input *string
+	// Output: the edited output of the node paused after it runs, json marshal string.
+	Output *string
+}
+
+// RunMode is the way a debug run calls the graph, see compose.Runnable.
+type RunMode string
+
+const (
+	// RunModeInvoke: ping pong, the input is a single value.
+	RunModeInvoke RunMode = "invoke"
+	// RunModeStream: the input is a single value, the output is a stream.
+	RunModeStream RunMode = "stream"
+	// RunModeCollect: the input is a stream, given as a json array of chunks.
+	RunModeCollect RunMode = "collect"
+	// RunModeTransform: the input is a stream, given as a json array of chunks, the output is a stream.
+	RunModeTransform RunMode = "transform"
+)
+
+// IsValid reports whether the mode is known, the empty mode meaning RunModeInvoke.
+func (m RunMode) IsValid() bool {
+	switch m {
+	case "", RunModeInvoke, RunModeStream, RunModeCollect, RunModeTransform:
+		return true
+	default:
+		return false
+	}
+}
+
+// StreamInput reports whether the graph input is a stream in this mode.
+func (m RunMode) StreamInput() bool {
+	return m == RunModeCollect || m == RunModeTransform
 }
 
 type ErrorType string
@@ -0,0 +1,46 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"github.com/cloudwego/eino-ext/devops/eval"
+)
+
+// Dataset is a set of cases registered against a graph, to evaluate it.
+type Dataset struct {
+	ID           string
+	GraphID      string
+	Name         string
+	Cases        []*eval.Case
+	CreateTimeMS int64
+}
+
+// EvaluatorSpec selects an evaluator of an evaluation.
+type EvaluatorSpec struct {
+	// Name: exact_match, json_path, regex, or the name of an evaluator registered with the dev server.
+	Name string
+	// Path: the json path of json_path.
+	Path string
+	// Pattern: the pattern of regex.
+	Pattern string
+}
+
+type EvalConfig struct {
+	Evaluators []*EvaluatorSpec
+	// Concurrency: the number of cases run at the same time.
+	Concurrency int
+}
@@ -0,0 +1,226 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/compose"
+
+	devmodel "github.com/cloudwego/eino-ext/devops/model"
+)
+
+// NodeInterceptor hooks into the nodes of a dev graph, e.g. to pause a debug run on breakpoints.
+// The input and output are the ones of the node itself, without its input or output key.
+type NodeInterceptor interface {
+	// BeforeNode is called before the node runs, and returns the input to run it with.
+	BeforeNode(ctx context.Context, nodeKey string, input any) (any, error)
+	// AfterNode is called once the node succeeds, and returns the output to pass on.
+	AfterNode(ctx context.Context, nodeKey string, output any) (any, error)
+}
+
+// NodeStubFunc replaces a node of a dev graph, returning its output from its input. As for NodeInterceptor,
+// the input and output are the ones of the node itself, without its input or output key.
+type NodeStubFunc func(ctx context.Context, input any) (output any, err error)
+
+type DevGraphOption func(*devGraphOptions)
+
+type devGraphOptions struct {
+	interceptor NodeInterceptor
+	stubs       map[string]nodeStub
+}
+
+type nodeStub struct {
+	kind devmodel.StubKind
+	fn   NodeStubFunc
+}
+
+// WithNodeInterceptor intercepts the nodes of the dev graph. Each node is run in a lambda of the same key,
+// so that its input and output can be replaced, at the cost of streaming: the node is invoked.
+// Nodes with state handlers are left as is, see Graph.InterceptedNodes.
+func WithNodeInterceptor(interceptor NodeInterceptor) DevGraphOption {
+	return func(o *devGraphOptions) {
+		o.interceptor = interceptor
+	}
+}
+
+// WithNodeStub replaces the node of the key with a lambda calling stub, the state handlers of the node
+// being skipped too. The stub is intercepted as well if there is a NodeInterceptor.
+// The kind tells where the stub output comes from, see Graph.StubbedNodes.
+func WithNodeStub(nodeKey string, kind devmodel.StubKind, stub NodeStubFunc) DevGraphOption {
+	return func(o *devGraphOptions) {
+		if o.stubs == nil {
+			o.stubs = make(map[string]nodeStub)
+		}
+		o.stubs[nodeKey] = nodeStub{kind: kind, fn: stub}
+	}
+}
+
+func (g *Graph) addDevNode(key string, node compose.GraphNodeInfo, o *devGraphOptions) error {
+	var (
+		invoke  func(ctx context.Context, input any) (any, error)
+		stub    nodeStub
+		stubbed bool
+	)
+	if stub, stubbed = o.stubs[key]; stubbed {
+		invoke = stubInvoker(node, stub.fn)
+	} else if o.interceptor != nil {
+		invoke = nodeInvoker(key, node)
+	}
+	if invoke == nil {
+		return g.addNode(key, node)
+	}
+
+	if err := g.AddLambdaNode(key, devNodeLambda(key, node, invoke, o.interceptor), compose.WithNodeName(node.Name)); err != nil {
+		return err
+	}
+
+	if o.interceptor != nil {
+		if g.InterceptedNodes == nil {
+			g.InterceptedNodes = make(map[string]bool)
+		}
+		g.InterceptedNodes[key] = true
+	}
+	if stubbed {
+		if g.StubbedNodes == nil {
+			g.StubbedNodes = make(map[string]devmodel.StubKind)
+		}
+		g.StubbedNodes[key] = stub.kind
+	}
+
+	return nil
+}
+
+// nodeInvoker runs the node as the only node of a graph. It is nil if the node can not be run this way,
+// which is the case of nodes using the state of the graph.
+func nodeInvoker(key string, node compose.GraphNodeInfo) func(ctx context.Context, input any) (any, error) {
+	for _, opt := range node.GraphAddNodeOpts {
+		if needState(opt) {
+			return nil
+		}
+	}
+
+	ng := &Graph{
+		Graph:     compose.NewGraph[any, any](),
+		GraphInfo: &GraphInfo{GraphInfo: &compose.GraphInfo{}},
+	}
+	if err := ng.addNode(key, node); err != nil {
+		return nil
+	}
+	if err := ng.AddEdge(compose.START, key); err != nil {
+		return nil
+	}
+	if err := ng.AddEdge(key, compose.END); err != nil {
+		return nil
+	}
+	r, err := ng.Compile()
+	if err != nil {
+		return nil
+	}
+
+	return func(ctx context.Context, input any) (any, error) {
+		return r.r.Invoke(ctx, input)
+	}
+}
+
+// stubInvoker calls the stub in place of the node, with its input and output keys.
+func stubInvoker(node compose.GraphNodeInfo, stub NodeStubFunc) func(ctx context.Context, input any) (any, error) {
+	return func(ctx context.Context, input any) (any, error) {
+		output, err := stub(ctx, unwrapKey(input, node.InputKey))
+		if err != nil {
+			return nil, err
+		}
+		if node.OutputKey != "" {
+			return map[string]any{node.OutputKey: output}, nil
+		}
+		return output, nil
+	}
+}
+
+// devNodeLambda wraps the invoker of the node in a lambda, passing its input and output to the interceptor,
+// if any.
+func devNodeLambda(key string, node compose.GraphNodeInfo, invoke func(ctx context.Context, input any) (any, error),
+	interceptor NodeInterceptor) *compose.Lambda {
+	run := func(ctx context.Context, input any) (output any, err error) {
+		in := unwrapKey(input, node.InputKey)
+		if interceptor != nil {
+			in, err = interceptor.BeforeNode(ctx, key, in)
+			if err != nil {
+				return nil, err
+			}
+			input = wrapKey(input, node.InputKey, in)
+		}
+
+		// the lambda reports the callbacks itself, with the input and output replaced by the interceptor
+		ctx = callbacks.OnStart(ctx, in)
+		output, err = invoke(ctx, input)
+		if err != nil {
+			callbacks.OnError(ctx, err)
+			return nil, err
+		}
+
+		out := unwrapKey(output, node.OutputKey)
+		if interceptor != nil {
+			out, err = interceptor.AfterNode(ctx, key, out)
+			if err != nil {
+				callbacks.OnError(ctx, err)
+				return nil, err
+			}
+			output = wrapKey(output, node.OutputKey, out)
+		}
+		callbacks.OnEnd(ctx, out)
+
+		return output, nil
+	}
+
+	return compose.InvokableLambda(run, compose.WithLambdaCallbackEnable(true))
+}
+
+// needState reports whether the option requires the state of the graph, e.g. a state handler.
+func needState(opt compose.GraphAddNodeOpt) bool {
+	g := compose.NewGraph[any, any]()
+	return g.AddPassthroughNode("node", opt) != nil
+}
+
+func unwrapKey(v any, key string) any {
+	if key == "" {
+		return v
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return v
+	}
+	return m[key]
+}
+
+func wrapKey(v any, key string, value any) any {
+	if key == "" {
+		return value
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return value
+	}
+
+	cp := make(map[string]any, len(m))
+	for k, val := range m {
+		cp[k] = val
+	}
+	cp[key] = value
+	return cp
+}
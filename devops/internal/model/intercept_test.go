@@ -0,0 +1,139 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino/compose"
+
+	devmodel "github.com/cloudwego/eino-ext/devops/model"
+)
+
+type testInterceptor struct {
+	before []string
+	after  []string
+}
+
+func (ti *testInterceptor) BeforeNode(ctx context.Context, nodeKey string, input any) (any, error) {
+	ti.before = append(ti.before, nodeKey)
+	if nodeKey == "upper" {
+		return input.(string) + " b", nil
+	}
+	return input, nil
+}
+
+func (ti *testInterceptor) AfterNode(ctx context.Context, nodeKey string, output any) (any, error) {
+	ti.after = append(ti.after, nodeKey)
+	if nodeKey == "upper" {
+		return output.(string) + "!", nil
+	}
+	return output, nil
+}
+
+func Test_BuildDevGraph_WithNodeInterceptor(t *testing.T) {
+	ctx := context.Background()
+
+	g := compose.NewGraph[string, map[string]any](compose.WithGenLocalState(func(ctx context.Context) *struct{} {
+		return &struct{}{}
+	}))
+	err := g.AddLambdaNode("upper", compose.InvokableLambda(func(ctx context.Context, input string) (string, error) {
+		return strings.ToUpper(input), nil
+	}), compose.WithOutputKey("upper"))
+	assert.NoError(t, err)
+	err = g.AddLambdaNode("count", compose.InvokableLambda(func(ctx context.Context, input map[string]any) (int, error) {
+		return len(input["upper"].(string)), nil
+	}), compose.WithOutputKey("count"))
+	assert.NoError(t, err)
+	err = g.AddLambdaNode("state", compose.InvokableLambda(func(ctx context.Context, input map[string]any) (map[string]any, error) {
+		return input, nil
+	}), compose.WithStatePreHandler(func(ctx context.Context, in map[string]any, state *struct{}) (map[string]any, error) {
+		return in, nil
+	}))
+	assert.NoError(t, err)
+	err = g.AddEdge(compose.START, "upper")
+	assert.NoError(t, err)
+	err = g.AddBranch("upper", compose.NewGraphBranch(func(ctx context.Context, in map[string]any) (string, error) {
+		return "count", nil
+	}, map[string]bool{"count": true, compose.END: true}))
+	assert.NoError(t, err)
+	err = g.AddEdge("count", "state")
+	assert.NoError(t, err)
+	err = g.AddEdge("state", compose.END)
+	assert.NoError(t, err)
+
+	tc := &testCallback{}
+	_, err = g.Compile(ctx, compose.WithGraphCompileCallbacks(tc))
+	assert.NoError(t, err)
+
+	ti := &testInterceptor{}
+	ng, err := BuildDevGraph(tc.gi, compose.START, WithNodeInterceptor(ti))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"upper": true, "count": true}, ng.InterceptedNodes)
+
+	r, err := ng.Compile()
+	assert.NoError(t, err)
+
+	input, err := UnmarshalJson([]byte(`"a"`), ng.GraphInfo.InputType)
+	assert.NoError(t, err)
+	resp, err := r.Invoke(ctx, input)
+	assert.NoError(t, err)
+
+	// count gets the input and output of upper edited: "A B!"
+	assert.Equal(t, map[string]any{"count": 4}, resp)
+	assert.Equal(t, []string{"upper", "count"}, ti.before)
+	assert.Equal(t, []string{"upper", "count"}, ti.after)
+}
+
+func Test_BuildDevGraph_WithNodeStub(t *testing.T) {
+	ctx := context.Background()
+
+	g := compose.NewGraph[string, map[string]any]()
+	err := g.AddLambdaNode("upper", compose.InvokableLambda(func(ctx context.Context, input string) (string, error) {
+		return "", fmt.Errorf("should be stubbed")
+	}), compose.WithOutputKey("upper"))
+	assert.NoError(t, err)
+	err = g.AddEdge(compose.START, "upper")
+	assert.NoError(t, err)
+	err = g.AddEdge("upper", compose.END)
+	assert.NoError(t, err)
+
+	tc := &testCallback{}
+	_, err = g.Compile(ctx, compose.WithGraphCompileCallbacks(tc))
+	assert.NoError(t, err)
+
+	ng, err := BuildDevGraph(tc.gi, compose.START, WithNodeStub("upper", devmodel.StubKindFixed, func(ctx context.Context, input any) (any, error) {
+		return strings.ToUpper(input.(string)) + " stub", nil
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]devmodel.StubKind{"upper": devmodel.StubKindFixed}, ng.StubbedNodes)
+	assert.Empty(t, ng.InterceptedNodes)
+
+	r, err := ng.Compile()
+	assert.NoError(t, err)
+
+	input, err := UnmarshalJson([]byte(`"a"`), ng.GraphInfo.InputType)
+	assert.NoError(t, err)
+	resp, err := r.Invoke(ctx, input)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"upper": "A stub"}, resp)
+}
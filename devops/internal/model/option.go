@@ -16,13 +16,30 @@
 
 package model
 
+import (
+	"github.com/cloudwego/eino-ext/devops/eval"
+	devmodel "github.com/cloudwego/eino-ext/devops/model"
+)
+
 const (
 	defaultHttpPort = "52538"
 )
 
 type DevOpt struct {
 	DevServerPort string
-	GoTypes       []RegisteredType
+	// DevServerBindAddress is the host the dev server listens on, all the interfaces if empty.
+	DevServerBindAddress string
+	// AuthToken is the bearer token required by every route of the dev server, no auth if empty.
+	AuthToken string
+	// CORSAllowedOrigins are the origins allowed by CORS, any origin if empty.
+	CORSAllowedOrigins []string
+	GoTypes            []RegisteredType
+	// DebugRunStore keeps the debug run history, in memory if not set.
+	DebugRunStore devmodel.DebugRunStore
+	// Evaluators can be chosen by name in evaluations, besides the built-in ones.
+	Evaluators []eval.Evaluator
+	// EvalReportDir is where the evaluation reports are written, in the temp dir if empty.
+	EvalReportDir string
 }
 
 type DevOption func(*DevOpt)
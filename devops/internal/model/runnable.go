@@ -18,10 +18,13 @@ package model
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"reflect"
 
 	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
 )
 
 type Runnable struct {
@@ -46,6 +49,72 @@ func (dr Runnable) Invoke(ctx context.Context, input reflect.Value, opts ...comp
 	return res[0].Interface(), nil
 }
 
+// Stream runs the graph, and returns its output stream.
+func (dr Runnable) Stream(ctx context.Context, input reflect.Value, opts ...compose.Option) (output *schema.StreamReader[any], err error) {
+	callArgs := make([]reflect.Value, 0, len(opts))
+	callArgs = append(callArgs, reflect.ValueOf(ctx), input)
+	for _, opt := range opts {
+		callArgs = append(callArgs, reflect.ValueOf(opt))
+	}
+
+	res := reflect.ValueOf(dr.r).MethodByName("Stream").Call(callArgs)
+	if !res[1].IsNil() {
+		return nil, res[1].Interface().(error)
+	}
+	if res[0].IsNil() {
+		return nil, fmt.Errorf("output stream is nil")
+	}
+
+	return res[0].Interface().(*schema.StreamReader[any]), nil
+}
+
+// Collect runs the graph with the input chunks as a stream, and returns the chunks of its output.
+// The dev graph outputs any, the chunks of which eino is not able to concat, so they are returned as is.
+func (dr Runnable) Collect(ctx context.Context, inputs []reflect.Value, opts ...compose.Option) (output []any, err error) {
+	sr, err := dr.Transform(ctx, inputs, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer sr.Close()
+
+	for {
+		chunk, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, chunk)
+	}
+	if len(output) == 0 {
+		return nil, fmt.Errorf("output is empty")
+	}
+
+	return output, nil
+}
+
+// Transform runs the graph with the input chunks as a stream, and returns its output stream.
+func (dr Runnable) Transform(ctx context.Context, inputs []reflect.Value, opts ...compose.Option) (output *schema.StreamReader[any], err error) {
+	output, err = dr.r.Transform(ctx, streamOf(inputs), opts...)
+	if err != nil {
+		return nil, err
+	}
+	if output == nil {
+		return nil, fmt.Errorf("output stream is nil")
+	}
+
+	return output, nil
+}
+
+func streamOf(inputs []reflect.Value) *schema.StreamReader[any] {
+	chunks := make([]any, 0, len(inputs))
+	for _, in := range inputs {
+		chunks = append(chunks, in.Interface())
+	}
+	return schema.StreamReaderFromArray(chunks)
+}
+
 func getPtrValue(typ reflect.Value, level int) reflect.Value {
 	for i := 0; i < level; i++ {
 		newInput := reflect.New(typ.Type())
@@ -0,0 +1,179 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino-ext/devops/internal/model"
+)
+
+var _ model.NodeInterceptor = &debugSession{}
+
+// debugSession pauses a debug run on its breakpoints, until the client resumes it.
+type debugSession struct {
+	graphID  string
+	threadID string
+	// graph: the dev graph of the run, to get the types of the edited values.
+	graph       *model.Graph
+	stateCh     chan *model.NodeDebugState
+	breakpoints map[model.Breakpoint]bool
+
+	mu       sync.Mutex
+	stepping bool
+	// paused: node key vs the channel of its resume command
+	paused map[string]chan *model.ResumeCommand
+}
+
+func newDebugSession(rm *model.DebugRunMeta, stateCh chan *model.NodeDebugState) *debugSession {
+	breakpoints := make(map[model.Breakpoint]bool, len(rm.Breakpoints))
+	for _, bp := range rm.Breakpoints {
+		breakpoints[bp] = true
+	}
+
+	return &debugSession{
+		graphID:     rm.GraphID,
+		threadID:    rm.ThreadID,
+		stateCh:     stateCh,
+		breakpoints: breakpoints,
+		paused:      make(map[string]chan *model.ResumeCommand),
+	}
+}
+
+// checkBreakpoints checks that the nodes with breakpoints can be paused.
+func (s *debugSession) checkBreakpoints() error {
+	for bp := range s.breakpoints {
+		if !s.graph.InterceptedNodes[bp.NodeKey] {
+			return fmt.Errorf("node=%s not found or does not support breakpoints", bp.NodeKey)
+		}
+	}
+	return nil
+}
+
+func (s *debugSession) BeforeNode(ctx context.Context, nodeKey string, input any) (any, error) {
+	cmd, err := s.pause(ctx, nodeKey, model.BreakpointBefore, input)
+	if err != nil || cmd == nil || cmd.Input == nil {
+		return input, err
+	}
+
+	return unmarshalNodeValue(nodeKey, *cmd.Input, s.graph.GraphInfo.Nodes[nodeKey].InputType)
+}
+
+func (s *debugSession) AfterNode(ctx context.Context, nodeKey string, output any) (any, error) {
+	cmd, err := s.pause(ctx, nodeKey, model.BreakpointAfter, output)
+	if err != nil || cmd == nil || cmd.Output == nil {
+		return output, err
+	}
+
+	return unmarshalNodeValue(nodeKey, *cmd.Output, s.graph.GraphInfo.Nodes[nodeKey].OutputType)
+}
+
+// pause blocks the node until it is resumed, if it has a breakpoint at pos or the client is stepping.
+// cmd is nil if the node did not pause.
+func (s *debugSession) pause(ctx context.Context, nodeKey string, pos model.BreakpointPosition, value any) (cmd *model.ResumeCommand, err error) {
+	s.mu.Lock()
+	if !s.stepping && !s.breakpoints[model.Breakpoint{NodeKey: nodeKey, Position: pos}] {
+		s.mu.Unlock()
+		return nil, nil
+	}
+	resumeCh := make(chan *model.ResumeCommand, 1)
+	s.paused[nodeKey] = resumeCh
+	s.mu.Unlock()
+
+	jsonValue, err := json.Marshal(value)
+	if err != nil {
+		s.unpause(nodeKey)
+		return nil, fmt.Errorf("error serializing paused value to json, err=%w", err)
+	}
+
+	state := &model.NodeDebugState{
+		NodeKey: nodeKey,
+		Paused:  pos,
+		Metrics: model.NodeDebugMetrics{
+			InvokeTimeMS: time.Now().UnixMilli(),
+		},
+	}
+	if pos == model.BreakpointBefore {
+		state.Input = string(jsonValue)
+	} else {
+		state.Output = string(jsonValue)
+	}
+	s.stateCh <- state
+
+	select {
+	case <-ctx.Done():
+		s.unpause(nodeKey)
+		return nil, ctx.Err()
+	case cmd = <-resumeCh:
+	}
+
+	s.mu.Lock()
+	s.stepping = cmd.Action == model.ResumeActionStep
+	s.mu.Unlock()
+
+	if cmd.Action == model.ResumeActionAbort {
+		return nil, fmt.Errorf("debug run aborted at node=%s", nodeKey)
+	}
+
+	return cmd, nil
+}
+
+func (s *debugSession) unpause(nodeKey string) {
+	s.mu.Lock()
+	delete(s.paused, nodeKey)
+	s.mu.Unlock()
+}
+
+// resume passes the command to the paused node.
+func (s *debugSession) resume(cmd *model.ResumeCommand) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nodeKey := cmd.NodeKey
+	if nodeKey == "" {
+		if len(s.paused) != 1 {
+			return fmt.Errorf("node_key is required when the paused nodes are [%s]", strings.Join(s.pausedNodes(), ","))
+		}
+		for k := range s.paused {
+			nodeKey = k
+		}
+	}
+
+	resumeCh, ok := s.paused[nodeKey]
+	if !ok {
+		return fmt.Errorf("node=%s is not paused", nodeKey)
+	}
+	delete(s.paused, nodeKey)
+	resumeCh <- cmd
+
+	return nil
+}
+
+func (s *debugSession) pausedNodes() []string {
+	nodes := make([]string, 0, len(s.paused))
+	for k := range s.paused {
+		nodes = append(nodes, k)
+	}
+	sort.Strings(nodes)
+	return nodes
+}
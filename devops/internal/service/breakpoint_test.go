@@ -0,0 +1,131 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino-ext/devops/internal/model"
+	"github.com/cloudwego/eino/compose"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_debugServiceImpl_DebugRun_Breakpoints(t *testing.T) {
+	graphID := addDebugRunTestGraph(t)
+
+	// run runs the graph, and answers the paused states in order with cmds
+	run := func(breakpoints []model.Breakpoint, cmds ...*model.ResumeCommand) (paused, states []*model.NodeDebugState, errs []error) {
+		svc := newDebugService()
+		threadID, err := svc.CreateDebugThread(context.Background(), graphID)
+		assert.Nil(t, err)
+
+		debugID, stateCh, errCh, err := svc.DebugRun(context.Background(), &model.DebugRunMeta{
+			GraphID:     graphID,
+			ThreadID:    threadID,
+			FromNode:    compose.START,
+			Breakpoints: breakpoints,
+		}, `"a b c"`)
+		assert.Nil(t, err)
+
+		for state := range stateCh {
+			if state.Paused == "" {
+				states = append(states, state)
+				continue
+			}
+			paused = append(paused, state)
+			if assert.NotEmpty(t, cmds) {
+				err = svc.ResumeDebugRun(context.Background(), graphID, threadID, debugID, cmds[0])
+				assert.Nil(t, err)
+				cmds = cmds[1:]
+			}
+		}
+		for e := range errCh {
+			errs = append(errs, e)
+		}
+		return paused, states, errs
+	}
+
+	t.Run("edit input", func(t *testing.T) {
+		input := `"x y"`
+		paused, states, errs := run([]model.Breakpoint{{NodeKey: "split", Position: model.BreakpointBefore}},
+			&model.ResumeCommand{Action: model.ResumeActionContinue, Input: &input})
+		assert.Empty(t, errs)
+		if assert.Len(t, paused, 1) {
+			assert.Equal(t, model.BreakpointBefore, paused[0].Paused)
+			assert.Equal(t, `"a b c"`, paused[0].Input)
+		}
+		if assert.NotEmpty(t, states) {
+			assert.Equal(t, `"x y"`, states[len(states)-1].Output)
+		}
+	})
+
+	t.Run("edit output", func(t *testing.T) {
+		output := `"z"`
+		paused, states, errs := run([]model.Breakpoint{{NodeKey: "split", Position: model.BreakpointAfter}},
+			&model.ResumeCommand{NodeKey: "split", Action: model.ResumeActionContinue, Output: &output})
+		assert.Empty(t, errs)
+		if assert.Len(t, paused, 1) {
+			assert.Equal(t, model.BreakpointAfter, paused[0].Paused)
+			assert.Equal(t, `"a b c"`, paused[0].Output)
+		}
+		if assert.NotEmpty(t, states) {
+			assert.Equal(t, `"z"`, states[len(states)-1].Output)
+		}
+	})
+
+	t.Run("step", func(t *testing.T) {
+		paused, _, errs := run([]model.Breakpoint{{NodeKey: "split", Position: model.BreakpointBefore}},
+			&model.ResumeCommand{Action: model.ResumeActionStep},
+			&model.ResumeCommand{Action: model.ResumeActionContinue})
+		assert.Empty(t, errs)
+		if assert.Len(t, paused, 2) {
+			assert.Equal(t, model.BreakpointBefore, paused[0].Paused)
+			assert.Equal(t, model.BreakpointAfter, paused[1].Paused)
+		}
+	})
+
+	t.Run("abort", func(t *testing.T) {
+		paused, _, errs := run([]model.Breakpoint{{NodeKey: "split", Position: model.BreakpointBefore}},
+			&model.ResumeCommand{Action: model.ResumeActionAbort})
+		assert.Len(t, paused, 1)
+		if assert.Len(t, errs, 1) {
+			assert.Contains(t, errs[0].Error(), "aborted")
+		}
+	})
+
+	t.Run("unknown node", func(t *testing.T) {
+		svc := newDebugService()
+		threadID, err := svc.CreateDebugThread(context.Background(), graphID)
+		assert.Nil(t, err)
+
+		_, _, _, err = svc.DebugRun(context.Background(), &model.DebugRunMeta{
+			GraphID:     graphID,
+			ThreadID:    threadID,
+			FromNode:    compose.START,
+			Breakpoints: []model.Breakpoint{{NodeKey: "unknown", Position: model.BreakpointBefore}},
+		}, `"a b c"`)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("not running", func(t *testing.T) {
+		svc := newDebugService()
+		err := svc.ResumeDebugRun(context.Background(), graphID, "t", "r", &model.ResumeCommand{Action: model.ResumeActionContinue})
+		assert.NotNil(t, err)
+	})
+}
@@ -22,10 +22,13 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/cloudwego/eino-ext/devops/internal/model"
 	"github.com/cloudwego/eino-ext/devops/internal/utils/log"
+	"github.com/cloudwego/eino-ext/devops/internal/utils/safego"
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components/embedding"
 	"github.com/cloudwego/eino/components/indexer"
@@ -36,12 +39,14 @@ import (
 	"github.com/cloudwego/eino/schema"
 )
 
-func newCallbackOption(nodeKey, threadID string, node compose.GraphNodeInfo, stateCh chan *model.NodeDebugState) compose.Option {
+func newCallbackOption(nodeKey, threadID string, node compose.GraphNodeInfo, stateCh chan *model.NodeDebugState,
+	streamWG *sync.WaitGroup) compose.Option {
 	cb := &callbackHandler{
 		nodeKey:  nodeKey,
 		threadID: threadID,
 		stateCh:  stateCh,
 		node:     node,
+		streamWG: streamWG,
 	}
 	op := compose.WithCallbacks(cb).DesignateNode(nodeKey)
 	return op
@@ -52,6 +57,9 @@ type callbackHandler struct {
 	stateCh  chan *model.NodeDebugState
 	threadID string
 	node     compose.GraphNodeInfo
+	// streamWG, if set, tracks the goroutines forwarding output streams,
+	// so that stateCh is closed only once they are done.
+	streamWG *sync.WaitGroup
 }
 
 func (c *callbackHandler) OnStart(ctx context.Context, info *callbacks.RunInfo, input callbacks.CallbackInput) context.Context {
@@ -204,8 +212,6 @@ func (c *callbackHandler) OnStartWithStreamInput(ctx context.Context, info *call
 
 func (c *callbackHandler) OnEndWithStreamOutput(ctx context.Context, info *callbacks.RunInfo,
 	output *schema.StreamReader[callbacks.CallbackOutput]) context.Context {
-	defer output.Close()
-	completionTime := time.Now().UnixMilli()
 	var (
 		startTime int64
 		jsonInput string
@@ -214,23 +220,36 @@ func (c *callbackHandler) OnEndWithStreamOutput(ctx context.Context, info *callb
 	if ctxValOK {
 		if ctxVal.depth > 1 {
 			ctxVal.depth--
+			output.Close()
 			return ctx
 		}
 		startTime = ctxVal.invokeTimeMS
 		jsonInput = ctxVal.callbackInput
 	}
 
-	state, recvErr := c.parseDefaultStreamOutput(ctx, output)
-	if recvErr != nil {
-		c.systemErrorProcess(fmt.Sprintf("parse stream output failed, err=%v", recvErr), startTime, completionTime)
-		return ctx
+	// The stream is forwarded asynchronously, so that downstream nodes are not blocked until it ends.
+	if c.streamWG != nil {
+		c.streamWG.Add(1)
 	}
+	safego.Go(ctx, func() {
+		defer output.Close()
+		if c.streamWG != nil {
+			defer c.streamWG.Done()
+		}
+
+		state, recvErr := c.parseDefaultStreamOutput(ctx, output, startTime)
+		if recvErr != nil {
+			c.systemErrorProcess(fmt.Sprintf("parse stream output failed, err=%v", recvErr), startTime, time.Now().UnixMilli())
+			return
+		}
+
+		state.NodeKey = c.nodeKey
+		state.Input = jsonInput
+		state.Metrics.InvokeTimeMS = startTime
+		state.Metrics.CompletionTimeMS = time.Now().UnixMilli()
+		c.stateCh <- state
+	})
 
-	state.NodeKey = c.nodeKey
-	state.Input = jsonInput
-	state.Metrics.InvokeTimeMS = startTime
-	state.Metrics.CompletionTimeMS = time.Now().UnixMilli()
-	c.stateCh <- state
 	return ctx
 }
 
@@ -254,9 +273,12 @@ func (c *callbackHandler) parseDefaultStreamInput(ctx context.Context, input *sc
 	return chunks, nil
 }
 
-func (c *callbackHandler) parseDefaultStreamOutput(ctx context.Context, output *schema.StreamReader[callbacks.CallbackOutput]) (state *model.NodeDebugState, err error) {
+// parseDefaultStreamOutput forwards every chunk of the output stream as a chunk state,
+// and returns the state of the whole output once the stream is closed.
+func (c *callbackHandler) parseDefaultStreamOutput(ctx context.Context, output *schema.StreamReader[callbacks.CallbackOutput],
+	invokeTime int64) (state *model.NodeDebugState, err error) {
 	state = &model.NodeDebugState{}
-	chunks := make([]callbacks.CallbackOutput, 0)
+	chunks := make([]any, 0)
 	for {
 		item, recvErr := output.Recv()
 		if recvErr != nil {
@@ -273,12 +295,23 @@ func (c *callbackHandler) parseDefaultStreamOutput(ctx context.Context, output *
 		}
 
 		callbackOutput := c.convCallbackOutput(item)
-		if len(c.node.OutputKey) > 0 {
-			callbackOutput = map[string]any{c.node.OutputKey: callbackOutput}
-		}
 		chunks = append(chunks, callbackOutput)
+
+		jsonChunk, marshalErr := json.Marshal(c.withOutputKey(callbackOutput))
+		if marshalErr != nil {
+			log.Errorf("error serializing output chunk to JSON, err=%v", marshalErr)
+			return nil, marshalErr
+		}
+		c.stateCh <- &model.NodeDebugState{
+			NodeKey: c.nodeKey,
+			Output:  string(jsonChunk),
+			Chunk:   true,
+			Metrics: model.NodeDebugMetrics{
+				InvokeTimeMS: invokeTime,
+			},
+		}
 	}
-	jsonData, err := json.Marshal(chunks)
+	jsonData, err := json.Marshal(c.withOutputKey(concatStreamOutput(chunks)))
 	if err != nil {
 		log.Errorf("error serializing output to JSON, err=%v", err)
 		return nil, err
@@ -288,6 +321,51 @@ func (c *callbackHandler) parseDefaultStreamOutput(ctx context.Context, output *
 	return state, nil
 }
 
+func (c *callbackHandler) withOutputKey(output any) any {
+	if len(c.node.OutputKey) > 0 {
+		return map[string]any{c.node.OutputKey: output}
+	}
+	return output
+}
+
+// concatStreamOutput aggregates the chunks of a stream: messages and strings are concatenated,
+// other chunks are kept as a list.
+func concatStreamOutput(chunks []any) any {
+	if len(chunks) == 0 {
+		return chunks
+	}
+
+	switch chunks[0].(type) {
+	case *schema.Message:
+		msgs := make([]*schema.Message, 0, len(chunks))
+		for _, chunk := range chunks {
+			msg, ok := chunk.(*schema.Message)
+			if !ok || msg == nil {
+				return chunks
+			}
+			msgs = append(msgs, msg)
+		}
+		msg, err := schema.ConcatMessages(msgs)
+		if err != nil {
+			log.Warnf("concat message chunks failed, err=%v", err)
+			return chunks
+		}
+		return msg
+	case string:
+		sb := strings.Builder{}
+		for _, chunk := range chunks {
+			str, ok := chunk.(string)
+			if !ok {
+				return chunks
+			}
+			sb.WriteString(str)
+		}
+		return sb.String()
+	default:
+		return chunks
+	}
+}
+
 func (c *callbackHandler) ConvCallbackOutput(src callbacks.CallbackOutput) *einomodel.CallbackOutput {
 	switch t := src.(type) {
 	case *einomodel.CallbackOutput:
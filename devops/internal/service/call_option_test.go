@@ -40,7 +40,7 @@ import (
 )
 
 func Test_NewCallbackOption(t *testing.T) {
-	op := newCallbackOption("mock_node", "thread_1", compose.GraphNodeInfo{}, nil)
+	op := newCallbackOption("mock_node", "thread_1", compose.GraphNodeInfo{}, nil, nil)
 	assert.NotNil(t, op)
 }
 
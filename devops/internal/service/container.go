@@ -35,6 +35,7 @@ type ContainerService interface {
 	ListGraphs() (graphNameToID map[string]string)
 	CreateDevGraph(graphID, fromNode string) (devGraph *model.Graph, err error)
 	GetDevGraph(graphID, fromNode string) (devGraph *model.Graph, exist bool)
+	BuildDevGraph(graphID, fromNode string, opts ...model.DevGraphOption) (devGraph *model.Graph, err error)
 	CreateCanvas(graphID string) (canvas devmodel.CanvasInfo, err error)
 	GetCanvas(graphID string) (canvas devmodel.CanvasInfo, exist bool)
 }
@@ -186,6 +187,23 @@ func (s *containerServiceImpl) CreateDevGraph(graphID, fromNode string) (devGrap
 	return graph, nil
 }
 
+// BuildDevGraph builds a dev graph for a single run, which is not cached unlike CreateDevGraph.
+func (s *containerServiceImpl) BuildDevGraph(graphID, fromNode string, opts ...model.DevGraphOption) (devGraph *model.Graph, err error) {
+	s.mu.RLock()
+	c := s.container[graphID]
+	s.mu.RUnlock()
+	if c == nil {
+		return devGraph, fmt.Errorf("must add graph info first")
+	}
+
+	graph, err := model.BuildDevGraph(c.GraphInfo, fromNode, opts...)
+	if err != nil {
+		return devGraph, fmt.Errorf("build dev graph failed, err=%w", err)
+	}
+
+	return graph, nil
+}
+
 func (s *containerServiceImpl) GetDevGraph(graphID, fromNode string) (devGraph *model.Graph, exist bool) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -18,15 +18,23 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"reflect"
 	"sync"
+	"time"
 
 	"github.com/matoous/go-nanoid"
 
 	"github.com/cloudwego/eino-ext/devops/internal/model"
 	"github.com/cloudwego/eino-ext/devops/internal/utils/log"
 	"github.com/cloudwego/eino-ext/devops/internal/utils/safego"
+	devmodel "github.com/cloudwego/eino-ext/devops/model"
+	"github.com/cloudwego/eino-ext/devops/store"
 	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
 )
 
 // TODO@liujian: implement debug run service
@@ -37,18 +45,33 @@ var _ DebugService = &debugServiceImpl{}
 type DebugService interface {
 	CreateDebugThread(ctx context.Context, graphID string) (threadID string, err error)
 	DebugRun(ctx context.Context, m *model.DebugRunMeta, userInput string) (debugID string, stateCh chan *model.NodeDebugState, errCh chan error, err error)
+	ListDebugRuns(ctx context.Context, graphID, threadID string) (runs []*devmodel.DebugRun, err error)
+	GetDebugRun(ctx context.Context, graphID, threadID, runID string) (run *devmodel.DebugRun, exist bool, err error)
+	GetStubbedNodes(ctx context.Context, graphID, threadID string) (stubs map[string]devmodel.StubKind, err error)
+	ResumeDebugRun(ctx context.Context, graphID, threadID, debugID string, cmd *model.ResumeCommand) error
 }
 
 type debugServiceImpl struct {
 	mu sync.RWMutex
 	// debugGraphs: graphID vs DebugGraph
 	debugGraphs map[string]*model.DebugGraph
+	// store: the history of debug runs
+	store devmodel.DebugRunStore
+	// sessions: debugID vs the session of a running debug run with breakpoints
+	sessions map[string]*debugSession
 }
 
 func newDebugService() DebugService {
+	return NewDebugService(store.NewMemoryStore())
+}
+
+// NewDebugService returns a DebugService recording the debug runs to runStore.
+func NewDebugService(runStore devmodel.DebugRunStore) DebugService {
 	return &debugServiceImpl{
 		mu:          sync.RWMutex{},
 		debugGraphs: make(map[string]*model.DebugGraph, 10),
+		store:       runStore,
+		sessions:    make(map[string]*debugSession, 10),
 	}
 }
 
@@ -73,6 +96,10 @@ func (d *debugServiceImpl) CreateDebugThread(ctx context.Context, graphID string
 
 func (d *debugServiceImpl) DebugRun(ctx context.Context, rm *model.DebugRunMeta, userInput string) (debugID string,
 	stateCh chan *model.NodeDebugState, errCh chan error, err error) {
+	if !rm.Mode.IsValid() {
+		return "", nil, nil, fmt.Errorf("run mode=%s not supported", rm.Mode)
+	}
+
 	d.mu.RLock()
 	dg := d.debugGraphs[rm.GraphID]
 	if dg == nil {
@@ -88,6 +115,9 @@ func (d *debugServiceImpl) DebugRun(ctx context.Context, rm *model.DebugRunMeta,
 
 	debugID = gonanoid.MustID(6)
 
+	// nodeStateCh receives the states from the node callbacks, they are recorded before being passed to stateCh.
+	nodeStateCh := make(chan *model.NodeDebugState, 100)
+
 	devGraph, ok := ContainerSVC.GetDevGraph(rm.GraphID, rm.FromNode)
 	if !ok {
 		devGraph, err = ContainerSVC.CreateDevGraph(rm.GraphID, rm.FromNode)
@@ -96,6 +126,30 @@ func (d *debugServiceImpl) DebugRun(ctx context.Context, rm *model.DebugRunMeta,
 		}
 	}
 
+	var session *debugSession
+	if len(rm.Breakpoints) > 0 || len(rm.Stubs) > 0 {
+		// the nodes are intercepted or stubbed, so the dev graph is built for this run only
+		devOpts, err := d.stubOptions(ctx, rm, devGraph.GraphInfo)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		if len(rm.Breakpoints) > 0 {
+			session = newDebugSession(rm, nodeStateCh)
+			devOpts = append(devOpts, model.WithNodeInterceptor(session))
+		}
+
+		devGraph, err = ContainerSVC.BuildDevGraph(rm.GraphID, rm.FromNode, devOpts...)
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("create runnable failed, err=%w", err)
+		}
+		if session != nil {
+			session.graph = devGraph
+			if err = session.checkBreakpoints(); err != nil {
+				return "", nil, nil, err
+			}
+		}
+	}
+
 	inputType := devGraph.GraphInfo.InputType
 	if rm.FromNode != compose.START {
 		fromNode, ok := devGraph.GraphInfo.Nodes[rm.FromNode]
@@ -105,35 +159,79 @@ func (d *debugServiceImpl) DebugRun(ctx context.Context, rm *model.DebugRunMeta,
 		inputType = fromNode.InputType
 	}
 
-	input, err := model.UnmarshalJson([]byte(userInput), inputType)
+	inputs, err := unmarshalInputs(userInput, inputType, rm.Mode)
 	if err != nil {
 		return "", nil, nil, err
 	}
 
-	stateCh = make(chan *model.NodeDebugState, 100)
+	// streamWG tracks the callbacks still forwarding the chunks of node output streams.
+	streamWG := &sync.WaitGroup{}
 
-	opts, err := d.getInvokeOptions(devGraph.GraphInfo, rm.ThreadID, stateCh)
+	opts, err := d.getInvokeOptions(devGraph.GraphInfo, rm.ThreadID, nodeStateCh, streamWG)
 	if err != nil {
-		close(stateCh)
+		close(nodeStateCh)
 		return "", nil, nil, fmt.Errorf("get invoke option failed, err=%w", err)
 	}
 
-	errCh = make(chan error, 1)
+	d.setStubbedNodes(rm.GraphID, rm.ThreadID, devGraph.StubbedNodes)
+
+	record := newDebugRunRecord(debugID, rm, userInput)
+	stateCh = make(chan *model.NodeDebugState, 100)
+	relayDone := make(chan struct{})
 	safego.Go(ctx, func() {
+		defer close(relayDone)
 		defer close(stateCh)
-		defer close(errCh)
+		stubKinds := make(map[string]devmodel.StubKind, len(rm.Stubs))
+		for _, stub := range rm.Stubs {
+			stubKinds[stub.NodeKey] = stub.Kind
+		}
+		for state := range nodeStateCh {
+			if state.Paused == "" {
+				state.Stub = stubKinds[state.NodeKey]
+			}
+			if !state.Chunk && state.Paused == "" {
+				record.States = append(record.States, toDebugRunState(state))
+			}
+			stateCh <- state
+		}
+	})
+
+	if session != nil {
+		d.mu.Lock()
+		d.sessions[debugID] = session
+		d.mu.Unlock()
+	}
+
+	errCh = make(chan error, 1)
+	safego.Go(ctx, func() {
+		var e error
+		defer func() {
+			if session != nil {
+				d.mu.Lock()
+				delete(d.sessions, debugID)
+				d.mu.Unlock()
+			}
+
+			streamWG.Wait()
+			close(nodeStateCh)
+			<-relayDone
+
+			d.saveDebugRun(ctx, record, e)
+			if e != nil {
+				errCh <- e
+			}
+			close(errCh)
+		}()
 
 		r, e := devGraph.Compile()
 		if e != nil {
-			errCh <- e
 			log.Errorf("Compile failed, fromNode=%s\nerr=%s", rm.FromNode, e)
 			return
 		}
 
-		_, e = r.Invoke(ctx, input, opts...)
+		e = run(ctx, r, rm.Mode, inputs, opts...)
 		if e != nil {
-			errCh <- e
-			log.Errorf("invoke failed, userInput=%s\nerr=%s", userInput, e)
+			log.Errorf("%s failed, userInput=%s\nerr=%s", runModeOrDefault(rm.Mode), userInput, e)
 			return
 		}
 	})
@@ -141,11 +239,207 @@ func (d *debugServiceImpl) DebugRun(ctx context.Context, rm *model.DebugRunMeta,
 	return debugID, stateCh, errCh, nil
 }
 
-func (d *debugServiceImpl) getInvokeOptions(gi *model.GraphInfo, threadID string, stateCh chan *model.NodeDebugState) (opts []compose.Option, err error) {
+// GetStubbedNodes returns the nodes stubbed in the latest run of the thread, and the kind of their stub.
+func (d *debugServiceImpl) GetStubbedNodes(ctx context.Context, graphID, threadID string) (
+	stubs map[string]devmodel.StubKind, err error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	dg := d.debugGraphs[graphID]
+	if dg == nil {
+		return nil, fmt.Errorf("graph=%s not exist", graphID)
+	}
+	dt, ok := dg.GetDebugThread(threadID)
+	if !ok {
+		return nil, fmt.Errorf("thread=%s not exist", threadID)
+	}
+
+	return dt.StubbedNodes, nil
+}
+
+func (d *debugServiceImpl) setStubbedNodes(graphID, threadID string, stubs map[string]devmodel.StubKind) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	dg := d.debugGraphs[graphID]
+	if dg == nil {
+		return
+	}
+	for _, dt := range dg.DT {
+		if dt.ID == threadID {
+			dt.StubbedNodes = stubs
+			return
+		}
+	}
+}
+
+func (d *debugServiceImpl) ListDebugRuns(ctx context.Context, graphID, threadID string) (runs []*devmodel.DebugRun, err error) {
+	return d.store.ListDebugRuns(ctx, graphID, threadID)
+}
+
+func (d *debugServiceImpl) GetDebugRun(ctx context.Context, graphID, threadID, runID string) (run *devmodel.DebugRun, exist bool, err error) {
+	run, exist, err = d.store.GetDebugRun(ctx, runID)
+	if err != nil || !exist {
+		return nil, false, err
+	}
+	if run.GraphID != graphID || run.ThreadID != threadID {
+		return nil, false, nil
+	}
+
+	return run, true, nil
+}
+
+func (d *debugServiceImpl) ResumeDebugRun(ctx context.Context, graphID, threadID, debugID string, cmd *model.ResumeCommand) error {
+	d.mu.RLock()
+	session := d.sessions[debugID]
+	d.mu.RUnlock()
+	if session == nil || session.graphID != graphID || session.threadID != threadID {
+		return fmt.Errorf("run=%s is not running with breakpoints", debugID)
+	}
+
+	return session.resume(cmd)
+}
+
+func newDebugRunRecord(debugID string, rm *model.DebugRunMeta, userInput string) *devmodel.DebugRun {
+	return &devmodel.DebugRun{
+		ID:          debugID,
+		GraphID:     rm.GraphID,
+		ThreadID:    rm.ThreadID,
+		FromNode:    rm.FromNode,
+		Mode:        string(runModeOrDefault(rm.Mode)),
+		Input:       userInput,
+		ReplayOf:    rm.ReplayOf,
+		Stubs:       rm.Stubs,
+		States:      make([]*devmodel.NodeDebugState, 0),
+		StartTimeMS: time.Now().UnixMilli(),
+	}
+}
+
+// saveDebugRun records the run once it is over, the failure of which does not fail the run.
+func (d *debugServiceImpl) saveDebugRun(ctx context.Context, record *devmodel.DebugRun, runErr error) {
+	record.EndTimeMS = time.Now().UnixMilli()
+	if runErr != nil {
+		record.Error = runErr.Error()
+	}
+
+	if err := d.store.SaveDebugRun(context.WithoutCancel(ctx), record); err != nil {
+		log.Errorf("save debug run failed, debugID=%s\nerr=%s", record.ID, err)
+	}
+}
+
+func toDebugRunState(state *model.NodeDebugState) *devmodel.NodeDebugState {
+	return &devmodel.NodeDebugState{
+		NodeKey:   state.NodeKey,
+		Input:     state.Input,
+		Output:    state.Output,
+		Error:     state.Error,
+		ErrorType: string(state.ErrorType),
+		Stub:      state.Stub,
+		Metrics: devmodel.NodeDebugMetrics{
+			PromptTokens:     state.Metrics.PromptTokens,
+			CompletionTokens: state.Metrics.CompletionTokens,
+			InvokeTimeMS:     state.Metrics.InvokeTimeMS,
+			CompletionTimeMS: state.Metrics.CompletionTimeMS,
+		},
+	}
+}
+
+func (d *debugServiceImpl) getInvokeOptions(gi *model.GraphInfo, threadID string, stateCh chan *model.NodeDebugState,
+	streamWG *sync.WaitGroup) (opts []compose.Option, err error) {
 	opts = make([]compose.Option, 0, len(gi.Nodes))
 	for key, node := range gi.Nodes {
-		opts = append(opts, newCallbackOption(key, threadID, node, stateCh))
+		opts = append(opts, newCallbackOption(key, threadID, node, stateCh, streamWG))
 	}
 
 	return opts, nil
 }
+
+// unmarshalNodeValue parses a json value edited or given by the client for a node, of type rt if known.
+func unmarshalNodeValue(nodeKey, value string, rt reflect.Type) (any, error) {
+	if rt == nil {
+		var v any
+		if err := json.Unmarshal([]byte(value), &v); err != nil {
+			return nil, fmt.Errorf("unmarshal value of node=%s failed, err=%w", nodeKey, err)
+		}
+		return v, nil
+	}
+
+	v, err := model.UnmarshalJson([]byte(value), rt)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal value of node=%s failed, err=%w", nodeKey, err)
+	}
+
+	return v.Interface(), nil
+}
+
+// unmarshalInputs parses the user input, a single value of the input type, or a json array of chunks
+// when the graph input is a stream.
+func unmarshalInputs(userInput string, inputType reflect.Type, mode model.RunMode) ([]reflect.Value, error) {
+	if !mode.StreamInput() {
+		input, err := model.UnmarshalJson([]byte(userInput), inputType)
+		if err != nil {
+			return nil, err
+		}
+		return []reflect.Value{input}, nil
+	}
+
+	var chunks []json.RawMessage
+	if err := json.Unmarshal([]byte(userInput), &chunks); err != nil {
+		return nil, fmt.Errorf("input of %s mode must be a json array of chunks, err=%w", mode, err)
+	}
+
+	inputs := make([]reflect.Value, 0, len(chunks))
+	for i, chunk := range chunks {
+		input, err := model.UnmarshalJson(chunk, inputType)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal input chunk %d failed, err=%w", i, err)
+		}
+		inputs = append(inputs, input)
+	}
+
+	return inputs, nil
+}
+
+func run(ctx context.Context, r model.Runnable, mode model.RunMode, inputs []reflect.Value, opts ...compose.Option) error {
+	switch mode {
+	case model.RunModeStream:
+		sr, err := r.Stream(ctx, inputs[0], opts...)
+		if err != nil {
+			return err
+		}
+		return drain(sr)
+	case model.RunModeCollect:
+		_, err := r.Collect(ctx, inputs, opts...)
+		return err
+	case model.RunModeTransform:
+		sr, err := r.Transform(ctx, inputs, opts...)
+		if err != nil {
+			return err
+		}
+		return drain(sr)
+	default:
+		_, err := r.Invoke(ctx, inputs[0], opts...)
+		return err
+	}
+}
+
+// drain consumes the graph output stream, the chunks of which are already reported by the node callbacks.
+func drain(sr *schema.StreamReader[any]) error {
+	defer sr.Close()
+	for {
+		_, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func runModeOrDefault(mode model.RunMode) model.RunMode {
+	if mode == "" {
+		return model.RunModeInvoke
+	}
+	return mode
+}
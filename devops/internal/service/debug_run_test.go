@@ -17,10 +17,14 @@
 package service
 
 import (
+	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/cloudwego/eino-ext/devops/internal/model"
 	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -45,7 +49,185 @@ func Test_debugServiceImpl_getInvokeOptions(t *testing.T) {
 	svc := newDebugService()
 	impl, ok := svc.(*debugServiceImpl)
 	assert.True(t, ok)
-	opts, err := impl.getInvokeOptions(gi, "t1", nil)
+	opts, err := impl.getInvokeOptions(gi, "t1", nil, nil)
 	assert.Nil(t, err)
 	assert.NotNil(t, opts)
 }
+
+type debugRunTestCallback struct {
+	gi *compose.GraphInfo
+}
+
+func (tc *debugRunTestCallback) OnFinish(ctx context.Context, graphInfo *compose.GraphInfo) {
+	tc.gi = graphInfo
+}
+
+// addDebugRunTestGraph adds a graph splitting its input into words to ContainerSVC, until the test ends.
+func addDebugRunTestGraph(t *testing.T) (graphID string) {
+	g := compose.NewGraph[string, string]()
+	err := g.AddLambdaNode("split", compose.StreamableLambda(func(ctx context.Context, input string) (*schema.StreamReader[string], error) {
+		words := strings.SplitAfter(input, " ")
+		return schema.StreamReaderFromArray(words), nil
+	}))
+	assert.Nil(t, err)
+	err = g.AddEdge(compose.START, "split")
+	assert.Nil(t, err)
+	err = g.AddEdge("split", compose.END)
+	assert.Nil(t, err)
+
+	tc := &debugRunTestCallback{}
+	_, err = g.Compile(context.Background(), compose.WithGraphCompileCallbacks(tc))
+	assert.Nil(t, err)
+
+	containerSVC := ContainerSVC
+	t.Cleanup(func() { ContainerSVC = containerSVC })
+	ContainerSVC = newContainerService()
+	graphID, err = ContainerSVC.AddGraphInfo("debug_run_graph", tc.gi)
+	assert.Nil(t, err)
+
+	return graphID
+}
+
+func Test_debugServiceImpl_DebugRun_Mode(t *testing.T) {
+	graphID := addDebugRunTestGraph(t)
+
+	run := func(mode model.RunMode, input string) (states []*model.NodeDebugState, errs []error) {
+		svc := newDebugService()
+		threadID, err := svc.CreateDebugThread(context.Background(), graphID)
+		assert.Nil(t, err)
+
+		_, stateCh, errCh, err := svc.DebugRun(context.Background(), &model.DebugRunMeta{
+			GraphID:  graphID,
+			ThreadID: threadID,
+			FromNode: compose.START,
+			Mode:     mode,
+		}, input)
+		assert.Nil(t, err)
+
+		for state := range stateCh {
+			states = append(states, state)
+		}
+		for e := range errCh {
+			errs = append(errs, e)
+		}
+		return states, errs
+	}
+
+	t.Run("invoke", func(t *testing.T) {
+		states, errs := run(model.RunModeInvoke, `"a b c"`)
+		assert.Empty(t, errs)
+		assert.NotEmpty(t, states)
+		last := states[len(states)-1]
+		assert.False(t, last.Chunk)
+		assert.Equal(t, `"a b c"`, last.Output)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		states, errs := run(model.RunModeStream, `"a b c"`)
+		assert.Empty(t, errs)
+		assert.Len(t, states, 4)
+		for i, chunk := range []string{`"a "`, `"b "`, `"c"`} {
+			assert.True(t, states[i].Chunk)
+			assert.Equal(t, "split", states[i].NodeKey)
+			assert.Equal(t, chunk, states[i].Output)
+		}
+		assert.False(t, states[3].Chunk)
+		assert.Equal(t, "split", states[3].NodeKey)
+		assert.Equal(t, `"a b c"`, states[3].Output)
+		assert.Equal(t, `"a b c"`, states[3].Input)
+	})
+
+	t.Run("transform", func(t *testing.T) {
+		states, errs := run(model.RunModeTransform, `["a b", " c"]`)
+		assert.Empty(t, errs)
+		assert.NotEmpty(t, states)
+		last := states[len(states)-1]
+		assert.False(t, last.Chunk)
+		assert.Equal(t, `"a b c"`, last.Output)
+		for _, state := range states[:len(states)-1] {
+			assert.True(t, state.Chunk)
+		}
+	})
+
+	t.Run("collect", func(t *testing.T) {
+		states, errs := run(model.RunModeCollect, `["a b", " c"]`)
+		assert.Empty(t, errs)
+		assert.NotEmpty(t, states)
+		assert.Equal(t, `"a b c"`, states[len(states)-1].Output)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		svc := newDebugService()
+		threadID, err := svc.CreateDebugThread(context.Background(), graphID)
+		assert.Nil(t, err)
+
+		m := &model.DebugRunMeta{GraphID: graphID, ThreadID: threadID, FromNode: compose.START, Mode: model.RunModeCollect}
+		_, _, _, err = svc.DebugRun(context.Background(), m, `"a b c"`)
+		assert.NotNil(t, err)
+
+		m.Mode = "unknown"
+		_, _, _, err = svc.DebugRun(context.Background(), m, `"a b c"`)
+		assert.NotNil(t, err)
+	})
+}
+
+func Test_concatStreamOutput(t *testing.T) {
+	assert.Equal(t, "ab", concatStreamOutput([]any{"a", "b"}))
+	assert.Equal(t, []any{1, 2}, concatStreamOutput([]any{1, 2}))
+	assert.Equal(t, []any{"a", 1}, concatStreamOutput([]any{"a", 1}))
+
+	msg := concatStreamOutput([]any{schema.AssistantMessage("hello ", nil), schema.AssistantMessage("world", nil)})
+	assert.Equal(t, schema.AssistantMessage("hello world", nil), msg)
+}
+
+func Test_debugServiceImpl_DebugRun_History(t *testing.T) {
+	ctx := context.Background()
+	graphID := addDebugRunTestGraph(t)
+
+	svc := newDebugService()
+	threadID, err := svc.CreateDebugThread(ctx, graphID)
+	assert.Nil(t, err)
+
+	run := func(m *model.DebugRunMeta, input string) string {
+		debugID, stateCh, errCh, err := svc.DebugRun(ctx, m, input)
+		assert.Nil(t, err)
+		for range stateCh {
+		}
+		for e := range errCh {
+			assert.Nil(t, e)
+		}
+		return debugID
+	}
+
+	m := &model.DebugRunMeta{GraphID: graphID, ThreadID: threadID, FromNode: compose.START, Mode: model.RunModeStream}
+	firstID := run(m, `"a b"`)
+
+	r, ok, err := svc.GetDebugRun(ctx, graphID, threadID, firstID)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, `"a b"`, r.Input)
+	assert.Equal(t, string(model.RunModeStream), r.Mode)
+	assert.Empty(t, r.Error)
+	assert.True(t, r.EndTimeMS >= r.StartTimeMS)
+	if assert.Len(t, r.States, 1) {
+		assert.Equal(t, "split", r.States[0].NodeKey)
+		assert.Equal(t, `"a b"`, r.States[0].Output)
+	}
+
+	_, ok, err = svc.GetDebugRun(ctx, graphID, "other_thread", firstID)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	time.Sleep(2 * time.Millisecond) // runs are listed by start time
+	replay := &model.DebugRunMeta{GraphID: graphID, ThreadID: threadID, FromNode: r.FromNode, Mode: model.RunMode(r.Mode), ReplayOf: r.ID}
+	secondID := run(replay, `"c d"`)
+
+	runs, err := svc.ListDebugRuns(ctx, graphID, threadID)
+	assert.Nil(t, err)
+	if assert.Len(t, runs, 2) {
+		assert.Equal(t, firstID, runs[0].ID)
+		assert.Equal(t, secondID, runs[1].ID)
+		assert.Equal(t, firstID, runs[1].ReplayOf)
+		assert.Equal(t, `"c d"`, runs[1].States[0].Output)
+	}
+}
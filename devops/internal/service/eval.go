@@ -0,0 +1,431 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/compose"
+	"github.com/matoous/go-nanoid"
+
+	"github.com/cloudwego/eino-ext/devops/eval"
+	"github.com/cloudwego/eino-ext/devops/internal/model"
+	"github.com/cloudwego/eino-ext/devops/internal/utils/log"
+	"github.com/cloudwego/eino-ext/devops/internal/utils/safego"
+)
+
+var _ EvalService = &evalServiceImpl{}
+
+//go:generate mockgen -source=eval.go -destination=../mock/eval_mock.go -package=mock
+type EvalService interface {
+	// AddDataset registers the JSONL dataset against the graph, see eval.ParseDataset.
+	AddDataset(ctx context.Context, graphID, name, content string) (dataset *model.Dataset, err error)
+	ListDatasets(ctx context.Context, graphID string) (datasets []*model.Dataset)
+	// RunEvaluation runs the graph on the cases of the dataset in the background, the report being updated
+	// as the cases finish.
+	RunEvaluation(ctx context.Context, graphID, datasetID string, config *model.EvalConfig) (reportID string, err error)
+	GetReport(ctx context.Context, graphID, reportID string) (report *eval.Report, exist bool)
+	// ListReports returns the reports of the graph without their cases.
+	ListReports(ctx context.Context, graphID string) (reports []*eval.Report)
+	// ListEvaluators returns the names of the built-in and registered evaluators.
+	ListEvaluators() (names []string)
+}
+
+const (
+	defaultEvalConcurrency = 4
+	maxEvalConcurrency     = 32
+)
+
+type evalServiceImpl struct {
+	mu sync.RWMutex
+	// evaluators: name vs the evaluators registered with the dev server
+	evaluators map[string]eval.Evaluator
+	reportDir  string
+	// datasets: datasetID vs dataset
+	datasets map[string]*model.Dataset
+	// evaluations: reportID vs evaluation
+	evaluations map[string]*evaluation
+}
+
+func newEvalService() EvalService {
+	return NewEvalService(nil, "")
+}
+
+// NewEvalService returns an EvalService with the evaluators registered besides the built-in ones,
+// the reports being written to reportDir, or to a directory in os.TempDir() if empty.
+func NewEvalService(evaluators []eval.Evaluator, reportDir string) EvalService {
+	if reportDir == "" {
+		reportDir = filepath.Join(os.TempDir(), "eino-devops", "eval_reports")
+	}
+
+	e := &evalServiceImpl{
+		evaluators:  make(map[string]eval.Evaluator, len(evaluators)),
+		reportDir:   reportDir,
+		datasets:    make(map[string]*model.Dataset, 10),
+		evaluations: make(map[string]*evaluation, 10),
+	}
+	for _, ev := range evaluators {
+		if _, ok := e.evaluators[ev.Name()]; ok {
+			log.Warnf("evaluator=%s registered more than once, the last one is used", ev.Name())
+		}
+		e.evaluators[ev.Name()] = ev
+	}
+
+	return e
+}
+
+func (e *evalServiceImpl) AddDataset(ctx context.Context, graphID, name, content string) (dataset *model.Dataset, err error) {
+	if _, ok := graphName(graphID); !ok {
+		return nil, fmt.Errorf("graph=%s not exist", graphID)
+	}
+
+	cases, err := eval.ParseDataset(strings.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	dataset = &model.Dataset{
+		ID:           gonanoid.MustID(6),
+		GraphID:      graphID,
+		Name:         name,
+		Cases:        cases,
+		CreateTimeMS: time.Now().UnixMilli(),
+	}
+
+	e.mu.Lock()
+	e.datasets[dataset.ID] = dataset
+	e.mu.Unlock()
+
+	return dataset, nil
+}
+
+func (e *evalServiceImpl) ListDatasets(ctx context.Context, graphID string) (datasets []*model.Dataset) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	datasets = make([]*model.Dataset, 0, len(e.datasets))
+	for _, ds := range e.datasets {
+		if ds.GraphID == graphID {
+			datasets = append(datasets, ds)
+		}
+	}
+	sort.Slice(datasets, func(i, j int) bool {
+		if datasets[i].CreateTimeMS != datasets[j].CreateTimeMS {
+			return datasets[i].CreateTimeMS < datasets[j].CreateTimeMS
+		}
+		return datasets[i].ID < datasets[j].ID
+	})
+
+	return datasets
+}
+
+func (e *evalServiceImpl) RunEvaluation(ctx context.Context, graphID, datasetID string, config *model.EvalConfig) (reportID string, err error) {
+	e.mu.RLock()
+	dataset := e.datasets[datasetID]
+	e.mu.RUnlock()
+	if dataset == nil || dataset.GraphID != graphID {
+		return "", fmt.Errorf("dataset=%s not exist", datasetID)
+	}
+
+	evaluators, err := e.newEvaluators(config.Evaluators)
+	if err != nil {
+		return "", err
+	}
+
+	devGraph, ok := ContainerSVC.GetDevGraph(graphID, compose.START)
+	if !ok {
+		devGraph, err = ContainerSVC.CreateDevGraph(graphID, compose.START)
+		if err != nil {
+			return "", fmt.Errorf("create runnable failed, err=%w", err)
+		}
+	}
+	r, err := devGraph.Compile()
+	if err != nil {
+		return "", fmt.Errorf("compile graph failed, err=%w", err)
+	}
+
+	concurrency := config.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultEvalConcurrency
+	}
+	if concurrency > maxEvalConcurrency {
+		concurrency = maxEvalConcurrency
+	}
+
+	name, _ := graphName(graphID)
+	ev := &evaluation{
+		report: &eval.Report{
+			ID:          gonanoid.MustID(6),
+			GraphID:     graphID,
+			GraphName:   name,
+			DatasetID:   dataset.ID,
+			DatasetName: dataset.Name,
+			Status:      eval.ReportStatusRunning,
+			Total:       len(dataset.Cases),
+			StartTimeMS: time.Now().UnixMilli(),
+		},
+		results: make([]*eval.CaseResult, len(dataset.Cases)),
+	}
+
+	e.mu.Lock()
+	e.evaluations[ev.report.ID] = ev
+	e.mu.Unlock()
+
+	// the evaluation outlives the request starting it
+	ctx = context.WithoutCancel(ctx)
+	safego.Go(ctx, func() {
+		sem := make(chan struct{}, concurrency)
+		wg := &sync.WaitGroup{}
+		for i, c := range dataset.Cases {
+			sem <- struct{}{}
+			wg.Add(1)
+			safego.Go(ctx, func() {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				ev.finishCase(i, runCase(ctx, r, devGraph.GraphInfo.InputType, c, evaluators))
+			})
+		}
+		wg.Wait()
+
+		e.finish(ev)
+	})
+
+	return ev.report.ID, nil
+}
+
+func (e *evalServiceImpl) GetReport(ctx context.Context, graphID, reportID string) (report *eval.Report, exist bool) {
+	e.mu.RLock()
+	ev := e.evaluations[reportID]
+	e.mu.RUnlock()
+	if ev == nil {
+		return nil, false
+	}
+
+	report = ev.snapshot(true)
+	if report.GraphID != graphID {
+		return nil, false
+	}
+
+	return report, true
+}
+
+func (e *evalServiceImpl) ListReports(ctx context.Context, graphID string) (reports []*eval.Report) {
+	e.mu.RLock()
+	evaluations := make([]*evaluation, 0, len(e.evaluations))
+	for _, ev := range e.evaluations {
+		evaluations = append(evaluations, ev)
+	}
+	e.mu.RUnlock()
+
+	reports = make([]*eval.Report, 0, len(evaluations))
+	for _, ev := range evaluations {
+		if report := ev.snapshot(false); report.GraphID == graphID {
+			reports = append(reports, report)
+		}
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].StartTimeMS != reports[j].StartTimeMS {
+			return reports[i].StartTimeMS < reports[j].StartTimeMS
+		}
+		return reports[i].ID < reports[j].ID
+	})
+
+	return reports
+}
+
+func (e *evalServiceImpl) ListEvaluators() (names []string) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	names = make([]string, 0, len(e.evaluators))
+	for name := range e.evaluators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return append([]string{eval.ExactMatchName, eval.JSONPathEqualName, eval.RegexMatchName}, names...)
+}
+
+// newEvaluators returns the evaluators of the specs, exact_match if there is none.
+func (e *evalServiceImpl) newEvaluators(specs []*model.EvaluatorSpec) ([]eval.Evaluator, error) {
+	if len(specs) == 0 {
+		return []eval.Evaluator{eval.ExactMatch()}, nil
+	}
+
+	evaluators := make([]eval.Evaluator, 0, len(specs))
+	for _, spec := range specs {
+		var (
+			ev  eval.Evaluator
+			err error
+		)
+		switch spec.Name {
+		case eval.ExactMatchName:
+			ev = eval.ExactMatch()
+		case eval.JSONPathEqualName:
+			ev, err = eval.JSONPathEqual(spec.Path)
+		case eval.RegexMatchName:
+			ev, err = eval.RegexMatch(spec.Pattern)
+		default:
+			e.mu.RLock()
+			registered, ok := e.evaluators[spec.Name]
+			e.mu.RUnlock()
+			if !ok {
+				return nil, fmt.Errorf("evaluator=%s not found", spec.Name)
+			}
+			ev = registered
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid evaluator=%s, err=%w", spec.Name, err)
+		}
+		evaluators = append(evaluators, ev)
+	}
+
+	return evaluators, nil
+}
+
+// finish marks the evaluation done, and writes its report.
+func (e *evalServiceImpl) finish(ev *evaluation) {
+	file := filepath.Join(e.reportDir, ev.report.ID+".json")
+
+	ev.mu.Lock()
+	ev.report.Status = eval.ReportStatusDone
+	ev.report.EndTimeMS = time.Now().UnixMilli()
+	ev.report.File = file
+	ev.mu.Unlock()
+
+	if err := writeReport(file, ev.snapshot(true)); err != nil {
+		log.Errorf("write evaluation report failed, err=%v", err)
+		ev.mu.Lock()
+		ev.report.File = ""
+		ev.mu.Unlock()
+	}
+}
+
+// writeReport writes the report to a temp file renamed to file, so that the readers never see a partial report.
+func writeReport(file string, report *eval.Report) error {
+	b, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+	tmp := file + ".tmp"
+	if err = os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, file)
+}
+
+// evaluation is an evaluation running or done, the results of which are summarized in its report on demand.
+type evaluation struct {
+	mu     sync.Mutex
+	report *eval.Report
+	// results: the results by case index, nil until the case finishes
+	results []*eval.CaseResult
+}
+
+func (ev *evaluation) finishCase(i int, result *eval.CaseResult) {
+	ev.mu.Lock()
+	ev.results[i] = result
+	ev.mu.Unlock()
+}
+
+func (ev *evaluation) snapshot(withCases bool) *eval.Report {
+	ev.mu.Lock()
+	defer ev.mu.Unlock()
+
+	report := *ev.report
+	report.Cases = make([]*eval.CaseResult, 0, len(ev.results))
+	for _, result := range ev.results {
+		if result != nil {
+			report.Cases = append(report.Cases, result)
+		}
+	}
+	report.Summarize()
+	if !withCases {
+		report.Cases = nil
+	}
+
+	return &report
+}
+
+// runCase runs the graph on the input of the case, and scores its output.
+func runCase(ctx context.Context, r model.Runnable, inputType reflect.Type, c *eval.Case, evaluators []eval.Evaluator) *eval.CaseResult {
+	result := &eval.CaseResult{
+		CaseID:   c.ID,
+		Input:    string(c.Input),
+		Expected: string(c.Expected),
+	}
+
+	input, err := model.UnmarshalJson(c.Input, inputType)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	start := time.Now()
+	output, err := r.Invoke(ctx, input)
+	result.LatencyMS = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	b, err := json.Marshal(output)
+	if err != nil {
+		result.Error = fmt.Sprintf("marshal output failed, err=%v", err)
+		return result
+	}
+	result.Output = string(b)
+
+	sample := &eval.Sample{
+		Input:    result.Input,
+		Expected: result.Expected,
+		Output:   result.Output,
+	}
+	result.Pass = true
+	for _, ev := range evaluators {
+		score := eval.Evaluate(ctx, ev, sample)
+		result.Scores = append(result.Scores, score)
+		result.Pass = result.Pass && score.Pass && score.Error == ""
+	}
+
+	return result
+}
+
+func graphName(graphID string) (name string, ok bool) {
+	for n, id := range ContainerSVC.ListGraphs() {
+		if id == graphID {
+			return n, true
+		}
+	}
+	return "", false
+}
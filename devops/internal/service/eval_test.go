@@ -0,0 +1,108 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/devops/eval"
+	"github.com/cloudwego/eino-ext/devops/internal/model"
+)
+
+func Test_evalServiceImpl_RunEvaluation(t *testing.T) {
+	graphID := addDebugRunTestGraph(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+	svc := NewEvalService(nil, dir)
+
+	_, err := svc.AddDataset(ctx, "unknown", "ds", `{"input": "a"}`)
+	assert.Error(t, err)
+	_, err = svc.AddDataset(ctx, graphID, "ds", `{"input": `)
+	assert.Error(t, err)
+
+	ds, err := svc.AddDataset(ctx, graphID, "ds", `{"id": "same", "input": "a b", "expected": "a b"}
+{"id": "diff", "input": "x", "expected": "y"}
+{"id": "bad", "input": 1, "expected": "1"}`)
+	assert.NoError(t, err)
+	assert.Len(t, ds.Cases, 3)
+	assert.Len(t, svc.ListDatasets(ctx, graphID), 1)
+
+	_, err = svc.RunEvaluation(ctx, graphID, ds.ID, &model.EvalConfig{
+		Evaluators: []*model.EvaluatorSpec{{Name: "unknown"}},
+	})
+	assert.Error(t, err)
+	_, err = svc.RunEvaluation(ctx, "other", ds.ID, &model.EvalConfig{})
+	assert.Error(t, err)
+
+	reportID, err := svc.RunEvaluation(ctx, graphID, ds.ID, &model.EvalConfig{
+		Evaluators: []*model.EvaluatorSpec{
+			{Name: eval.ExactMatchName},
+			{Name: eval.RegexMatchName, Pattern: "^[a-z]"},
+		},
+		Concurrency: 2,
+	})
+	assert.NoError(t, err)
+
+	var report *eval.Report
+	assert.Eventually(t, func() bool {
+		var ok bool
+		report, ok = svc.GetReport(ctx, graphID, reportID)
+		return ok && report.Status == eval.ReportStatusDone
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, 3, report.Total)
+	assert.Equal(t, 3, report.Finished)
+	assert.Equal(t, 1, report.Passed)
+	assert.Equal(t, 1, report.Errors)
+	if assert.Len(t, report.Cases, 3) {
+		assert.Equal(t, "same", report.Cases[0].CaseID)
+		assert.True(t, report.Cases[0].Pass)
+		assert.Equal(t, `"a b"`, report.Cases[0].Output)
+		assert.False(t, report.Cases[1].Pass)
+		assert.Len(t, report.Cases[1].Scores, 2)
+		assert.NotEmpty(t, report.Cases[2].Error)
+	}
+	if assert.Len(t, report.Evaluators, 2) {
+		assert.Equal(t, eval.ExactMatchName, report.Evaluators[0].Evaluator)
+		assert.Equal(t, 1, report.Evaluators[0].Passed)
+		assert.Equal(t, 1, report.Evaluators[0].Failed)
+	}
+
+	b, err := os.ReadFile(report.File)
+	assert.NoError(t, err)
+	written := &eval.Report{}
+	assert.NoError(t, json.Unmarshal(b, written))
+	assert.Equal(t, reportID, written.ID)
+	assert.Equal(t, eval.ReportStatusDone, written.Status)
+	assert.Len(t, written.Cases, 3)
+
+	reports := svc.ListReports(ctx, graphID)
+	if assert.Len(t, reports, 1) {
+		assert.Empty(t, reports[0].Cases)
+		assert.Equal(t, 1, reports[0].Passed)
+	}
+
+	_, ok := svc.GetReport(ctx, "other", reportID)
+	assert.False(t, ok)
+	assert.Equal(t, []string{eval.ExactMatchName, eval.JSONPathEqualName, eval.RegexMatchName}, svc.ListEvaluators())
+}
@@ -19,4 +19,5 @@ package service
 var (
 	ContainerSVC = newContainerService()
 	DebugSVC     = newDebugService()
+	EvalSVC      = newEvalService()
 )
@@ -0,0 +1,170 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/cloudwego/eino/compose"
+
+	"github.com/cloudwego/eino-ext/devops/internal/model"
+	devmodel "github.com/cloudwego/eino-ext/devops/model"
+)
+
+// stubOptions replaces the nodes stubbed by the run, gi giving the types of the nodes.
+func (d *debugServiceImpl) stubOptions(ctx context.Context, rm *model.DebugRunMeta, gi *model.GraphInfo) (
+	opts []model.DevGraphOption, err error) {
+	opts = make([]model.DevGraphOption, 0, len(rm.Stubs))
+	for _, stub := range rm.Stubs {
+		node, ok := gi.Nodes[stub.NodeKey]
+		if !ok {
+			return nil, fmt.Errorf("stubbed node=%s not found", stub.NodeKey)
+		}
+
+		fn, err := d.newNodeStub(ctx, rm, stub, node)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, model.WithNodeStub(stub.NodeKey, stub.Kind, fn))
+	}
+
+	return opts, nil
+}
+
+func (d *debugServiceImpl) newNodeStub(ctx context.Context, rm *model.DebugRunMeta, stub *devmodel.NodeStub,
+	node compose.GraphNodeInfo) (model.NodeStubFunc, error) {
+	switch stub.Kind {
+	case devmodel.StubKindFixed:
+		return fixedStub(stub.NodeKey, stub.Output, node.OutputType)
+	case devmodel.StubKindRecorded:
+		output, err := d.recordedOutput(ctx, rm, stub)
+		if err != nil {
+			return nil, err
+		}
+		if node.OutputKey != "" {
+			if output, err = unwrapOutputKey(stub.NodeKey, output, node.OutputKey); err != nil {
+				return nil, err
+			}
+		}
+		return fixedStub(stub.NodeKey, output, node.OutputType)
+	case devmodel.StubKindTemplate:
+		return templateStub(stub.NodeKey, stub.Template, node.OutputType)
+	default:
+		return nil, fmt.Errorf("stub kind=%s of node=%s not supported", stub.Kind, stub.NodeKey)
+	}
+}
+
+// fixedStub outputs the json value, unmarshalled on each call so that the runs do not share it.
+func fixedStub(nodeKey, output string, outputType reflect.Type) (model.NodeStubFunc, error) {
+	if _, err := unmarshalNodeValue(nodeKey, output, outputType); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, input any) (any, error) {
+		return unmarshalNodeValue(nodeKey, output, outputType)
+	}, nil
+}
+
+// recordedOutput returns the output of the node in the run of the stub, or in the latest run of the thread
+// where the node succeeded.
+func (d *debugServiceImpl) recordedOutput(ctx context.Context, rm *model.DebugRunMeta, stub *devmodel.NodeStub) (string, error) {
+	var runs []*devmodel.DebugRun
+	if stub.RunID != "" {
+		run, exist, err := d.store.GetDebugRun(ctx, stub.RunID)
+		if err != nil {
+			return "", fmt.Errorf("get debug run=%s failed, err=%w", stub.RunID, err)
+		}
+		if !exist || run.GraphID != rm.GraphID {
+			return "", fmt.Errorf("run=%s not exist", stub.RunID)
+		}
+		runs = append(runs, run)
+	} else {
+		var err error
+		runs, err = d.store.ListDebugRuns(ctx, rm.GraphID, rm.ThreadID)
+		if err != nil {
+			return "", fmt.Errorf("list debug runs failed, err=%w", err)
+		}
+	}
+
+	for i := len(runs) - 1; i >= 0; i-- {
+		states := runs[i].States
+		for j := len(states) - 1; j >= 0; j-- {
+			if states[j].NodeKey == stub.NodeKey && states[j].Error == "" && states[j].Output != "" {
+				return states[j].Output, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("no recorded output of node=%s", stub.NodeKey)
+}
+
+// unwrapOutputKey returns the output of the node itself from a recorded one, which holds it under the output key
+// of the node, see callbackHandler.OnEnd.
+func unwrapOutputKey(nodeKey, output, outputKey string) (string, error) {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(output), &m); err != nil {
+		return "", fmt.Errorf("unmarshal recorded output of node=%s failed, err=%w", nodeKey, err)
+	}
+	v, ok := m[outputKey]
+	if !ok {
+		return "", fmt.Errorf("recorded output of node=%s has no output key=%s", nodeKey, outputKey)
+	}
+	return string(v), nil
+}
+
+// templateStub renders the output from the node input decoded from json, the output being the text itself
+// if the node outputs a string, the text parsed as json otherwise.
+func templateStub(nodeKey, text string, outputType reflect.Type) (model.NodeStubFunc, error) {
+	tpl, err := template.New(nodeKey).Funcs(template.FuncMap{
+		"json": func(v any) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse template of node=%s failed, err=%w", nodeKey, err)
+	}
+
+	return func(ctx context.Context, input any) (any, error) {
+		b, err := json.Marshal(input)
+		if err != nil {
+			return nil, fmt.Errorf("marshal input of node=%s failed, err=%w", nodeKey, err)
+		}
+		var data any
+		if err = json.Unmarshal(b, &data); err != nil {
+			return nil, fmt.Errorf("unmarshal input of node=%s failed, err=%w", nodeKey, err)
+		}
+
+		sb := &strings.Builder{}
+		if err = tpl.Execute(sb, data); err != nil {
+			return nil, fmt.Errorf("execute template of node=%s failed, err=%w", nodeKey, err)
+		}
+
+		if outputType == nil {
+			return sb.String(), nil
+		}
+		if outputType.Kind() == reflect.String {
+			return reflect.ValueOf(sb.String()).Convert(outputType).Interface(), nil
+		}
+		return unmarshalNodeValue(nodeKey, sb.String(), outputType)
+	}, nil
+}
@@ -0,0 +1,190 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/compose"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/devops/internal/model"
+	devmodel "github.com/cloudwego/eino-ext/devops/model"
+)
+
+func Test_debugServiceImpl_DebugRun_Stubs(t *testing.T) {
+	graphID := addDebugRunTestGraph(t)
+
+	svc := newDebugService()
+	threadID, err := svc.CreateDebugThread(context.Background(), graphID)
+	assert.Nil(t, err)
+
+	var debugID string
+	run := func(input string, stubs ...*devmodel.NodeStub) (last *model.NodeDebugState, err error) {
+		var (
+			stateCh chan *model.NodeDebugState
+			errCh   chan error
+		)
+		debugID, stateCh, errCh, err = svc.DebugRun(context.Background(), &model.DebugRunMeta{
+			GraphID:  graphID,
+			ThreadID: threadID,
+			FromNode: compose.START,
+			Stubs:    stubs,
+		}, input)
+		if err != nil {
+			return nil, err
+		}
+
+		for state := range stateCh {
+			last = state
+		}
+		for e := range errCh {
+			err = e
+		}
+		return last, err
+	}
+
+	t.Run("fixed", func(t *testing.T) {
+		last, err := run(`"a b c"`, &devmodel.NodeStub{NodeKey: "split", Kind: devmodel.StubKindFixed, Output: `"stubbed"`})
+		assert.Nil(t, err)
+		if assert.NotNil(t, last) {
+			assert.Equal(t, devmodel.StubKindFixed, last.Stub)
+			assert.Equal(t, `"a b c"`, last.Input)
+			assert.Equal(t, `"stubbed"`, last.Output)
+		}
+
+		stubs, err := svc.GetStubbedNodes(context.Background(), graphID, threadID)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]devmodel.StubKind{"split": devmodel.StubKindFixed}, stubs)
+	})
+
+	t.Run("template", func(t *testing.T) {
+		last, err := run(`"a b c"`, &devmodel.NodeStub{NodeKey: "split", Kind: devmodel.StubKindTemplate, Template: `{{.}}!`})
+		assert.Nil(t, err)
+		if assert.NotNil(t, last) {
+			assert.Equal(t, devmodel.StubKindTemplate, last.Stub)
+			assert.Equal(t, `"a b c!"`, last.Output)
+		}
+	})
+
+	t.Run("recorded", func(t *testing.T) {
+		// a thread of its own, so that the run below is the latest one
+		threadID, err = svc.CreateDebugThread(context.Background(), graphID)
+		assert.Nil(t, err)
+
+		last, err := run(`"x y"`)
+		assert.Nil(t, err)
+		if assert.NotNil(t, last) {
+			assert.Empty(t, last.Stub)
+		}
+		stubs, err := svc.GetStubbedNodes(context.Background(), graphID, threadID)
+		assert.Nil(t, err)
+		assert.Empty(t, stubs)
+
+		last, err = run(`"z"`, &devmodel.NodeStub{NodeKey: "split", Kind: devmodel.StubKindRecorded})
+		assert.Nil(t, err)
+		if assert.NotNil(t, last) {
+			assert.Equal(t, devmodel.StubKindRecorded, last.Stub)
+			assert.Equal(t, `"x y"`, last.Output)
+		}
+
+		record, exist, err := svc.GetDebugRun(context.Background(), graphID, threadID, debugID)
+		assert.Nil(t, err)
+		if assert.True(t, exist) {
+			assert.Len(t, record.Stubs, 1)
+			if assert.Len(t, record.States, 1) {
+				assert.Equal(t, devmodel.StubKindRecorded, record.States[0].Stub)
+			}
+		}
+
+		_, err = run(`"z"`, &devmodel.NodeStub{NodeKey: "split", Kind: devmodel.StubKindRecorded, RunID: "unknown"})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := run(`"a"`, &devmodel.NodeStub{NodeKey: "unknown", Kind: devmodel.StubKindFixed, Output: `"a"`})
+		assert.NotNil(t, err)
+		_, err = run(`"a"`, &devmodel.NodeStub{NodeKey: "split", Kind: devmodel.StubKindFixed, Output: `{`})
+		assert.NotNil(t, err)
+		_, err = run(`"a"`, &devmodel.NodeStub{NodeKey: "split", Kind: devmodel.StubKindTemplate, Template: `{{`})
+		assert.NotNil(t, err)
+		_, err = svc.GetStubbedNodes(context.Background(), graphID, "unknown")
+		assert.NotNil(t, err)
+	})
+}
+
+func Test_debugServiceImpl_DebugRun_RecordedStubWithOutputKey(t *testing.T) {
+	g := compose.NewGraph[string, map[string]any]()
+	err := g.AddLambdaNode("upper", compose.InvokableLambda(func(ctx context.Context, input string) (string, error) {
+		return strings.ToUpper(input), nil
+	}), compose.WithOutputKey("upper"))
+	assert.Nil(t, err)
+	err = g.AddEdge(compose.START, "upper")
+	assert.Nil(t, err)
+	err = g.AddEdge("upper", compose.END)
+	assert.Nil(t, err)
+
+	tc := &debugRunTestCallback{}
+	_, err = g.Compile(context.Background(), compose.WithGraphCompileCallbacks(tc))
+	assert.Nil(t, err)
+
+	containerSVC := ContainerSVC
+	t.Cleanup(func() { ContainerSVC = containerSVC })
+	ContainerSVC = newContainerService()
+	graphID, err := ContainerSVC.AddGraphInfo("output_key_graph", tc.gi)
+	assert.Nil(t, err)
+
+	svc := newDebugService()
+	threadID, err := svc.CreateDebugThread(context.Background(), graphID)
+	assert.Nil(t, err)
+
+	run := func(input string, stubs ...*devmodel.NodeStub) (last *model.NodeDebugState, err error) {
+		_, stateCh, errCh, err := svc.DebugRun(context.Background(), &model.DebugRunMeta{
+			GraphID:  graphID,
+			ThreadID: threadID,
+			FromNode: compose.START,
+			Stubs:    stubs,
+		}, input)
+		if err != nil {
+			return nil, err
+		}
+
+		for state := range stateCh {
+			last = state
+		}
+		for e := range errCh {
+			err = e
+		}
+		return last, err
+	}
+
+	last, err := run(`"a"`)
+	assert.Nil(t, err)
+	if assert.NotNil(t, last) {
+		assert.Equal(t, `{"upper":"A"}`, last.Output)
+	}
+
+	// the recorded output is the one of the node itself, wrapped in the output key once more by the stub
+	last, err = run(`"b"`, &devmodel.NodeStub{NodeKey: "upper", Kind: devmodel.StubKindRecorded})
+	assert.Nil(t, err)
+	if assert.NotNil(t, last) {
+		assert.Equal(t, devmodel.StubKindRecorded, last.Stub)
+		assert.Equal(t, `{"upper":"A"}`, last.Output)
+	}
+}
@@ -85,6 +85,10 @@ type Node struct {
 
 	AllowOperate bool `json:"allow_operate"` //  used to indicate whether the node can be operated on
 
+	// Stub: the kind of stub the node was replaced with in the latest run of a debug thread,
+	// set in the canvas of the thread only.
+	Stub StubKind `json:"stub,omitempty"`
+
 	Extra map[string]any `json:"extra,omitempty"` // used to store extra information
 }
 
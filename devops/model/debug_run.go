@@ -0,0 +1,102 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"context"
+)
+
+// DebugRun is the record of a debug run: its input, the state of every node it went through,
+// and how it ended.
+type DebugRun struct {
+	// ID: the debug id of the run, as returned in its SSE events.
+	ID       string `json:"id"`
+	GraphID  string `json:"graph_id"`
+	ThreadID string `json:"thread_id"`
+	FromNode string `json:"from_node"`
+	Mode     string `json:"mode,omitempty"`
+	// Input: the user input of the run, json marshal string.
+	Input string `json:"input"`
+	// ReplayOf: the id of the run this one replays, if any.
+	ReplayOf string `json:"replay_of,omitempty"`
+	// Stubs: the nodes replaced with canned outputs in the run.
+	Stubs []*NodeStub `json:"stubs,omitempty"`
+
+	// States: the final state of each node, in the order they were reported. Stream chunks are not recorded.
+	States []*NodeDebugState `json:"states,omitempty"`
+	// Error: the error that ended the run, if any.
+	Error string `json:"error,omitempty"`
+
+	StartTimeMS int64 `json:"start_time_ms"`
+	EndTimeMS   int64 `json:"end_time_ms"`
+}
+
+type NodeDebugState struct {
+	NodeKey string `json:"node_key"`
+
+	Input     string `json:"input,omitempty"`
+	Output    string `json:"output,omitempty"`
+	Error     string `json:"error,omitempty"`
+	ErrorType string `json:"error_type,omitempty"`
+	// Stub: the kind of stub the node was replaced with, empty if the node really ran.
+	Stub StubKind `json:"stub,omitempty"`
+
+	Metrics NodeDebugMetrics `json:"metrics"`
+}
+
+type NodeDebugMetrics struct {
+	PromptTokens     int64 `json:"prompt_tokens,omitempty"`
+	CompletionTokens int64 `json:"completion_tokens,omitempty"`
+
+	InvokeTimeMS     int64 `json:"invoke_time_ms,omitempty"`
+	CompletionTimeMS int64 `json:"completion_time_ms,omitempty"`
+}
+
+type StubKind string
+
+const (
+	// StubKindFixed: the output is the given json.
+	StubKindFixed StubKind = "fixed"
+	// StubKindRecorded: the output is the one of the node in a recorded run.
+	StubKindRecorded StubKind = "recorded"
+	// StubKindTemplate: the output is rendered from the node input by a text/template.
+	StubKindTemplate StubKind = "template"
+)
+
+// NodeStub replaces a node of a debug run with a canned output, e.g. to skip the calls of a chat model.
+type NodeStub struct {
+	NodeKey string   `json:"node_key"`
+	Kind    StubKind `json:"kind"`
+	// Output: the output of a fixed stub, json marshal string.
+	Output string `json:"output,omitempty"`
+	// RunID: the run a recorded stub takes the output from, the latest run of the thread where the node
+	// succeeded if empty.
+	RunID string `json:"run_id,omitempty"`
+	// Template: the text/template of a template stub, executed on the node input decoded from json, e.g.
+	// {{.content}}. The result is the output if the node outputs a string, its json marshal string otherwise.
+	Template string `json:"template,omitempty"`
+}
+
+// DebugRunStore persists the debug runs, see github.com/cloudwego/eino-ext/devops/store for implementations.
+type DebugRunStore interface {
+	// SaveDebugRun creates or overwrites the run with the same id.
+	SaveDebugRun(ctx context.Context, run *DebugRun) error
+	// GetDebugRun returns the run of the id, exist being false if there is none.
+	GetDebugRun(ctx context.Context, runID string) (run *DebugRun, exist bool, err error)
+	// ListDebugRuns returns the runs of a debug thread, in the order they started.
+	ListDebugRuns(ctx context.Context, graphID, threadID string) (runs []*DebugRun, err error)
+}
@@ -19,7 +19,9 @@ package devops
 import (
 	"reflect"
 
+	"github.com/cloudwego/eino-ext/devops/eval"
 	"github.com/cloudwego/eino-ext/devops/internal/model"
+	devmodel "github.com/cloudwego/eino-ext/devops/model"
 )
 
 // WithDevServerPort sets dev server port, default to 52538
@@ -29,6 +31,53 @@ func WithDevServerPort(port string) model.DevOption {
 	}
 }
 
+// WithDevServerBindAddress sets the host the dev server listens on, e.g. 127.0.0.1, default to all the interfaces.
+func WithDevServerBindAddress(addr string) model.DevOption {
+	return func(o *model.DevOpt) {
+		o.DevServerBindAddress = addr
+	}
+}
+
+// WithAuthToken requires every request to the dev server to carry the token,
+// as an `Authorization: Bearer <token>` header, or an access_token query parameter for SSE clients that can not set headers.
+func WithAuthToken(token string) model.DevOption {
+	return func(o *model.DevOpt) {
+		o.AuthToken = token
+	}
+}
+
+// WithCORSAllowedOrigins sets the origins allowed to call the dev server from a browser, e.g. http://localhost:3000,
+// default to any origin.
+func WithCORSAllowedOrigins(origins ...string) model.DevOption {
+	return func(o *model.DevOpt) {
+		o.CORSAllowedOrigins = origins
+	}
+}
+
+// WithDebugRunStore sets the store of the debug run history, default to an in-memory store.
+// See github.com/cloudwego/eino-ext/devops/store for a local file store, and the store/sqlite module for a
+// SQLite one, which requires cgo.
+func WithDebugRunStore(store devmodel.DebugRunStore) model.DevOption {
+	return func(o *model.DevOpt) {
+		o.DebugRunStore = store
+	}
+}
+
+// WithEvaluators registers evaluators that can be chosen by name when evaluating a graph on a dataset,
+// besides the built-in exact_match, json_path and regex, e.g. an eval.LLMJudge.
+func WithEvaluators(evaluators ...eval.Evaluator) model.DevOption {
+	return func(o *model.DevOpt) {
+		o.Evaluators = append(o.Evaluators, evaluators...)
+	}
+}
+
+// WithEvalReportDir sets the directory the evaluation reports are written to, default to a directory in os.TempDir().
+func WithEvalReportDir(dir string) model.DevOption {
+	return func(o *model.DevOpt) {
+		o.EvalReportDir = dir
+	}
+}
+
 // AppendType registers a concrete type that can be chosen as an implementation of an interface
 // during mock debugging input in the Eino Dev plugin. The identifier is the type.String() value,
 // and some generic types are also registered in github.com/cloudwego/eino-ext/devops/internal/model/types.go:registeredTypes,
@@ -0,0 +1,189 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino-ext/devops/model"
+)
+
+var _ model.DebugRunStore = &FileStore{}
+
+const fileExt = ".json"
+
+// FileStore keeps each debug run as a json file in a local directory, so that the history survives restarts.
+type FileStore struct {
+	mu  sync.RWMutex
+	dir string
+	// threads: the ids of the runs of each thread, so that listing them only reads their own files
+	threads map[threadKey][]string
+	// runThreads: runID vs the thread of the run
+	runThreads map[string]threadKey
+}
+
+type threadKey struct {
+	graphID  string
+	threadID string
+}
+
+// NewFileStore returns a FileStore writing to dir, which is created if needed.
+// The runs already in dir are read once, to index them by thread.
+func NewFileStore(dir string) (*FileStore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("dir is empty")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create dir failed, err=%w", err)
+	}
+
+	f := &FileStore{
+		dir:        dir,
+		threads:    make(map[threadKey][]string),
+		runThreads: make(map[string]threadKey),
+	}
+	if err := f.buildIndex(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (f *FileStore) buildIndex() error {
+	entries, err := os.ReadDir(f.dir)
+	if err != nil {
+		return fmt.Errorf("read dir failed, err=%w", err)
+	}
+
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != fileExt {
+			continue
+		}
+
+		b, err := os.ReadFile(filepath.Join(f.dir, e.Name()))
+		if err != nil {
+			return fmt.Errorf("read run failed, err=%w", err)
+		}
+		run, err := unmarshalRun(b)
+		if err != nil {
+			return fmt.Errorf("decode %s failed, err=%w", e.Name(), err)
+		}
+		f.index(run)
+	}
+
+	return nil
+}
+
+// index adds the run to the runs of its thread, if not yet indexed.
+func (f *FileStore) index(run *model.DebugRun) {
+	if _, ok := f.runThreads[run.ID]; ok {
+		return
+	}
+	key := threadKey{graphID: run.GraphID, threadID: run.ThreadID}
+	f.runThreads[run.ID] = key
+	f.threads[key] = append(f.threads[key], run.ID)
+}
+
+func (f *FileStore) SaveDebugRun(ctx context.Context, run *model.DebugRun) error {
+	path, err := f.path(run.ID)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// write then rename, so that a crash never leaves a truncated run behind
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, b, 0o644); err != nil {
+		return fmt.Errorf("write run failed, err=%w", err)
+	}
+	if err = os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("write run failed, err=%w", err)
+	}
+	f.index(run)
+
+	return nil
+}
+
+func (f *FileStore) GetDebugRun(ctx context.Context, runID string) (*model.DebugRun, bool, error) {
+	path, err := f.path(runID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	f.mu.RLock()
+	b, err := os.ReadFile(path)
+	f.mu.RUnlock()
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("read run failed, err=%w", err)
+	}
+
+	run, err := unmarshalRun(b)
+	if err != nil {
+		return nil, false, fmt.Errorf("decode run=%s failed, err=%w", runID, err)
+	}
+
+	return run, true, nil
+}
+
+func (f *FileStore) ListDebugRuns(ctx context.Context, graphID, threadID string) ([]*model.DebugRun, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	runIDs := f.threads[threadKey{graphID: graphID, threadID: threadID}]
+	runs := make([]*model.DebugRun, 0, len(runIDs))
+	for _, runID := range runIDs {
+		b, err := os.ReadFile(filepath.Join(f.dir, runID+fileExt))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read run failed, err=%w", err)
+		}
+		run, err := unmarshalRun(b)
+		if err != nil {
+			return nil, fmt.Errorf("decode run=%s failed, err=%w", runID, err)
+		}
+		runs = append(runs, run)
+	}
+	sortRuns(runs)
+
+	return runs, nil
+}
+
+func (f *FileStore) path(runID string) (string, error) {
+	if runID == "" || strings.ContainsAny(runID, `/\`) || runID == "." || runID == ".." {
+		return "", fmt.Errorf("invalid run id=%q", runID)
+	}
+	return filepath.Join(f.dir, runID+fileExt), nil
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package store provides the implementations of model.DebugRunStore to keep the debug run history.
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/cloudwego/eino-ext/devops/model"
+)
+
+var _ model.DebugRunStore = &MemoryStore{}
+
+// MemoryStore keeps the debug runs in memory, they are lost once the process exits.
+type MemoryStore struct {
+	mu   sync.RWMutex
+	runs map[string][]byte
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		runs: make(map[string][]byte, 16),
+	}
+}
+
+// SaveDebugRun stores a copy of the run, later changes to the run are not reflected.
+func (m *MemoryStore) SaveDebugRun(ctx context.Context, run *model.DebugRun) error {
+	b, err := json.Marshal(run)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.runs[run.ID] = b
+
+	return nil
+}
+
+func (m *MemoryStore) GetDebugRun(ctx context.Context, runID string) (*model.DebugRun, bool, error) {
+	m.mu.RLock()
+	b, ok := m.runs[runID]
+	m.mu.RUnlock()
+	if !ok {
+		return nil, false, nil
+	}
+
+	run, err := unmarshalRun(b)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return run, true, nil
+}
+
+func (m *MemoryStore) ListDebugRuns(ctx context.Context, graphID, threadID string) ([]*model.DebugRun, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	runs := make([]*model.DebugRun, 0)
+	for _, b := range m.runs {
+		run, err := unmarshalRun(b)
+		if err != nil {
+			return nil, err
+		}
+		if run.GraphID == graphID && run.ThreadID == threadID {
+			runs = append(runs, run)
+		}
+	}
+	sortRuns(runs)
+
+	return runs, nil
+}
+
+func unmarshalRun(b []byte) (*model.DebugRun, error) {
+	run := &model.DebugRun{}
+	if err := json.Unmarshal(b, run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// sortRuns sorts the runs by start time, the id breaking ties to keep the order stable.
+func sortRuns(runs []*model.DebugRun) {
+	sort.Slice(runs, func(i, j int) bool {
+		if runs[i].StartTimeMS != runs[j].StartTimeMS {
+			return runs[i].StartTimeMS < runs[j].StartTimeMS
+		}
+		return runs[i].ID < runs[j].ID
+	})
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/cloudwego/eino-ext/devops"
+	"github.com/cloudwego/eino-ext/devops/store/sqlite"
+)
+
+func main() {
+	ctx := context.Background()
+
+	store, err := sqlite.NewStore(ctx, "debug_runs.db")
+	if err != nil {
+		log.Fatalf("open store failed, err=%v", err)
+	}
+	defer store.Close()
+
+	// the debug runs of the graphs compiled from now on are recorded to debug_runs.db
+	shutdown, err := devops.Init(ctx, devops.WithDebugRunStore(store))
+	if err != nil {
+		log.Fatalf("init devops failed, err=%v", err)
+	}
+	defer shutdown(ctx)
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt)
+	<-sigs
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package sqlite provides a model.DebugRunStore backed by a SQLite database. It requires cgo.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/cloudwego/eino-ext/devops/model"
+)
+
+var _ model.DebugRunStore = &Store{}
+
+const schema = `
+CREATE TABLE IF NOT EXISTS debug_runs (
+	id            TEXT PRIMARY KEY,
+	graph_id      TEXT NOT NULL,
+	thread_id     TEXT NOT NULL,
+	start_time_ms INTEGER NOT NULL,
+	run           TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_debug_runs_thread ON debug_runs (graph_id, thread_id, start_time_ms);
+`
+
+// Store keeps the debug runs in a SQLite database, the run itself stored as json.
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore opens the SQLite database at dsn, e.g. a file path, and creates the table if needed.
+func NewStore(ctx context.Context, dsn string) (*Store, error) {
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite failed, err=%w", err)
+	}
+
+	if _, err = db.ExecContext(ctx, schema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("create table failed, err=%w", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func (s *Store) SaveDebugRun(ctx context.Context, run *model.DebugRun) error {
+	b, err := json.Marshal(run)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx,
+		`INSERT OR REPLACE INTO debug_runs (id, graph_id, thread_id, start_time_ms, run) VALUES (?, ?, ?, ?, ?)`,
+		run.ID, run.GraphID, run.ThreadID, run.StartTimeMS, string(b))
+	if err != nil {
+		return fmt.Errorf("save run failed, err=%w", err)
+	}
+
+	return nil
+}
+
+func (s *Store) GetDebugRun(ctx context.Context, runID string) (*model.DebugRun, bool, error) {
+	var b string
+	err := s.db.QueryRowContext(ctx, `SELECT run FROM debug_runs WHERE id = ?`, runID).Scan(&b)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("get run failed, err=%w", err)
+	}
+
+	run := &model.DebugRun{}
+	if err = json.Unmarshal([]byte(b), run); err != nil {
+		return nil, false, fmt.Errorf("decode run=%s failed, err=%w", runID, err)
+	}
+
+	return run, true, nil
+}
+
+func (s *Store) ListDebugRuns(ctx context.Context, graphID, threadID string) ([]*model.DebugRun, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT run FROM debug_runs WHERE graph_id = ? AND thread_id = ? ORDER BY start_time_ms, id`,
+		graphID, threadID)
+	if err != nil {
+		return nil, fmt.Errorf("list runs failed, err=%w", err)
+	}
+	defer rows.Close()
+
+	runs := make([]*model.DebugRun, 0)
+	for rows.Next() {
+		var b string
+		if err = rows.Scan(&b); err != nil {
+			return nil, fmt.Errorf("list runs failed, err=%w", err)
+		}
+		run := &model.DebugRun{}
+		if err = json.Unmarshal([]byte(b), run); err != nil {
+			return nil, fmt.Errorf("decode run failed, err=%w", err)
+		}
+		runs = append(runs, run)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("list runs failed, err=%w", err)
+	}
+
+	return runs, nil
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/devops/model"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	dsn := filepath.Join(t.TempDir(), "runs.db")
+	s, err := NewStore(ctx, dsn)
+	assert.NoError(t, err)
+
+	_, ok, err := s.GetDebugRun(ctx, "r1")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	run := &model.DebugRun{
+		ID:          "r1",
+		GraphID:     "g1",
+		ThreadID:    "t1",
+		FromNode:    "start",
+		Input:       `"a"`,
+		States:      []*model.NodeDebugState{{NodeKey: "n1", Output: `"A"`}},
+		StartTimeMS: 2,
+		EndTimeMS:   3,
+	}
+	assert.NoError(t, s.SaveDebugRun(ctx, run))
+	assert.NoError(t, s.SaveDebugRun(ctx, &model.DebugRun{ID: "r0", GraphID: "g1", ThreadID: "t1", StartTimeMS: 1}))
+	assert.NoError(t, s.SaveDebugRun(ctx, &model.DebugRun{ID: "r2", GraphID: "g1", ThreadID: "t2", StartTimeMS: 1}))
+
+	run.Error = "failed"
+	assert.NoError(t, s.SaveDebugRun(ctx, run))
+	assert.NoError(t, s.Close())
+
+	// the history survives reopening the database
+	s, err = NewStore(ctx, dsn)
+	assert.NoError(t, err)
+	defer s.Close()
+
+	got, ok, err := s.GetDebugRun(ctx, "r1")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, run, got)
+
+	runs, err := s.ListDebugRuns(ctx, "g1", "t1")
+	assert.NoError(t, err)
+	if assert.Len(t, runs, 2) {
+		assert.Equal(t, "r0", runs[0].ID)
+		assert.Equal(t, "r1", runs[1].ID)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/devops/model"
+)
+
+func TestStores(t *testing.T) {
+	fileStore, err := NewFileStore(t.TempDir())
+	assert.NoError(t, err)
+
+	stores := map[string]model.DebugRunStore{
+		"memory": NewMemoryStore(),
+		"file":   fileStore,
+	}
+
+	for name, s := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			_, ok, err := s.GetDebugRun(ctx, "r1")
+			assert.NoError(t, err)
+			assert.False(t, ok)
+
+			runs := []*model.DebugRun{
+				{ID: "r2", GraphID: "g1", ThreadID: "t1", Input: `"b"`, StartTimeMS: 2},
+				{ID: "r1", GraphID: "g1", ThreadID: "t1", Input: `"a"`, StartTimeMS: 1},
+				{ID: "r3", GraphID: "g1", ThreadID: "t2", Input: `"c"`, StartTimeMS: 3},
+			}
+			for _, run := range runs {
+				assert.NoError(t, s.SaveDebugRun(ctx, run))
+			}
+
+			run := &model.DebugRun{
+				ID:       "r1",
+				GraphID:  "g1",
+				ThreadID: "t1",
+				Input:    `"a"`,
+				States: []*model.NodeDebugState{
+					{NodeKey: "n1", Input: `"a"`, Output: `"A"`, Metrics: model.NodeDebugMetrics{PromptTokens: 3}},
+				},
+				Error:       "failed",
+				StartTimeMS: 1,
+				EndTimeMS:   5,
+			}
+			assert.NoError(t, s.SaveDebugRun(ctx, run))
+
+			got, ok, err := s.GetDebugRun(ctx, "r1")
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, run, got)
+
+			list, err := s.ListDebugRuns(ctx, "g1", "t1")
+			assert.NoError(t, err)
+			if assert.Len(t, list, 2) {
+				assert.Equal(t, "r1", list[0].ID)
+				assert.Equal(t, "r2", list[1].ID)
+			}
+
+			list, err = s.ListDebugRuns(ctx, "g2", "t1")
+			assert.NoError(t, err)
+			assert.Empty(t, list)
+		})
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewFileStore(dir)
+	assert.NoError(t, err)
+	assert.NoError(t, s.SaveDebugRun(context.Background(), &model.DebugRun{ID: "r1", GraphID: "g1", ThreadID: "t1"}))
+
+	// the history survives a new store on the same dir
+	s, err = NewFileStore(dir)
+	assert.NoError(t, err)
+	_, ok, err := s.GetDebugRun(context.Background(), "r1")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	runs, err := s.ListDebugRuns(context.Background(), "g1", "t1")
+	assert.NoError(t, err)
+	if assert.Len(t, runs, 1) {
+		assert.Equal(t, "r1", runs[0].ID)
+	}
+
+	// the runs are listed from the index of their thread, other files are not read
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "other.json"), []byte("{"), 0o644))
+	runs, err = s.ListDebugRuns(context.Background(), "g1", "t1")
+	assert.NoError(t, err)
+	assert.Len(t, runs, 1)
+	_, err = NewFileStore(dir)
+	assert.Error(t, err)
+
+	assert.Error(t, s.SaveDebugRun(context.Background(), &model.DebugRun{ID: "../r1"}))
+	_, err = NewFileStore("")
+	assert.Error(t, err)
+}